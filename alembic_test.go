@@ -0,0 +1,80 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateAlembicMigration(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique().WithNote("Login name")).
+			AddColumn(NewColumn("created_at", "timestamptz").WithDefault("now()"))).
+		AddTable(NewTable("posts").WithSchema("blog").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "post_status").WithDefault("'draft'")).
+			AddColumn(NewColumn("tags", "text[]").WithNull()).
+			AddIndex(NewIndex("user_id", "status").WithType("btree"))).
+		AddRef(NewRef(ManyToOne).From("blog", "posts", "user_id").To("public", "users", "id").WithOnDelete(Cascade))
+
+	output := project.GenerateAlembicMigration("create blog schema")
+
+	expected := []string{
+		"# Code generated by dbml. DO NOT EDIT.\n\n\"\"\"create blog schema\n\nRevision ID: ",
+		"from alembic import op\nimport sqlalchemy as sa\n",
+		"\ndown_revision = None\nbranch_labels = None\ndepends_on = None\n",
+		"def upgrade() -> None:\n    op.create_table(\n        \"posts\",\n",
+		"        sa.Column(\"status\", sa.Enum(\"draft\", \"published\", name=\"post_status\"), nullable=False, server_default=sa.text(\"'draft'\")),\n",
+		"        sa.Column(\"tags\", sa.ARRAY(sa.Text()), nullable=True),\n",
+		"        sa.PrimaryKeyConstraint(\"id\"),\n        schema=\"blog\",\n    )\n",
+		"    op.create_index(\"idx_posts_user_id_status\", \"posts\", [\"user_id\", \"status\"], unique=False, postgresql_using=\"btree\", schema=\"blog\")\n",
+		"        sa.Column(\"id\", sa.BigInteger(), autoincrement=True, nullable=False),\n",
+		"        sa.Column(\"email\", sa.String(255), nullable=False, comment=\"Login name\"),\n",
+		"        sa.Column(\"created_at\", sa.DateTime(timezone=True), nullable=False, server_default=sa.text(\"now()\")),\n",
+		"        sa.UniqueConstraint(\"email\"),\n    )\n",
+		"    op.create_foreign_key(\"fk_posts_user_id\", \"posts\", \"users\", [\"user_id\"], [\"id\"], source_schema=\"blog\", ondelete=\"CASCADE\")\n",
+		"def downgrade() -> None:\n    op.drop_constraint(\"fk_posts_user_id\", \"posts\", type_=\"foreignkey\", schema=\"blog\")\n" +
+			"    op.drop_table(\"users\")\n    op.drop_table(\"posts\", schema=\"blog\")\n" +
+			"    sa.Enum(name=\"post_status\").drop(op.get_bind(), checkfirst=True)\n",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Index(output, "op.create_table(\n        \"users\"") > strings.Index(output, "op.create_foreign_key") {
+		t.Error("Expected foreign keys to be added after all tables")
+	}
+}
+
+func TestProject_GenerateAlembicMigration_Revision(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	first := project.GenerateAlembicMigration("init")
+	if first != project.GenerateAlembicMigration("init") {
+		t.Error("Expected the same project to produce the same revision file")
+	}
+	if first == project.GenerateAlembicMigration("initial tables") {
+		t.Error("Expected a different message to produce a different revision")
+	}
+
+	revision := first[strings.Index(first, "revision = \"")+len("revision = \""):]
+	revision = revision[:strings.Index(revision, "\"")]
+	if len(revision) != 12 || !strings.Contains(first, "Revision ID: "+revision+"\n") {
+		t.Errorf("Expected a 12 character revision ID in the header, got %q", revision)
+	}
+}
+
+func TestProject_GenerateAlembicMigration_Empty(t *testing.T) {
+	output := NewProject("empty").GenerateAlembicMigration("empty")
+
+	if !strings.Contains(output, "def upgrade() -> None:\n    pass\n") || !strings.Contains(output, "def downgrade() -> None:\n    pass\n") {
+		t.Errorf("Expected empty upgrade and downgrade bodies, got:\n%s", output)
+	}
+}
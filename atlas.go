@@ -0,0 +1,232 @@
+package dbml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// atlasBareType matches column types Atlas accepts unquoted, like int or varchar(255).
+var atlasBareType = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\(\s*\d+\s*(,\s*\d+\s*)?\))?$`)
+
+// atlasHCLEscaper escapes strings for HCL, including template sequences.
+var atlasHCLEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\t", `\t`,
+	"${", "$${",
+	"%{", "%%{",
+)
+
+// GenerateAtlasHCL generates an Atlas (ariga.io/atlas) HCL schema from a
+// Project. Every table becomes a table block with its columns, primary key,
+// foreign keys and indexes; unique columns become unique indexes. Types that
+// are not plain identifiers are passed through with sql("..."). Tables
+// excluded from DDL are skipped along with their foreign keys.
+func (p *Project) GenerateAtlasHCL() string {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n")
+
+	// Schemas, including those only referenced by tables or enums
+	schemas := map[string]bool{}
+	for name := range p.Schemas {
+		schemas[name] = true
+	}
+	tables := p.GetDDLTables()
+	for _, table := range tables {
+		schemas[table.Schema] = true
+	}
+	for _, enum := range p.Enums {
+		schemas[enum.Schema] = true
+	}
+	for _, name := range sortedKeys(schemas) {
+		b.WriteString(fmt.Sprintf("\nschema %s {\n", atlasString(name)))
+		if schema, ok := p.Schemas[name]; ok && schema.Comment != nil {
+			b.WriteString(fmt.Sprintf("  comment = %s\n", atlasString(*schema.Comment)))
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, key := range sortedKeys(p.Enums) {
+		enum := p.Enums[key]
+		values := make([]string, len(enum.Values))
+		for i, value := range enum.Values {
+			values[i] = atlasString(value)
+		}
+		b.WriteString(fmt.Sprintf("\nenum %s {\n", atlasString(enum.Name)))
+		b.WriteString(fmt.Sprintf("  schema = schema.%s\n", enum.Schema))
+		b.WriteString(fmt.Sprintf("  values = [%s]\n", strings.Join(values, ", ")))
+		b.WriteString("}\n")
+	}
+
+	foreignKeys := map[string][]*Ref{}
+	for _, ref := range p.allRefs() {
+		child, _, ok := ref.foreignKey()
+		if !ok || p.refTouchesMigrationTable(ref) || p.refTouchesExcludedTable(ref) {
+			continue
+		}
+		key := child.Schema + "." + child.Table
+		foreignKeys[key] = append(foreignKeys[key], ref)
+	}
+
+	for _, table := range tables {
+		b.WriteString("\n")
+		b.WriteString(p.atlasTable(table, foreignKeys[table.Schema+"."+table.Name]))
+	}
+
+	return b.String()
+}
+
+func (p *Project) atlasTable(t *Table, foreignKeys []*Ref) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("table %s {\n", atlasString(t.Name)))
+	b.WriteString(fmt.Sprintf("  schema = schema.%s\n", t.Schema))
+	if t.Note != nil {
+		b.WriteString(fmt.Sprintf("  comment = %s\n", atlasString(*t.Note)))
+	}
+
+	for _, col := range t.Columns {
+		b.WriteString(p.atlasColumn(col))
+	}
+
+	if pkColumns := t.primaryKeyColumns(); len(pkColumns) > 0 {
+		b.WriteString("  primary_key {\n")
+		b.WriteString(fmt.Sprintf("    columns = [%s]\n", atlasColumnRefs("column.", pkColumns)))
+		b.WriteString("  }\n")
+	}
+
+	for _, ref := range foreignKeys {
+		child, parent, _ := ref.foreignKey()
+		b.WriteString(fmt.Sprintf("  foreign_key %s {\n", atlasString(ref.foreignKeyName(child))))
+		b.WriteString(fmt.Sprintf("    columns = [%s]\n", atlasColumnRefs("column.", child.Columns)))
+		b.WriteString(fmt.Sprintf("    ref_columns = [%s]\n", atlasColumnRefs("table."+parent.Table+".column.", parent.Columns)))
+		if ref.OnDelete != nil {
+			b.WriteString(fmt.Sprintf("    on_delete = %s\n", atlasAction(*ref.OnDelete)))
+		}
+		if ref.OnUpdate != nil {
+			b.WriteString(fmt.Sprintf("    on_update = %s\n", atlasAction(*ref.OnUpdate)))
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, col := range t.Columns {
+		if col.Settings == nil {
+			continue
+		}
+		if col.Settings.Unique && !col.Settings.PrimaryKey {
+			b.WriteString(fmt.Sprintf("  index %s {\n", atlasString(t.Name+"_"+col.Name+"_key")))
+			b.WriteString("    unique = true\n")
+			b.WriteString(fmt.Sprintf("    columns = [column.%s]\n", col.Name))
+			b.WriteString("  }\n")
+		}
+		if col.Settings.Check != nil {
+			b.WriteString(fmt.Sprintf("  check %s {\n", atlasString(t.Name+"_"+col.Name+"_check")))
+			b.WriteString(fmt.Sprintf("    expr = %s\n", atlasString(*col.Settings.Check)))
+			b.WriteString("  }\n")
+		}
+	}
+
+	for _, idx := range t.Indexes {
+		if idx.PrimaryKey {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  index %s {\n", atlasString(idx.indexName(t))))
+		if idx.Unique {
+			b.WriteString("    unique = true\n")
+		}
+		if idx.Type != nil {
+			b.WriteString(fmt.Sprintf("    type = %s\n", strings.ToUpper(*idx.Type)))
+		}
+		for _, col := range idx.Columns {
+			b.WriteString("    on {\n")
+			if col.Name != nil {
+				b.WriteString(fmt.Sprintf("      column = column.%s\n", *col.Name))
+			} else if col.Expression != nil {
+				b.WriteString(fmt.Sprintf("      expr = %s\n", atlasString(*col.Expression)))
+			}
+			b.WriteString("    }\n")
+		}
+		b.WriteString("  }\n")
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (p *Project) atlasColumn(c *Column) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("  column %s {\n", atlasString(c.Name)))
+	b.WriteString(fmt.Sprintf("    null = %t\n", c.Settings != nil && c.Settings.Null && !c.Settings.PrimaryKey))
+	if enum := p.columnEnum(c); enum != nil && c.TypeAlias == nil && !strings.HasSuffix(c.Type, "[]") {
+		b.WriteString(fmt.Sprintf("    type = enum.%s\n", enum.Name))
+	} else {
+		b.WriteString(fmt.Sprintf("    type = %s\n", atlasType(c.sqlType())))
+	}
+	if c.Settings != nil && c.Settings.Default != nil && !c.Settings.Increment && !c.Settings.DatabaseDefault && c.ComputedAlias == nil {
+		b.WriteString(fmt.Sprintf("    default = %s\n", atlasDefault(sqlDefault(*c.Settings.Default, c.Settings.DefaultKind))))
+	}
+	if c.Note != nil {
+		b.WriteString(fmt.Sprintf("    comment = %s\n", atlasString(*c.Note)))
+	}
+	if c.ComputedAlias != nil {
+		b.WriteString("    as {\n")
+		b.WriteString(fmt.Sprintf("      expr = %s\n", atlasString(*c.ComputedAlias)))
+		b.WriteString("      type = STORED\n")
+		b.WriteString("    }\n")
+	}
+	if c.Settings != nil && c.Settings.Increment {
+		if p.sqlDialect() == DialectMySQL {
+			b.WriteString("    auto_increment = true\n")
+		} else {
+			b.WriteString("    identity {\n")
+			b.WriteString("      generated = BY_DEFAULT\n")
+			b.WriteString("    }\n")
+		}
+	}
+	b.WriteString("  }\n")
+
+	return b.String()
+}
+
+// atlasType returns the column type as an Atlas type expression.
+func atlasType(colType string) string {
+	if atlasBareType.MatchString(colType) {
+		return strings.ReplaceAll(colType, " ", "")
+	}
+	return fmt.Sprintf("sql(%s)", atlasString(colType))
+}
+
+// atlasDefault converts a DBML default value to an Atlas default expression.
+// Literals are passed as values; anything else is a raw SQL expression.
+func atlasDefault(value string) string {
+	switch {
+	case value == "true" || value == "false" || isNumeric(value):
+		return value
+	case len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"):
+		return atlasString(strings.ReplaceAll(value[1:len(value)-1], "''", "'"))
+	default:
+		return fmt.Sprintf("sql(%s)", atlasString(value))
+	}
+}
+
+// atlasAction returns the Atlas spelling of a referential action, e.g. SET_NULL.
+func atlasAction(action RefAction) string {
+	return strings.ReplaceAll(strings.ToUpper(string(action)), " ", "_")
+}
+
+func atlasColumnRefs(prefix string, columns []string) string {
+	refs := make([]string, len(columns))
+	for i, col := range columns {
+		refs[i] = prefix + col
+	}
+	return strings.Join(refs, ", ")
+}
+
+func atlasString(s string) string {
+	return `"` + atlasHCLEscaper.Replace(s) + `"`
+}
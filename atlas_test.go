@@ -0,0 +1,87 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateAtlasHCL(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("users").WithNote("Registered users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique().WithNote("Login name")).
+			AddColumn(NewColumn("created_at", "timestamp with time zone").WithDefault("now()"))).
+		AddTable(NewTable("posts").WithSchema("content").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "post_status").WithDefault("'draft'")).
+			AddColumn(NewColumn("views", "int").WithDefault("0").WithNull()).
+			AddIndex(NewIndex("user_id", "status")).
+			AddIndex(NewExpressionIndex("lower(status::text)").WithName("posts_status_lower").WithType("btree"))).
+		AddRef(NewRef(ManyToOne).From("content", "posts", "user_id").To("public", "users", "id").WithOnDelete(SetNull))
+
+	output := project.GenerateAtlasHCL()
+
+	expected := []string{
+		"// Code generated by dbml. DO NOT EDIT.\n",
+		"schema \"content\" {\n}\n",
+		"schema \"public\" {\n}\n",
+		"enum \"post_status\" {\n  schema = schema.public\n  values = [\"draft\", \"published\"]\n}\n",
+		"table \"users\" {\n  schema = schema.public\n  comment = \"Registered users\"\n",
+		"  column \"id\" {\n    null = false\n    type = bigint\n    identity {\n      generated = BY_DEFAULT\n    }\n  }\n",
+		"  column \"email\" {\n    null = false\n    type = varchar(255)\n    comment = \"Login name\"\n  }\n",
+		"  column \"created_at\" {\n    null = false\n    type = sql(\"timestamp with time zone\")\n    default = sql(\"now()\")\n  }\n",
+		"  primary_key {\n    columns = [column.id]\n  }\n",
+		"  index \"users_email_key\" {\n    unique = true\n    columns = [column.email]\n  }\n",
+		"table \"posts\" {\n  schema = schema.content\n",
+		"  column \"status\" {\n    null = false\n    type = enum.post_status\n    default = \"draft\"\n  }\n",
+		"  column \"views\" {\n    null = true\n    type = int\n    default = 0\n  }\n",
+		"  foreign_key \"fk_posts_user_id\" {\n    columns = [column.user_id]\n    ref_columns = [table.users.column.id]\n    on_delete = SET_NULL\n  }\n",
+		"  index \"idx_posts_user_id_status\" {\n    on {\n      column = column.user_id\n    }\n    on {\n      column = column.status\n    }\n  }\n",
+		"  index \"posts_status_lower\" {\n    type = BTREE\n    on {\n      expr = \"lower(status::text)\"\n    }\n  }\n",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestProject_GenerateAtlasHCL_MySQL(t *testing.T) {
+	project := NewProject("shop").WithDatabaseType("MySQL").
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("note", "text").WithDefault("'${user}'")))
+
+	output := project.GenerateAtlasHCL()
+
+	for _, want := range []string{"    auto_increment = true\n", "    default = \"$${user}\"\n"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "identity {") {
+		t.Errorf("Expected no identity block for MySQL, got:\n%s", output)
+	}
+}
+
+func TestProject_GenerateAtlasHCL_TypeAliasAndExcludedTables(t *testing.T) {
+	project := NewProject("shop").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithTypeAlias("int8").WithPrimaryKey())).
+		AddTable(NewTable("audit").WithSchema("legacy").WithExcludeFromDDL().
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")))
+
+	output := project.GenerateAtlasHCL()
+
+	if !strings.Contains(output, "    type = int8\n") {
+		t.Errorf("Expected the type alias to be used, got:\n%s", output)
+	}
+	for _, unwanted := range []string{`table "audit"`, `schema "legacy"`, "foreign_key"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected excluded table to be skipped, found %q in:\n%s", unwanted, output)
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package dbml
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
 // NewProject creates a new DBML project.
 func NewProject(name string) *Project {
 	return &Project{
 		Name:        name,
 		Tables:      make(map[string]*Table),
 		Enums:       make(map[string]*Enum),
+		Schemas:     make(map[string]*Schema),
 		TableGroups: []*TableGroup{},
 		Refs:        []*Ref{},
 	}
@@ -17,6 +26,14 @@ func (p *Project) WithDatabaseType(dbType string) *Project {
 	return p
 }
 
+// WithDefaultDatabaseType sets the database type only if none has been set.
+func (p *Project) WithDefaultDatabaseType(dbType string) *Project {
+	if p.DatabaseType == nil {
+		p.DatabaseType = &dbType
+	}
+	return p
+}
+
 // WithNote adds a note to the project.
 func (p *Project) WithNote(note string) *Project {
 	p.Note = &note
@@ -30,6 +47,60 @@ func (p *Project) AddTable(table *Table) *Project {
 	return p
 }
 
+// migrationTableSetting marks bookkeeping tables created by AddMigrationTable.
+const migrationTableSetting = "migration_table"
+
+// AddMigrationTable adds a Flyway-style migration history table to the
+// default schema and marks it with the migration_table setting.
+func (p *Project) AddMigrationTable(tableName string) *Project {
+	return p.AddTable(NewTable(tableName).
+		WithSetting(migrationTableSetting, "true").
+		AddColumn(NewColumn("id", "integer").WithPrimaryKey()).
+		AddColumn(NewColumn("version", "varchar(50)").WithNull()).
+		AddColumn(NewColumn("description", "varchar(200)")).
+		AddColumn(NewColumn("script", "varchar(1000)")).
+		AddColumn(NewColumn("checksum", "integer").WithNull()).
+		AddColumn(NewColumn("installed_by", "varchar(100)")).
+		AddColumn(NewColumn("installed_on", "timestamp").WithDefault("now()")).
+		AddColumn(NewColumn("execution_time", "integer")).
+		AddColumn(NewColumn("success", "boolean")))
+}
+
+// AutoCreateTableGroups adds a table group for each table name prefix found by
+// GroupTablesByPrefix. Groups are named after the capitalized prefix (auth ->
+// Auth); prefixes with fewer than minTablesPerGroup tables, and groups whose
+// name is already taken, are skipped.
+func (p *Project) AutoCreateTableGroups(separator string, minTablesPerGroup int) *Project {
+	existing := make(map[string]bool, len(p.TableGroups))
+	for _, group := range p.TableGroups {
+		existing[group.Name] = true
+	}
+
+	groups := p.GroupTablesByPrefix(separator)
+	for _, prefix := range sortedKeys(groups) {
+		tables := groups[prefix]
+		r, size := utf8.DecodeRuneInString(prefix)
+		name := string(unicode.ToUpper(r)) + prefix[size:]
+		if len(tables) < minTablesPerGroup || existing[name] {
+			continue
+		}
+		group := NewTableGroup(name)
+		for _, table := range tables {
+			group.AddTable(table.Schema, table.Name)
+		}
+		p.AddTableGroup(group)
+	}
+
+	return p
+}
+
+// SetTableOrder sets the order in which Generate emits tables. Keys are
+// "schema.table"; tables not listed follow in sorted key order.
+func (p *Project) SetTableOrder(keys ...string) *Project {
+	p.TableOrder = keys
+	return p
+}
+
 // AddEnum adds an enum to the project.
 func (p *Project) AddEnum(enum *Enum) *Project {
 	key := enum.Schema + "." + enum.Name
@@ -37,6 +108,54 @@ func (p *Project) AddEnum(enum *Enum) *Project {
 	return p
 }
 
+// AddSchema registers a schema with the project.
+func (p *Project) AddSchema(schema *Schema) *Project {
+	if p.Schemas == nil {
+		p.Schemas = make(map[string]*Schema)
+	}
+	p.Schemas[schema.Name] = schema
+	return p
+}
+
+// SchemaOption configures a schema registered with DefineSchema.
+type SchemaOption func(*Schema)
+
+// WithOwner sets the role that owns the schema.
+func WithOwner(owner string) SchemaOption {
+	return func(s *Schema) {
+		s.Owner = &owner
+	}
+}
+
+// WithComment sets the schema comment.
+func WithComment(comment string) SchemaOption {
+	return func(s *Schema) {
+		s.Comment = &comment
+	}
+}
+
+// WithSearchPath sets whether the schema is added to the search_path.
+func WithSearchPath(enabled bool) SchemaOption {
+	return func(s *Schema) {
+		s.SearchPath = enabled
+	}
+}
+
+// DefineSchema registers a schema configured by opts with the project.
+func (p *Project) DefineSchema(name string, opts ...SchemaOption) *Project {
+	schema := NewSchema(name)
+	for _, opt := range opts {
+		opt(schema)
+	}
+	return p.AddSchema(schema)
+}
+
+// GetSchema returns the registered schema with the given name.
+func (p *Project) GetSchema(name string) (*Schema, bool) {
+	schema, ok := p.Schemas[name]
+	return schema, ok
+}
+
 // AddRef adds a relationship to the project.
 func (p *Project) AddRef(ref *Ref) *Project {
 	p.Refs = append(p.Refs, ref)
@@ -51,6 +170,13 @@ func (p *Project) AddTableGroup(group *TableGroup) *Project {
 
 const defaultSchemaName = "public"
 
+// NewSchema creates a new schema.
+func NewSchema(name string) *Schema {
+	return &Schema{
+		Name: name,
+	}
+}
+
 // NewTable creates a new table.
 func NewTable(name string) *Table {
 	return &Table{
@@ -62,12 +188,28 @@ func NewTable(name string) *Table {
 	}
 }
 
+// NewTableE creates a new table, rejecting names that are not plain identifiers.
+func NewTableE(name string) (*Table, error) {
+	if err := validateIdentifier("Table.Name", name); err != nil {
+		return nil, err
+	}
+	return NewTable(name), nil
+}
+
 // WithSchema sets the schema for the table.
 func (t *Table) WithSchema(schema string) *Table {
 	t.Schema = schema
 	return t
 }
 
+// WithSchemaE sets the schema for the table, rejecting names that are not plain identifiers.
+func (t *Table) WithSchemaE(schema string) (*Table, error) {
+	if err := validateIdentifier("Table.Schema", schema); err != nil {
+		return nil, err
+	}
+	return t.WithSchema(schema), nil
+}
+
 // WithAlias sets an alias for the table.
 func (t *Table) WithAlias(alias string) *Table {
 	t.Alias = &alias
@@ -80,30 +222,192 @@ func (t *Table) WithNote(note string) *Table {
 	return t
 }
 
+// WithComment is an alias for WithNote using SQL vocabulary.
+// Both set the same Note field.
+func (t *Table) WithComment(comment string) *Table {
+	return t.WithNote(comment)
+}
+
+// WithSQLComment sets the comment emitted as COMMENT ON TABLE in SQL DDL.
+func (t *Table) WithSQLComment(comment string) *Table {
+	t.SQLComment = &comment
+	return t
+}
+
+// WithUnifiedComment sets both the DBML note and the SQL comment, keeping
+// the two from drifting apart.
+func (t *Table) WithUnifiedComment(comment string) *Table {
+	return t.WithNote(comment).WithSQLComment(comment)
+}
+
 // WithSetting adds a setting to the table.
 func (t *Table) WithSetting(key, value string) *Table {
 	t.Settings[key] = value
 	return t
 }
 
+// WithSettings merges settings into the table, overwriting existing keys.
+func (t *Table) WithSettings(settings map[string]string) *Table {
+	for key, value := range settings {
+		t.Settings[key] = value
+	}
+	return t
+}
+
 // WithHeaderColor sets the header color for the table.
 func (t *Table) WithHeaderColor(color string) *Table {
 	t.Settings["headercolor"] = color
 	return t
 }
 
+// WithStorageParameter sets a PostgreSQL storage parameter such as fillfactor.
+func (t *Table) WithStorageParameter(key, value string) *Table {
+	if t.StorageParams == nil {
+		t.StorageParams = make(map[string]string)
+	}
+	t.StorageParams[key] = value
+	return t
+}
+
+// WithRowLevelSecurity enables or disables PostgreSQL row-level security for the table.
+// LintRowLevelSecurityTenant warns when an enabled table has no tenant_id column.
+func (t *Table) WithRowLevelSecurity(enabled bool) *Table {
+	t.RowLevelSecurity = &enabled
+	return t
+}
+
 // AddColumn adds a column to the table.
 func (t *Table) AddColumn(column *Column) *Table {
 	t.Columns = append(t.Columns, column)
 	return t
 }
 
+// AddColumnBefore inserts a column before the named column. An empty name
+// inserts at the start; an unknown name appends to the end.
+func (t *Table) AddColumnBefore(column *Column, beforeName string) *Table {
+	pos := 0
+	if beforeName != "" {
+		pos = t.GetColumnIndex(beforeName)
+	}
+	if pos < 0 {
+		return t.AddColumn(column)
+	}
+	t.Columns = slices.Insert(t.Columns, pos, column)
+	return t
+}
+
+// AddColumnAfter inserts a column after the named column. An empty or
+// unknown name appends to the end.
+func (t *Table) AddColumnAfter(column *Column, afterName string) *Table {
+	pos := t.GetColumnIndex(afterName)
+	if afterName == "" || pos < 0 {
+		return t.AddColumn(column)
+	}
+	t.Columns = slices.Insert(t.Columns, pos+1, column)
+	return t
+}
+
+// WithIndexesFromConstraints moves column-level pk and unique flags into
+// indexes: one primary key index over all pk columns, composite when there
+// are several, and a unique index per unique column. The column flags are
+// cleared so that each constraint is declared once.
+func (t *Table) WithIndexesFromConstraints() *Table {
+	pkColumns := []string{}
+	for _, col := range t.Columns {
+		if col.Settings != nil && col.Settings.PrimaryKey {
+			pkColumns = append(pkColumns, col.Name)
+		}
+	}
+	if len(pkColumns) > 0 && !t.hasPrimaryKeyIndex() {
+		t.AddIndex(NewIndex(pkColumns...).WithPrimaryKey())
+		for _, col := range t.Columns {
+			if col.Settings != nil {
+				col.Settings.PrimaryKey = false
+			}
+		}
+	}
+
+	for _, col := range t.Columns {
+		if col.Settings == nil || !col.Settings.Unique {
+			continue
+		}
+		if !t.hasUniqueIndexOn(col.Name) {
+			t.AddIndex(NewIndex(col.Name).WithUnique())
+		}
+		col.Settings.Unique = false
+	}
+
+	return t
+}
+
+// hasUniqueIndexOn reports whether a single-column unique index covers the named column.
+func (t *Table) hasUniqueIndexOn(column string) bool {
+	for _, idx := range t.Indexes {
+		if idx.Unique && len(idx.Columns) == 1 && idx.ContainsColumn(column) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithUUIDPrimaryKey prepends an id uuid primary key defaulting to
+// gen_random_uuid().
+func (t *Table) WithUUIDPrimaryKey() *Table {
+	return t.AddColumnBefore(NewColumn("id", "uuid").WithPrimaryKey().WithDefault("gen_random_uuid()"), "")
+}
+
+// WithBigIntPrimaryKey prepends an id bigint auto-increment primary key.
+func (t *Table) WithBigIntPrimaryKey() *Table {
+	return t.AddColumnBefore(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement(), "")
+}
+
 // AddIndex adds an index to the table.
 func (t *Table) AddIndex(index *Index) *Table {
 	t.Indexes = append(t.Indexes, index)
 	return t
 }
 
+// AddIndexIfNotExists adds an index unless the table already has one over the
+// same columns, in the same order, regardless of its settings. If index is
+// named, an existing index only matches when it has the same name.
+func (t *Table) AddIndexIfNotExists(index *Index) *Table {
+	for _, existing := range t.Indexes {
+		if index.Name != nil && !ptrEqual(existing.Name, index.Name) {
+			continue
+		}
+		if indexColumnsEqual(existing.Columns, index.Columns) {
+			return t
+		}
+	}
+	return t.AddIndex(index)
+}
+
+// WithExcludeFromDDL keeps the table out of generated SQL DDL, e.g. for
+// tables managed by another tool. It is still generated as DBML.
+func (t *Table) WithExcludeFromDDL() *Table {
+	t.ExcludeFromDDL = true
+	return t
+}
+
+// WithForeignKeyIndex adds an idx_<table>_<column> index on a foreign key
+// column. It does nothing if the table has no such column.
+func (t *Table) WithForeignKeyIndex(fkColumnName string) *Table {
+	if t.GetColumnIndex(fkColumnName) < 0 {
+		return t
+	}
+	return t.AddIndex(NewIndex(fkColumnName).WithName("idx_" + t.Name + "_" + fkColumnName))
+}
+
+// AddCompositeUniqueIndex adds a named unique index over the given columns.
+func (t *Table) AddCompositeUniqueIndex(name string, columns ...string) *Table {
+	return t.AddIndex(NewIndex(columns...).WithUnique().WithName(name))
+}
+
+// AddCompositePrimaryKey adds a primary key index over the given columns.
+func (t *Table) AddCompositePrimaryKey(columns ...string) *Table {
+	return t.AddIndex(NewIndex(columns...).WithPrimaryKey())
+}
+
 // NewColumn creates a new column.
 func NewColumn(name, colType string) *Column {
 	return &Column{
@@ -113,6 +417,14 @@ func NewColumn(name, colType string) *Column {
 	}
 }
 
+// NewColumnE creates a new column, rejecting names that are not plain identifiers.
+func NewColumnE(name, colType string) (*Column, error) {
+	if err := validateIdentifier("Column.Name", name); err != nil {
+		return nil, err
+	}
+	return NewColumn(name, colType), nil
+}
+
 // WithPrimaryKey marks the column as a primary key.
 func (c *Column) WithPrimaryKey() *Column {
 	c.Settings.PrimaryKey = true
@@ -137,9 +449,53 @@ func (c *Column) WithIncrement() *Column {
 	return c
 }
 
-// WithDefault sets a default value for the column.
+// WithTypeAlias sets the SQL type behind the column's domain-specific type,
+// e.g. decimal(19,4) for money_amount. DBML keeps Type; SQL DDL uses the alias.
+func (c *Column) WithTypeAlias(canonical string) *Column {
+	c.TypeAlias = &canonical
+	return c
+}
+
+// WithDefault sets a default value for the column. The value is written as
+// SQL, so string literals keep their quotes ('draft'). Its DefaultKind is
+// detected from the value: quoted strings, numbers, true/false and null are
+// recognised, and anything else is treated as an expression. An empty value
+// is stored as an empty SQL string literal, two single quotes.
 func (c *Column) WithDefault(value string) *Column {
+	if value == "" {
+		value = "''"
+	}
 	c.Settings.Default = &value
+	c.Settings.DefaultKind = detectDefaultKind(value)
+	return c
+}
+
+// WithDefaultKind sets a default value with an explicit kind.
+func (c *Column) WithDefaultKind(value string, kind DefaultKind) *Column {
+	c.Settings.Default = &value
+	c.Settings.DefaultKind = kind
+	return c
+}
+
+func detectDefaultKind(value string) DefaultKind {
+	trimmed := strings.TrimSpace(value)
+	switch {
+	case trimmed == "" || (len(trimmed) >= 2 && strings.HasPrefix(trimmed, "'") && strings.HasSuffix(trimmed, "'")):
+		return DefaultKindString
+	case strings.EqualFold(trimmed, "true") || strings.EqualFold(trimmed, "false"):
+		return DefaultKindBoolean
+	case strings.EqualFold(trimmed, "null"):
+		return DefaultKindNull
+	}
+	if isNumeric(trimmed) {
+		return DefaultKindNumber
+	}
+	return DefaultKindExpression
+}
+
+// WithDatabaseDefault marks the column default as generated by the database.
+func (c *Column) WithDatabaseDefault() *Column {
+	c.Settings.DatabaseDefault = true
 	return c
 }
 
@@ -155,6 +511,27 @@ func (c *Column) WithNote(note string) *Column {
 	return c
 }
 
+// WithComment is an alias for WithNote using SQL vocabulary.
+// Both set the same Note field.
+func (c *Column) WithComment(comment string) *Column {
+	return c.WithNote(comment)
+}
+
+// WithComputedAlias makes the column a computed column derived from expr.
+func (c *Column) WithComputedAlias(expr string) *Column {
+	c.ComputedAlias = &expr
+	return c
+}
+
+// WithTagValue sets an ORM struct tag emitted by GenerateGoStructs.
+func (c *Column) WithTagValue(tag, value string) *Column {
+	if c.Tags == nil {
+		c.Tags = make(map[string]string)
+	}
+	c.Tags[tag] = value
+	return c
+}
+
 // WithRef adds an inline relationship to the column.
 func (c *Column) WithRef(relType RelType, schema, table, column string) *Column {
 	c.InlineRef = &InlineRef{
@@ -166,6 +543,14 @@ func (c *Column) WithRef(relType RelType, schema, table, column string) *Column
 	return c
 }
 
+// ToRef converts the inline ref into a standalone ref whose left endpoint is
+// the column that declares it.
+func (r *InlineRef) ToRef(fromSchema, fromTable, fromColumn string) *Ref {
+	return NewRef(r.Type).
+		From(fromSchema, fromTable, fromColumn).
+		To(r.Schema, r.Table, r.Column)
+}
+
 // NewIndex creates a new index.
 func NewIndex(columns ...string) *Index {
 	indexColumns := make([]IndexColumn, len(columns))
@@ -177,6 +562,16 @@ func NewIndex(columns ...string) *Index {
 	}
 }
 
+// NewIndexE creates a new index, rejecting column names that are not plain identifiers.
+func NewIndexE(columns ...string) (*Index, error) {
+	for i, col := range columns {
+		if err := validateIdentifier(fmt.Sprintf("Index.Columns[%d]", i), col); err != nil {
+			return nil, err
+		}
+	}
+	return NewIndex(columns...), nil
+}
+
 // NewExpressionIndex creates a new expression-based index.
 func NewExpressionIndex(expressions ...string) *Index {
 	indexColumns := make([]IndexColumn, len(expressions))
@@ -263,6 +658,12 @@ func (r *Ref) WithOnUpdate(action RefAction) *Ref {
 	return r
 }
 
+// WithDeferrable makes the foreign key constraint deferrable.
+func (r *Ref) WithDeferrable(mode DeferrableMode) *Ref {
+	r.Deferrable = &mode
+	return r
+}
+
 // WithColor sets the relationship color.
 func (r *Ref) WithColor(color string) *Ref {
 	r.Color = &color
@@ -278,18 +679,60 @@ func NewEnum(name string, values ...string) *Enum {
 	}
 }
 
+// NewEnumFromSlice creates a new enum in the given schema from a slice of
+// values. An empty schema means the default schema. The slice is copied.
+func NewEnumFromSlice(name, schema string, values []string) *Enum {
+	if schema == "" {
+		schema = defaultSchemaName
+	}
+	return &Enum{
+		Schema: schema,
+		Name:   name,
+		Values: slices.Clone(values),
+	}
+}
+
+// NewEnumE creates a new enum, rejecting names that are not plain identifiers.
+func NewEnumE(name string, values ...string) (*Enum, error) {
+	if err := validateIdentifier("Enum.Name", name); err != nil {
+		return nil, err
+	}
+	return NewEnum(name, values...), nil
+}
+
 // WithSchema sets the schema for the enum.
 func (e *Enum) WithSchema(schema string) *Enum {
 	e.Schema = schema
 	return e
 }
 
+// WithSchemaE sets the schema for the enum, rejecting names that are not plain
+// identifiers or, when the project registers schemas, are not registered in p.
+func (e *Enum) WithSchemaE(schema string, p *Project) (*Enum, error) {
+	if err := validateIdentifier("Enum.Schema", schema); err != nil {
+		return nil, err
+	}
+	if err := p.validateSchemaRegistered("Enum.Schema", schema); err != nil {
+		return nil, err
+	}
+	return e.WithSchema(schema), nil
+}
+
 // WithNote adds a note to the enum.
 func (e *Enum) WithNote(note string) *Enum {
 	e.Note = &note
 	return e
 }
 
+// SetValueNote attaches a note to one of the enum's values.
+func (e *Enum) SetValueNote(value, note string) *Enum {
+	if e.ValueNotes == nil {
+		e.ValueNotes = make(map[string]string)
+	}
+	e.ValueNotes[value] = note
+	return e
+}
+
 // NewTableGroup creates a new table group.
 func NewTableGroup(name string) *TableGroup {
 	return &TableGroup{
@@ -298,6 +741,12 @@ func NewTableGroup(name string) *TableGroup {
 	}
 }
 
+// WithParentGroup nests the group inside the named group.
+func (tg *TableGroup) WithParentGroup(name string) *TableGroup {
+	tg.ParentGroup = &name
+	return tg
+}
+
 // AddTable adds a table reference to the group.
 func (tg *TableGroup) AddTable(schema, name string) *TableGroup {
 	tg.Tables = append(tg.Tables, TableRef{
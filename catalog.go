@@ -0,0 +1,132 @@
+package dbml
+
+import "fmt"
+
+// catalogSensitivityTag is the column tag holding a column's data
+// sensitivity classification, e.g. "pii".
+const catalogSensitivityTag = "sensitivity"
+
+// ColumnCatalogEntry is one row of a flat column catalog (data dictionary).
+type ColumnCatalogEntry struct {
+	Default     *string
+	Check       *string
+	Schema      string
+	Table       string
+	Column      string
+	Type        string
+	Note        string
+	Sensitivity string // from the column's "sensitivity" tag
+	PrimaryKey  bool
+	Unique      bool
+	Nullable    bool
+	Increment   bool
+}
+
+// ExportColumnCatalog returns one entry per column, sorted by schema and
+// table and then in column order. PrimaryKey is set for columns covered by
+// a primary key index as well as for column-level primary keys.
+func (p *Project) ExportColumnCatalog() []ColumnCatalogEntry {
+	entries := []ColumnCatalogEntry{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		pkColumns := map[string]bool{}
+		for _, name := range table.primaryKeyColumns() {
+			pkColumns[name] = true
+		}
+
+		for _, col := range table.Columns {
+			entry := ColumnCatalogEntry{
+				Schema:      table.Schema,
+				Table:       table.Name,
+				Column:      col.Name,
+				Type:        col.Type,
+				Sensitivity: col.Tags[catalogSensitivityTag],
+				PrimaryKey:  pkColumns[col.Name],
+			}
+			if col.Note != nil {
+				entry.Note = *col.Note
+			}
+			if col.Settings != nil {
+				entry.Default = clonePtr(col.Settings.Default)
+				entry.Check = clonePtr(col.Settings.Check)
+				entry.Unique = col.Settings.Unique
+				entry.Nullable = col.Settings.Null
+				entry.Increment = col.Settings.Increment
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// ImportColumnCatalog adds the catalog's columns to the project, creating
+// tables as needed and reusing existing ones. Entries without a schema use
+// the default schema. Entries are checked before any are applied, so a
+// catalog with a missing name or a duplicate column leaves p unchanged.
+func (p *Project) ImportColumnCatalog(entries []ColumnCatalogEntry) error {
+	seen := map[string]bool{}
+	for i, entry := range entries {
+		if entry.Table == "" {
+			return &ValidationError{Field: fmt.Sprintf("ColumnCatalogEntry[%d].Table", i), Message: "table is required"}
+		}
+		if entry.Column == "" {
+			return &ValidationError{Field: fmt.Sprintf("ColumnCatalogEntry[%d].Column", i), Message: "column is required"}
+		}
+		key := catalogTableKey(entry) + "." + entry.Column
+		exists := false
+		if table, ok := p.Tables[catalogTableKey(entry)]; ok {
+			exists = table.GetColumnIndex(entry.Column) >= 0
+		}
+		if seen[key] || exists {
+			return &ValidationError{
+				Field:   fmt.Sprintf("ColumnCatalogEntry[%d].Column", i),
+				Message: fmt.Sprintf("column %s already exists", key),
+			}
+		}
+		seen[key] = true
+	}
+
+	if p.Tables == nil {
+		p.Tables = make(map[string]*Table)
+	}
+
+	for _, entry := range entries {
+		table, ok := p.Tables[catalogTableKey(entry)]
+		if !ok {
+			table = NewTable(entry.Table)
+			if entry.Schema != "" {
+				table.WithSchema(entry.Schema)
+			}
+			p.AddTable(table)
+		}
+
+		col := NewColumn(entry.Column, entry.Type)
+		col.Settings.PrimaryKey = entry.PrimaryKey && !table.hasPrimaryKeyIndexOn(entry.Column)
+		col.Settings.Unique = entry.Unique
+		col.Settings.Null = entry.Nullable
+		col.Settings.Increment = entry.Increment
+		if entry.Default != nil {
+			col.WithDefault(*entry.Default)
+		}
+		col.Settings.Check = clonePtr(entry.Check)
+		if entry.Note != "" {
+			col.WithNote(entry.Note)
+		}
+		if entry.Sensitivity != "" {
+			col.WithTagValue(catalogSensitivityTag, entry.Sensitivity)
+		}
+		table.AddColumn(col)
+	}
+
+	return nil
+}
+
+func catalogTableKey(entry ColumnCatalogEntry) string {
+	schema := entry.Schema
+	if schema == "" {
+		schema = defaultSchemaName
+	}
+	return schema + "." + entry.Table
+}
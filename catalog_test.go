@@ -0,0 +1,116 @@
+package dbml
+
+import "testing"
+
+func TestProject_ExportColumnCatalog(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar").WithUnique().WithNote("Login name").WithTagValue("sensitivity", "pii")).
+			AddColumn(NewColumn("age", "int").WithNull().WithCheck("age >= 0"))).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("at", "timestamp").WithDefault("now()")).
+			AddIndex(NewIndex("user_id", "at").WithPrimaryKey()))
+
+	entries := project.ExportColumnCatalog()
+
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(entries))
+	}
+
+	order := []string{"audit.events.user_id", "audit.events.at", "public.users.id", "public.users.email", "public.users.age"}
+	for i, want := range order {
+		got := entries[i].Schema + "." + entries[i].Table + "." + entries[i].Column
+		if got != want {
+			t.Errorf("Expected entry %d to be %s, got %s", i, want, got)
+		}
+	}
+
+	if !entries[0].PrimaryKey || !entries[1].PrimaryKey {
+		t.Error("Expected columns covered by a primary key index to be marked as primary key")
+	}
+	if entries[1].Default == nil || *entries[1].Default != "now()" {
+		t.Errorf("Expected default now(), got %v", entries[1].Default)
+	}
+
+	id := entries[2]
+	if !id.PrimaryKey || !id.Increment || id.Nullable || id.Type != "bigint" {
+		t.Errorf("Expected id bigint pk increment not null, got %+v", id)
+	}
+
+	email := entries[3]
+	if !email.Unique || email.Note != "Login name" || email.Sensitivity != "pii" {
+		t.Errorf("Expected email unique with note and pii sensitivity, got %+v", email)
+	}
+
+	age := entries[4]
+	if !age.Nullable || age.Check == nil || *age.Check != "age >= 0" {
+		t.Errorf("Expected nullable age with check, got %+v", age)
+	}
+}
+
+func TestProject_ImportColumnCatalog(t *testing.T) {
+	original := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar").WithUnique().WithNote("Login name").WithTagValue("sensitivity", "pii")).
+			AddColumn(NewColumn("age", "int").WithNull().WithCheck("age >= 0"))).
+		AddTable(NewTable("memberships").WithSchema("auth").
+			AddColumn(NewColumn("user_id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("team_id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("joined_at", "timestamp").WithDefault("now()")))
+
+	imported := NewProject("test")
+	if err := imported.ImportColumnCatalog(original.ExportColumnCatalog()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !imported.Equal(original) {
+		t.Errorf("Expected round trip to reproduce the project, got:\n%s", imported.Generate())
+	}
+}
+
+func TestProject_ImportColumnCatalog_ReusesTables(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	err := project.ImportColumnCatalog([]ColumnCatalogEntry{
+		{Table: "users", Column: "email", Type: "varchar"},
+		{Schema: "audit", Table: "events", Column: "id", Type: "int"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if names := project.Tables["public.users"].GetColumnNames(); len(names) != 2 || names[1] != "email" {
+		t.Errorf("Expected email appended to users, got %v", names)
+	}
+	if project.Tables["audit.events"] == nil {
+		t.Error("Expected audit.events to be created")
+	}
+}
+
+func TestProject_ImportColumnCatalog_Errors(t *testing.T) {
+	tests := map[string][]ColumnCatalogEntry{
+		"missing table":     {{Column: "id", Type: "int"}},
+		"missing column":    {{Table: "users", Type: "int"}},
+		"duplicate column":  {{Table: "users", Column: "email"}, {Table: "users", Column: "email"}},
+		"column exists":     {{Table: "users", Column: "id"}},
+		"later entry fails": {{Table: "posts", Column: "id"}, {Table: "users", Column: ""}},
+	}
+
+	for name, entries := range tests {
+		t.Run(name, func(t *testing.T) {
+			project := NewProject("test").
+				AddTable(NewTable("users").AddColumn(NewColumn("id", "int")))
+
+			if err := project.ImportColumnCatalog(entries); err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if len(project.Tables) != 1 || len(project.Tables["public.users"].Columns) != 1 {
+				t.Error("Expected project to be left unchanged")
+			}
+		})
+	}
+}
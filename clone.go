@@ -0,0 +1,234 @@
+package dbml
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the project.
+func (p *Project) Clone() *Project {
+	if p == nil {
+		return nil
+	}
+	clone := &Project{
+		Name:         p.Name,
+		DatabaseType: clonePtr(p.DatabaseType),
+		Note:         clonePtr(p.Note),
+		TableOrder:   slices.Clone(p.TableOrder),
+	}
+	if p.Tables != nil {
+		clone.Tables = make(map[string]*Table, len(p.Tables))
+		for key, table := range p.Tables {
+			clone.Tables[key] = table.Clone()
+		}
+	}
+	if p.Enums != nil {
+		clone.Enums = make(map[string]*Enum, len(p.Enums))
+		for key, enum := range p.Enums {
+			clone.Enums[key] = enum.Clone()
+		}
+	}
+	if p.Schemas != nil {
+		clone.Schemas = make(map[string]*Schema, len(p.Schemas))
+		for key, schema := range p.Schemas {
+			clone.Schemas[key] = schema.Clone()
+		}
+	}
+	if p.TableGroups != nil {
+		clone.TableGroups = make([]*TableGroup, len(p.TableGroups))
+		for i, group := range p.TableGroups {
+			clone.TableGroups[i] = group.Clone()
+		}
+	}
+	if p.Refs != nil {
+		clone.Refs = make([]*Ref, len(p.Refs))
+		for i, ref := range p.Refs {
+			clone.Refs[i] = ref.Clone()
+		}
+	}
+	return clone
+}
+
+// Clone returns a copy of the schema.
+func (s *Schema) Clone() *Schema {
+	if s == nil {
+		return nil
+	}
+	return &Schema{
+		Owner:      clonePtr(s.Owner),
+		Comment:    clonePtr(s.Comment),
+		Name:       s.Name,
+		SearchPath: s.SearchPath,
+	}
+}
+
+// Clone returns a deep copy of the table, including its columns and indexes.
+func (t *Table) Clone() *Table {
+	if t == nil {
+		return nil
+	}
+	clone := &Table{
+		Alias:            clonePtr(t.Alias),
+		Note:             clonePtr(t.Note),
+		RowLevelSecurity: clonePtr(t.RowLevelSecurity),
+		SQLComment:       clonePtr(t.SQLComment),
+		Settings:         maps.Clone(t.Settings),
+		StorageParams:    maps.Clone(t.StorageParams),
+		Schema:           t.Schema,
+		Name:             t.Name,
+		ExcludeFromDDL:   t.ExcludeFromDDL,
+	}
+	if t.Columns != nil {
+		clone.Columns = make([]*Column, len(t.Columns))
+		for i, col := range t.Columns {
+			clone.Columns[i] = col.Clone()
+		}
+	}
+	if t.Indexes != nil {
+		clone.Indexes = make([]*Index, len(t.Indexes))
+		for i, idx := range t.Indexes {
+			clone.Indexes[i] = idx.Clone()
+		}
+	}
+	return clone
+}
+
+// CloneAs returns a deep copy of the table named newName, e.g. to replicate a
+// template table per tenant. Inline refs from the table to itself are
+// renamed to point at the clone.
+func (t *Table) CloneAs(newName string) *Table {
+	clone := t.Clone()
+	if clone == nil {
+		return nil
+	}
+	for _, col := range clone.Columns {
+		if ref := col.InlineRef; ref != nil && ref.Schema == t.Schema && ref.Table == t.Name {
+			ref.Table = newName
+		}
+	}
+	clone.Name = newName
+	return clone
+}
+
+// Clone returns a deep copy of the column.
+func (c *Column) Clone() *Column {
+	if c == nil {
+		return nil
+	}
+	return &Column{
+		Settings:      c.Settings.clone(),
+		Note:          clonePtr(c.Note),
+		InlineRef:     c.InlineRef.Clone(),
+		ComputedAlias: clonePtr(c.ComputedAlias),
+		TypeAlias:     clonePtr(c.TypeAlias),
+		Tags:          maps.Clone(c.Tags),
+		Name:          c.Name,
+		Type:          c.Type,
+	}
+}
+
+func (s *ColumnSettings) clone() *ColumnSettings {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	clone.Default = clonePtr(s.Default)
+	clone.Check = clonePtr(s.Check)
+	return &clone
+}
+
+// Clone returns a deep copy of the ref.
+func (r *Ref) Clone() *Ref {
+	if r == nil {
+		return nil
+	}
+	return &Ref{
+		Name:       clonePtr(r.Name),
+		Left:       r.Left.Clone(),
+		Right:      r.Right.Clone(),
+		OnDelete:   clonePtr(r.OnDelete),
+		OnUpdate:   clonePtr(r.OnUpdate),
+		Color:      clonePtr(r.Color),
+		Deferrable: clonePtr(r.Deferrable),
+		Type:       r.Type,
+	}
+}
+
+// Clone returns a deep copy of the enum.
+func (e *Enum) Clone() *Enum {
+	if e == nil {
+		return nil
+	}
+	return &Enum{
+		Note:       clonePtr(e.Note),
+		ValueNotes: maps.Clone(e.ValueNotes),
+		Schema:     e.Schema,
+		Name:       e.Name,
+		Values:     slices.Clone(e.Values),
+	}
+}
+
+// Clone returns a deep copy of the table group.
+func (g *TableGroup) Clone() *TableGroup {
+	if g == nil {
+		return nil
+	}
+	return &TableGroup{
+		ParentGroup: clonePtr(g.ParentGroup),
+		Name:        g.Name,
+		Tables:      slices.Clone(g.Tables),
+	}
+}
+
+// Clone returns a deep copy of the endpoint.
+func (e *RefEndpoint) Clone() *RefEndpoint {
+	if e == nil {
+		return nil
+	}
+	return &RefEndpoint{
+		Schema:  e.Schema,
+		Table:   e.Table,
+		Columns: slices.Clone(e.Columns),
+	}
+}
+
+// Clone returns a copy of the inline ref.
+func (r *InlineRef) Clone() *InlineRef {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	return &clone
+}
+
+// Clone returns a deep copy of the index.
+func (i *Index) Clone() *Index {
+	if i == nil {
+		return nil
+	}
+	clone := &Index{
+		Type:       clonePtr(i.Type),
+		Name:       clonePtr(i.Name),
+		Note:       clonePtr(i.Note),
+		Unique:     i.Unique,
+		PrimaryKey: i.PrimaryKey,
+	}
+	if i.Columns != nil {
+		clone.Columns = make([]IndexColumn, len(i.Columns))
+		for j, col := range i.Columns {
+			clone.Columns[j] = IndexColumn{
+				Name:       clonePtr(col.Name),
+				Expression: clonePtr(col.Expression),
+			}
+		}
+	}
+	return clone
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,157 @@
+package dbml
+
+import (
+	"testing"
+)
+
+func TestRefEndpoint_Clone(t *testing.T) {
+	endpoint := &RefEndpoint{Schema: "public", Table: "posts", Columns: []string{"tenant_id", "user_id"}}
+
+	clone := endpoint.Clone()
+	if !clone.Equal(endpoint) {
+		t.Fatalf("Expected clone to equal original, got %+v", clone)
+	}
+
+	clone.Columns[0] = "org_id"
+	if endpoint.Columns[0] != "tenant_id" {
+		t.Error("Expected clone to have an independent Columns slice")
+	}
+
+	var nilEndpoint *RefEndpoint
+	if nilEndpoint.Clone() != nil {
+		t.Error("Expected nil endpoint to clone to nil")
+	}
+}
+
+func TestInlineRef_Clone(t *testing.T) {
+	ref := &InlineRef{Type: ManyToOne, Schema: "public", Table: "users", Column: "id"}
+
+	clone := ref.Clone()
+	if clone == ref || *clone != *ref {
+		t.Fatalf("Expected a distinct equal copy, got %+v", clone)
+	}
+
+	clone.Column = "uuid"
+	if ref.Column != "id" {
+		t.Error("Expected clone mutation not to affect the original")
+	}
+
+	var nilRef *InlineRef
+	if nilRef.Clone() != nil {
+		t.Error("Expected nil inline ref to clone to nil")
+	}
+}
+
+func TestIndex_Clone(t *testing.T) {
+	idx := NewIndex("tenant_id", "created_at").WithName("idx_events").WithType("btree").WithNote("hot path").WithUnique()
+	idx.Columns = append(idx.Columns, NewExpressionIndex("lower(email)").Columns...)
+
+	clone := idx.Clone()
+	if clone == idx || !clone.Equal(idx) {
+		t.Fatalf("Expected a distinct equal copy, got %+v", clone)
+	}
+
+	*clone.Name = "idx_other"
+	*clone.Columns[0].Name = "org_id"
+	*clone.Columns[2].Expression = "upper(email)"
+	clone.Columns[1] = NewIndex("updated_at").Columns[0]
+
+	if *idx.Name != "idx_events" {
+		t.Error("Expected clone name mutation not to affect the original")
+	}
+	if *idx.Columns[0].Name != "tenant_id" || *idx.Columns[1].Name != "created_at" {
+		t.Error("Expected clone column mutation not to affect the original")
+	}
+	if *idx.Columns[2].Expression != "lower(email)" {
+		t.Error("Expected clone expression mutation not to affect the original")
+	}
+
+	var nilIndex *Index
+	if nilIndex.Clone() != nil {
+		t.Error("Expected nil index to clone to nil")
+	}
+}
+
+func TestProject_Clone(t *testing.T) {
+	project := NewProject("shop").
+		WithNote("Shop database").
+		AddSchema(NewSchema("public")).
+		AddEnum(NewEnum("status", "active", "inactive")).
+		AddTable(NewTable("users").
+			WithAlias("u").
+			WithSetting("engine", "InnoDB").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithDefault("1").WithTagValue("gorm", "primaryKey")).
+			AddColumn(NewColumn("org_id", "bigint").WithRef(ManyToOne, "public", "orgs", "id")).
+			AddIndex(NewIndex("org_id"))).
+		AddRef(NewRef(ManyToOne).From("public", "users", "org_id").To("public", "orgs", "id").WithOnDelete(Cascade)).
+		AddTableGroup(NewTableGroup("core").AddTable("public", "users")).
+		SetTableOrder("public.users")
+
+	clone := project.Clone()
+	if clone == project || !clone.Equal(project) {
+		t.Fatal("Expected a distinct equal copy")
+	}
+
+	table := clone.Tables["public.users"]
+	table.Name = "accounts"
+	table.Settings["engine"] = "MyISAM"
+	*table.Columns[0].Settings.Default = "2"
+	table.Columns[0].Tags["gorm"] = "column:id"
+	table.Columns[1].InlineRef.Table = "teams"
+	table.Indexes[0].Columns[0] = NewIndex("id").Columns[0]
+	clone.Enums["public.status"].Values[0] = "enabled"
+	clone.Refs[0].Left.Columns[0] = "team_id"
+	clone.TableGroups[0].Tables[0].Name = "accounts"
+	clone.TableOrder[0] = "public.accounts"
+
+	original := project.Tables["public.users"]
+	if original.Name != "users" || original.Settings["engine"] != "InnoDB" {
+		t.Error("Expected table mutation not to affect the original")
+	}
+	if *original.Columns[0].Settings.Default != "1" || original.Columns[0].Tags["gorm"] != "primaryKey" {
+		t.Error("Expected column mutation not to affect the original")
+	}
+	if original.Columns[1].InlineRef.Table != "orgs" || *original.Indexes[0].Columns[0].Name != "org_id" {
+		t.Error("Expected inline ref and index mutation not to affect the original")
+	}
+	if project.Enums["public.status"].Values[0] != "active" || project.Refs[0].Left.Columns[0] != "org_id" {
+		t.Error("Expected enum and ref mutation not to affect the original")
+	}
+	if project.TableGroups[0].Tables[0].Name != "users" || project.TableOrder[0] != "public.users" {
+		t.Error("Expected table group and table order mutation not to affect the original")
+	}
+
+	var nilProject *Project
+	if nilProject.Clone() != nil {
+		t.Error("Expected nil project to clone to nil")
+	}
+}
+
+func TestTable_CloneAs(t *testing.T) {
+	base := NewTable("users").WithSchema("tenants").
+		AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+		AddColumn(NewColumn("manager_id", "int").WithNull().WithRef(ManyToOne, "tenants", "users", "id")).
+		AddColumn(NewColumn("team_id", "int").WithRef(ManyToOne, "tenants", "teams", "id")).
+		AddIndex(NewIndex("team_id"))
+
+	clone := base.CloneAs("acme_users")
+
+	if clone.Name != "acme_users" || clone.Schema != "tenants" {
+		t.Errorf("Expected tenants.acme_users, got %s.%s", clone.Schema, clone.Name)
+	}
+	if base.Name != "users" {
+		t.Errorf("Expected original name to be kept, got %s", base.Name)
+	}
+	if clone.Columns[1].InlineRef.Table != "acme_users" {
+		t.Errorf("Expected self-reference to point at the clone, got %s", clone.Columns[1].InlineRef.Table)
+	}
+	if clone.Columns[2].InlineRef.Table != "teams" || base.Columns[1].InlineRef.Table != "users" {
+		t.Error("Expected other refs and the original table to be unchanged")
+	}
+
+	clone.Columns[0].Type = "bigint"
+	clone.AddColumn(NewColumn("extra", "text"))
+	if base.Columns[0].Type != "int" || len(base.Columns) != 3 {
+		t.Error("Expected column changes on the clone not to affect the original")
+	}
+}
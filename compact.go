@@ -0,0 +1,32 @@
+package dbml
+
+// Compact returns a copy of the project without display-only metadata:
+// notes, table aliases, ref colors and table header colors are removed.
+// The original project is not modified.
+func (p *Project) Compact() *Project {
+	compact := p.Clone()
+	compact.Note = nil
+
+	for _, table := range compact.Tables {
+		table.Note = nil
+		table.Alias = nil
+		delete(table.Settings, "headercolor")
+		for _, col := range table.Columns {
+			col.Note = nil
+		}
+		for _, idx := range table.Indexes {
+			idx.Note = nil
+		}
+	}
+
+	for _, enum := range compact.Enums {
+		enum.Note = nil
+		enum.ValueNotes = nil
+	}
+
+	for _, ref := range compact.Refs {
+		ref.Color = nil
+	}
+
+	return compact
+}
@@ -0,0 +1,51 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_Compact(t *testing.T) {
+	project := NewProject("shop").
+		WithNote("Shop database").
+		AddEnum(NewEnum("status", "active").WithNote("Account status")).
+		AddTable(NewTable("users").
+			WithAlias("u").
+			WithNote("Registered users").
+			WithHeaderColor("#3498DB").
+			WithSetting("engine", "InnoDB").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithNote("Surrogate key")).
+			AddColumn(NewColumn("status", "status")).
+			AddIndex(NewIndex("status").WithNote("Filter by status"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id").WithColor("#FF0000"))
+
+	original := project.Generate()
+	before := project.Clone()
+	compact := project.Compact()
+	output := compact.Generate()
+
+	if err := compact.Validate(); err != nil {
+		t.Errorf("Expected compact project to validate, got: %v", err)
+	}
+
+	if len(output) >= len(original) {
+		t.Errorf("Expected compact output to be smaller, got %d >= %d bytes", len(output), len(original))
+	}
+
+	for _, unwanted := range []string{"Note", "note:", " as u", "headercolor", "color:"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected compact output not to contain %q, got:\n%s", unwanted, output)
+		}
+	}
+
+	if compact.Tables["public.users"].Settings["engine"] != "InnoDB" {
+		t.Error("Expected non-display settings to be kept")
+	}
+
+	if !project.Equal(before) {
+		t.Error("Expected Compact not to modify the original project")
+	}
+}
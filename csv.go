@@ -0,0 +1,47 @@
+package dbml
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+)
+
+// csvHeader is the header row written by GenerateCSV.
+var csvHeader = []string{"schema", "table", "column", "type", "primarykey", "unique", "nullable", "increment", "default", "check", "note"}
+
+// GenerateCSV generates the schema as RFC 4180 CSV with one row per column,
+// in the order of ExportColumnCatalog, under a header row. Flags are written
+// as true or false and a missing default, check or note as an empty field.
+func (p *Project) GenerateCSV() string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.UseCRLF = true
+
+	// Writes to a strings.Builder cannot fail
+	_ = w.Write(csvHeader)
+	for _, entry := range p.ExportColumnCatalog() {
+		_ = w.Write([]string{
+			entry.Schema,
+			entry.Table,
+			entry.Column,
+			entry.Type,
+			strconv.FormatBool(entry.PrimaryKey),
+			strconv.FormatBool(entry.Unique),
+			strconv.FormatBool(entry.Nullable),
+			strconv.FormatBool(entry.Increment),
+			csvOptional(entry.Default),
+			csvOptional(entry.Check),
+			entry.Note,
+		})
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+func csvOptional(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
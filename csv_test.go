@@ -0,0 +1,48 @@
+package dbml
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateCSV(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar").WithUnique().WithNote("Login name, \"primary\" contact")).
+			AddColumn(NewColumn("age", "int").WithNull().WithCheck("age >= 0").WithDefault("0")))
+
+	output := project.GenerateCSV()
+
+	if !strings.HasPrefix(output, "schema,table,column,type,primarykey,unique,nullable,increment,default,check,note\r\n") {
+		t.Errorf("Expected header row, got:\n%s", output)
+	}
+
+	expected := []string{
+		"public,users,id,bigint,true,false,false,true,,,\r\n",
+		"public,users,email,varchar,false,true,false,false,,,\"Login name, \"\"primary\"\" contact\"\r\n",
+		"public,users,age,int,false,false,true,false,0,age >= 0,\r\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+		}
+	}
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, got %v", err)
+	}
+	if len(records) != 4 || records[2][10] != "Login name, \"primary\" contact" {
+		t.Errorf("Expected 4 records with the note intact, got %v", records)
+	}
+}
+
+func TestProject_GenerateCSV_Empty(t *testing.T) {
+	output := NewProject("test").GenerateCSV()
+
+	if strings.Count(output, "\r\n") != 1 {
+		t.Errorf("Expected only the header row, got:\n%s", output)
+	}
+}
@@ -0,0 +1,106 @@
+package dbml
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dbtSources is the body of a dbt sources.yml file, after its version line.
+type dbtSources struct {
+	Sources []dbtSource `yaml:"sources"`
+}
+
+type dbtSource struct {
+	Name        string     `yaml:"name"`
+	Schema      string     `yaml:"schema"`
+	Description string     `yaml:"description,omitempty"`
+	Tables      []dbtTable `yaml:"tables"`
+}
+
+type dbtTable struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description,omitempty"`
+	Columns     []dbtColumn `yaml:"columns"`
+}
+
+type dbtColumn struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Tests       []string `yaml:"tests,flow,omitempty"`
+}
+
+// GenerateDBT generates a dbt sources.yml file with one source per schema,
+// listing its tables and columns. Table and column notes become
+// descriptions, and the project note describes each source. Columns get a
+// unique test when they are unique, a single-column primary key or covered by
+// a single-column unique index, and a not_null test when they are not
+// nullable or part of the primary key.
+func (p *Project) GenerateDBT() ([]byte, error) {
+	file := dbtSources{Sources: []dbtSource{}}
+
+	sources := map[string]*dbtSource{}
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		source, ok := sources[table.Schema]
+		if !ok {
+			source = &dbtSource{Name: table.Schema, Schema: table.Schema}
+			if p.Note != nil {
+				source.Description = *p.Note
+			}
+			sources[table.Schema] = source
+		}
+		source.Tables = append(source.Tables, table.dbtTable())
+	}
+	for _, schema := range sortedKeys(sources) {
+		file.Sources = append(file.Sources, *sources[schema])
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by dbml. DO NOT EDIT.\n")
+	buf.WriteString("version: 2\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (t *Table) dbtTable() dbtTable {
+	table := dbtTable{Name: t.Name, Columns: []dbtColumn{}}
+	if t.Note != nil {
+		table.Description = *t.Note
+	}
+
+	pkColumns := t.primaryKeyColumns()
+	for _, col := range t.Columns {
+		column := dbtColumn{Name: col.Name}
+		if col.Note != nil {
+			column.Description = *col.Note
+		}
+
+		isPK := false
+		for _, name := range pkColumns {
+			isPK = isPK || name == col.Name
+		}
+		settings := col.Settings
+		if settings == nil {
+			settings = &ColumnSettings{}
+		}
+		if settings.Unique || (isPK && len(pkColumns) == 1) || t.hasUniqueIndexOn(col.Name) {
+			column.Tests = append(column.Tests, "unique")
+		}
+		if !settings.Null || isPK {
+			column.Tests = append(column.Tests, "not_null")
+		}
+
+		table.Columns = append(table.Columns, column)
+	}
+
+	return table
+}
@@ -0,0 +1,64 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestProject_GenerateDBT(t *testing.T) {
+	project := NewProject("shop").
+		WithNote("Shop database").
+		AddTable(NewTable("users").
+			WithNote("Registered users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar").WithUnique().WithNote("Login name")).
+			AddColumn(NewColumn("handle", "varchar").WithNull()).
+			AddColumn(NewColumn("bio", "text").WithNull()).
+			AddIndex(NewIndex("handle").WithUnique())).
+		AddTable(NewTable("order_lines").WithSchema("sales").
+			AddColumn(NewColumn("order_id", "bigint")).
+			AddColumn(NewColumn("line", "int")).
+			AddIndex(NewIndex("order_id", "line").WithPrimaryKey()))
+
+	data, err := project.GenerateDBT()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	output := string(data)
+
+	if !strings.HasPrefix(output, "# Code generated by dbml. DO NOT EDIT.\nversion: 2\nsources:\n") {
+		t.Errorf("Expected header and version first, got:\n%s", output)
+	}
+
+	expected := []string{
+		"  - name: public\n    schema: public\n    description: Shop database\n    tables:\n      - name: users\n        description: Registered users\n",
+		"          - name: id\n            tests: [unique, not_null]\n",
+		"          - name: email\n            description: Login name\n            tests: [unique, not_null]\n",
+		"          - name: handle\n            tests: [unique]\n",
+		"          - name: bio\n",
+		"          - name: order_id\n            tests: [not_null]\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+		}
+	}
+	if strings.Contains(output, "name: bio\n            tests") {
+		t.Errorf("Expected no tests for a nullable column, got:\n%s", output)
+	}
+
+	var parsed struct {
+		Sources []struct {
+			Name string
+		}
+		Version int
+	}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Expected valid YAML, got %v", err)
+	}
+	if parsed.Version != 2 || len(parsed.Sources) != 2 || parsed.Sources[0].Name != "public" || parsed.Sources[1].Name != "sales" {
+		t.Errorf("Expected version 2 with public and sales sources, got %+v", parsed)
+	}
+}
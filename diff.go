@@ -0,0 +1,682 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DBDiff is the structural difference between two projects, as computed by
+// Diff. Tables and enums are keyed by "schema.name", as in Project.Tables.
+type DBDiff struct {
+	From           *Project
+	To             *Project
+	columnRenames  map[string]map[string]string
+	TablesAdded    []TableAdded
+	TablesRemoved  []TableRemoved
+	TablesModified []TableModified
+	EnumsAdded     []EnumAdded
+	EnumsRemoved   []EnumRemoved
+	EnumsModified  []EnumModified
+	RefsAdded      []RefAdded
+	RefsRemoved    []RefRemoved
+}
+
+// TableAdded is a table present only in the new project. Old is nil.
+type TableAdded struct {
+	Old *Table
+	New *Table
+	Key string
+}
+
+// TableRemoved is a table present only in the old project. New is nil.
+type TableRemoved struct {
+	Old *Table
+	New *Table
+	Key string
+}
+
+// TableModified is a table present in both projects with a different
+// definition. Columns are matched by name and indexes by name, using the
+// conventional idx_<table>_<columns> name for unnamed indexes; an index
+// whose definition changed is reported as removed and added.
+type TableModified struct {
+	Old             *Table
+	New             *Table
+	Key             string
+	ColumnsAdded    []ColumnDiff
+	ColumnsRemoved  []ColumnDiff
+	ColumnsModified []ColumnDiff
+	IndexesAdded    []IndexDiff
+	IndexesRemoved  []IndexDiff
+}
+
+// ColumnDiff is an added, removed or modified column. Old is nil for added
+// columns and New for removed ones.
+type ColumnDiff struct {
+	Old  *Column
+	New  *Column
+	Name string
+}
+
+// IndexDiff is an added or removed index, keyed by index name.
+type IndexDiff struct {
+	Old  *Index
+	New  *Index
+	Name string
+}
+
+// EnumAdded is an enum present only in the new project. Old is nil.
+type EnumAdded struct {
+	Old *Enum
+	New *Enum
+	Key string
+}
+
+// EnumRemoved is an enum present only in the old project. New is nil.
+type EnumRemoved struct {
+	Old *Enum
+	New *Enum
+	Key string
+}
+
+// EnumModified is an enum present in both projects with a different
+// definition. Values are compared as sets; a reordering alone leaves both
+// lists empty.
+type EnumModified struct {
+	Old           *Enum
+	New           *Enum
+	Key           string
+	ValuesAdded   []string
+	ValuesRemoved []string
+}
+
+// RefAdded is a ref present only in the new project. Old is nil.
+type RefAdded struct {
+	Old *Ref
+	New *Ref
+	Key string
+}
+
+// RefRemoved is a ref present only in the old project. New is nil.
+type RefRemoved struct {
+	Old *Ref
+	New *Ref
+	Key string
+}
+
+// Diff computes the structural difference between two projects. Refs
+// include inline column refs, which are reported as synthesized Refs, and
+// are matched by relationship as in FindDuplicateRefs; a ref whose name or
+// settings changed is reported as removed and added. A nil project is
+// treated as empty. Entries are sorted by key; refs keep project order.
+func Diff(from, to *Project) *DBDiff {
+	if from == nil {
+		from = NewProject("")
+	}
+	if to == nil {
+		to = NewProject("")
+	}
+
+	d := &DBDiff{From: from, To: to}
+
+	for _, key := range sortedKeys(to.Tables) {
+		newTable := to.Tables[key]
+		oldTable, ok := from.Tables[key]
+		switch {
+		case !ok:
+			d.TablesAdded = append(d.TablesAdded, TableAdded{Key: key, New: newTable})
+		case !oldTable.Equal(newTable):
+			d.TablesModified = append(d.TablesModified, diffTable(key, oldTable, newTable))
+		}
+	}
+	for _, key := range sortedKeys(from.Tables) {
+		if _, ok := to.Tables[key]; !ok {
+			d.TablesRemoved = append(d.TablesRemoved, TableRemoved{Key: key, Old: from.Tables[key]})
+		}
+	}
+
+	for _, key := range sortedKeys(to.Enums) {
+		newEnum := to.Enums[key]
+		oldEnum, ok := from.Enums[key]
+		switch {
+		case !ok:
+			d.EnumsAdded = append(d.EnumsAdded, EnumAdded{Key: key, New: newEnum})
+		case !oldEnum.Equal(newEnum):
+			modified := EnumModified{Key: key, Old: oldEnum, New: newEnum}
+			for _, value := range newEnum.Values {
+				if !slices.Contains(oldEnum.Values, value) {
+					modified.ValuesAdded = append(modified.ValuesAdded, value)
+				}
+			}
+			for _, value := range oldEnum.Values {
+				if !slices.Contains(newEnum.Values, value) {
+					modified.ValuesRemoved = append(modified.ValuesRemoved, value)
+				}
+			}
+			d.EnumsModified = append(d.EnumsModified, modified)
+		}
+	}
+	for _, key := range sortedKeys(from.Enums) {
+		if _, ok := to.Enums[key]; !ok {
+			d.EnumsRemoved = append(d.EnumsRemoved, EnumRemoved{Key: key, Old: from.Enums[key]})
+		}
+	}
+
+	oldRefs, newRefs := from.allRefs(), to.allRefs()
+	for _, ref := range newRefs {
+		if !containsRef(oldRefs, ref) {
+			d.RefsAdded = append(d.RefsAdded, RefAdded{Key: refDuplicateKey(ref), New: ref})
+		}
+	}
+	for _, ref := range oldRefs {
+		if !containsRef(newRefs, ref) {
+			d.RefsRemoved = append(d.RefsRemoved, RefRemoved{Key: refDuplicateKey(ref), Old: ref})
+		}
+	}
+
+	return d
+}
+
+// IsEmpty reports whether the diff has no changes.
+func (d *DBDiff) IsEmpty() bool {
+	return len(d.TablesAdded) == 0 && len(d.TablesRemoved) == 0 && len(d.TablesModified) == 0 &&
+		len(d.EnumsAdded) == 0 && len(d.EnumsRemoved) == 0 && len(d.EnumsModified) == 0 &&
+		len(d.RefsAdded) == 0 && len(d.RefsRemoved) == 0
+}
+
+func diffTable(key string, oldTable, newTable *Table) TableModified {
+	modified := TableModified{Key: key, Old: oldTable, New: newTable}
+
+	for _, col := range newTable.Columns {
+		i := oldTable.GetColumnIndex(col.Name)
+		switch {
+		case i < 0:
+			modified.ColumnsAdded = append(modified.ColumnsAdded, ColumnDiff{Name: col.Name, New: col})
+		case !oldTable.Columns[i].Equal(col):
+			modified.ColumnsModified = append(modified.ColumnsModified, ColumnDiff{Name: col.Name, Old: oldTable.Columns[i], New: col})
+		}
+	}
+	for _, col := range oldTable.Columns {
+		if newTable.GetColumnIndex(col.Name) < 0 {
+			modified.ColumnsRemoved = append(modified.ColumnsRemoved, ColumnDiff{Name: col.Name, Old: col})
+		}
+	}
+
+	oldIndexes := map[string]*Index{}
+	for _, idx := range oldTable.Indexes {
+		oldIndexes[idx.indexName(oldTable)] = idx
+	}
+	newIndexes := map[string]*Index{}
+	for _, idx := range newTable.Indexes {
+		newIndexes[idx.indexName(newTable)] = idx
+	}
+	for _, idx := range newTable.Indexes {
+		name := idx.indexName(newTable)
+		if old, ok := oldIndexes[name]; !ok || !old.Equal(idx) {
+			modified.IndexesAdded = append(modified.IndexesAdded, IndexDiff{Name: name, New: idx})
+		}
+	}
+	for _, idx := range oldTable.Indexes {
+		name := idx.indexName(oldTable)
+		if updated, ok := newIndexes[name]; !ok || !updated.Equal(idx) {
+			modified.IndexesRemoved = append(modified.IndexesRemoved, IndexDiff{Name: name, Old: idx})
+		}
+	}
+
+	return modified
+}
+
+// containsRef reports whether refs has a ref for the same relationship as
+// r with the same name and settings.
+func containsRef(refs []*Ref, r *Ref) bool {
+	key := refDiffKey(r)
+	for _, ref := range refs {
+		if refDiffKey(ref) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func refDiffKey(r *Ref) string {
+	key := refDuplicateKey(r)
+	if r.Name != nil {
+		key += " name=" + *r.Name
+	}
+	if r.OnDelete != nil {
+		key += " delete=" + string(*r.OnDelete)
+	}
+	if r.OnUpdate != nil {
+		key += " update=" + string(*r.OnUpdate)
+	}
+	if r.Color != nil {
+		key += " color=" + *r.Color
+	}
+	if r.Deferrable != nil {
+		key += " deferrable=" + string(*r.Deferrable)
+	}
+	return key
+}
+
+// WithColumnRename tells MigrationSQL that column from of the table with the
+// given "schema.name" key was renamed to to, so that it is migrated with
+// RENAME COLUMN instead of being dropped and added again.
+func (d *DBDiff) WithColumnRename(table, from, to string) *DBDiff {
+	if d.columnRenames == nil {
+		d.columnRenames = map[string]map[string]string{}
+	}
+	if d.columnRenames[table] == nil {
+		d.columnRenames[table] = map[string]string{}
+	}
+	d.columnRenames[table][from] = to
+	return d
+}
+
+// MigrationSQL generates the SQL that migrates a database from the From
+// schema to the To schema. Only DialectPostgres is supported.
+//
+// Statements are ordered so that they run one after another without
+// foreign key errors: removed foreign keys and indexes are dropped first,
+// then removed tables in reverse dependency order, then new enums, tables
+// and columns are created and changed columns altered, then removed columns
+// and enums are dropped, and new indexes and foreign keys are added last.
+// Changed columns get their type, nullability, default, and unique and
+// check constraints altered, and changed tables their storage parameters,
+// row-level security and comment. Changes that cannot be migrated in place,
+// namely primary key, increment and computed column changes and removed enum
+// values, which PostgreSQL cannot drop, return an error. Changes without an
+// effect on the database, such as notes, produce no statements. Tables
+// excluded from DDL are skipped.
+func (d *DBDiff) MigrationSQL(dialect SQLDialect) (string, error) {
+	if dialect != DialectPostgres {
+		return "", fmt.Errorf("migration SQL: unsupported dialect %q", dialect)
+	}
+
+	var b strings.Builder
+
+	removedTables := map[string]bool{}
+	for _, removed := range d.TablesRemoved {
+		removedTables[removed.Key] = true
+	}
+
+	// Foreign keys go first so that no constraint blocks a later drop
+	for _, removed := range d.RefsRemoved {
+		child, _, ok := removed.Old.foreignKey()
+		if !ok || removedTables[child.Schema+"."+child.Table] ||
+			d.From.refTouchesMigrationTable(removed.Old) || d.From.refTouchesExcludedTable(removed.Old) {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;\n",
+			pgQualifiedName(child.Schema, child.Table), pgQuoteIdent(removed.Old.foreignKeyName(child))))
+	}
+
+	for _, modified := range d.TablesModified {
+		if modified.New.ExcludeFromDDL {
+			continue
+		}
+		for _, removed := range modified.IndexesRemoved {
+			if !removed.Old.PrimaryKey {
+				b.WriteString(fmt.Sprintf("DROP INDEX IF EXISTS %s;\n", pgQualifiedName(modified.Old.Schema, removed.Name)))
+			}
+		}
+	}
+
+	ordered, cyclic := d.From.ddlTablesInDependencyOrder()
+	for _, table := range slices.Backward(ordered) {
+		key := table.Schema + "." + table.Name
+		if !removedTables[key] {
+			continue
+		}
+		b.WriteString("DROP TABLE IF EXISTS " + pgQualifiedName(table.Schema, table.Name))
+		if cyclic[key] {
+			b.WriteString(" CASCADE")
+		}
+		b.WriteString(";\n")
+	}
+
+	for _, added := range d.EnumsAdded {
+		values := make([]string, len(added.New.Values))
+		for i, value := range added.New.Values {
+			values[i] = quoteSQLString(value)
+		}
+		b.WriteString(fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);\n",
+			pgQualifiedName(added.New.Schema, added.New.Name), strings.Join(values, ", ")))
+	}
+	for _, modified := range d.EnumsModified {
+		if len(modified.ValuesRemoved) > 0 {
+			return "", fmt.Errorf("migration SQL: enum %s: cannot remove values %s", modified.Key, strings.Join(modified.ValuesRemoved, ", "))
+		}
+		for _, value := range modified.ValuesAdded {
+			b.WriteString(fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS %s;\n",
+				pgQualifiedName(modified.New.Schema, modified.New.Name), quoteSQLString(value)))
+		}
+	}
+
+	for _, added := range d.TablesAdded {
+		if added.New.ExcludeFromDDL {
+			continue
+		}
+		b.WriteString(added.New.postgresCreateTable())
+	}
+
+	var drops []string
+	for _, modified := range d.TablesModified {
+		if modified.New.ExcludeFromDDL {
+			continue
+		}
+		statements, columnDrops, err := d.alterTable(modified)
+		if err != nil {
+			return "", err
+		}
+		for _, stmt := range statements {
+			b.WriteString(stmt)
+		}
+		drops = append(drops, columnDrops...)
+	}
+	for _, stmt := range drops {
+		b.WriteString(stmt)
+	}
+
+	for _, removed := range d.EnumsRemoved {
+		b.WriteString("DROP TYPE IF EXISTS " + pgQualifiedName(removed.Old.Schema, removed.Old.Name) + ";\n")
+	}
+
+	for _, modified := range d.TablesModified {
+		if modified.New.ExcludeFromDDL {
+			continue
+		}
+		for _, added := range modified.IndexesAdded {
+			if !added.New.PrimaryKey {
+				b.WriteString(added.New.postgresCreateIndex(modified.New))
+			}
+		}
+	}
+
+	for _, added := range d.RefsAdded {
+		if d.To.refTouchesMigrationTable(added.New) || d.To.refTouchesExcludedTable(added.New) {
+			continue
+		}
+		b.WriteString(added.New.postgresForeignKey())
+	}
+
+	return b.String(), nil
+}
+
+// alterTable returns the statements that rename, add and alter the columns
+// and table options of a modified table, and separately the statements that
+// drop its removed columns. It fails on changes it cannot migrate.
+func (d *DBDiff) alterTable(modified TableModified) (statements, drops []string, err error) {
+	old, updated := modified.Old, modified.New
+	table := pgQualifiedName(updated.Schema, updated.Name)
+	renames := d.columnRenames[modified.Key]
+
+	oldPK := old.primaryKeyColumns()
+	for i, name := range oldPK {
+		if to, ok := renames[name]; ok {
+			oldPK[i] = to
+		}
+	}
+	if newPK := updated.primaryKeyColumns(); !slices.Equal(oldPK, newPK) {
+		return nil, nil, fmt.Errorf("migration SQL: table %s: cannot migrate primary key change from (%s) to (%s)",
+			modified.Key, strings.Join(oldPK, ", "), strings.Join(newPK, ", "))
+	}
+
+	renamedTo := map[string]bool{}
+	for _, removed := range modified.ColumnsRemoved {
+		to, ok := renames[removed.Name]
+		if !ok || updated.GetColumnIndex(to) < 0 {
+			drops = append(drops, fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s;\n", table, pgQuoteIdent(removed.Name)))
+			continue
+		}
+		renamedTo[to] = true
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n",
+			table, pgQuoteIdent(removed.Name), pgQuoteIdent(to)))
+		altered, err := alterColumn(modified.Key, updated, removed.Old, updated.Columns[updated.GetColumnIndex(to)])
+		if err != nil {
+			return nil, nil, err
+		}
+		statements = append(statements, altered...)
+	}
+
+	for _, added := range modified.ColumnsAdded {
+		if renamedTo[added.Name] {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;\n", table, added.New.postgresDefinition(false)))
+	}
+	for _, changed := range modified.ColumnsModified {
+		altered, err := alterColumn(modified.Key, updated, changed.Old, changed.New)
+		if err != nil {
+			return nil, nil, err
+		}
+		statements = append(statements, altered...)
+	}
+
+	statements = append(statements, alterTableOptions(table, old, updated)...)
+
+	return statements, drops, nil
+}
+
+// alterColumn returns the ALTER TABLE statements for a changed column: its
+// type, nullability, default, and unique and check constraints. Unique and
+// check constraints use the names PostgreSQL gives column constraints,
+// <table>_<column>_key and <table>_<column>_check. Identity and generated
+// column changes cannot be migrated and return an error.
+func alterColumn(key string, t *Table, old, updated *Column) ([]string, error) {
+	oldSettings, newSettings := old.Settings, updated.Settings
+	if oldSettings == nil {
+		oldSettings = &ColumnSettings{Null: true}
+	}
+	if newSettings == nil {
+		newSettings = &ColumnSettings{Null: true}
+	}
+
+	if oldSettings.Increment != newSettings.Increment {
+		return nil, fmt.Errorf("migration SQL: column %s.%s: cannot migrate increment change", key, updated.Name)
+	}
+	if !ptrEqual(old.ComputedAlias, updated.ComputedAlias) {
+		return nil, fmt.Errorf("migration SQL: column %s.%s: cannot migrate computed expression change", key, updated.Name)
+	}
+
+	var statements []string
+	table := pgQualifiedName(t.Schema, t.Name)
+	name := pgQuoteIdent(updated.Name)
+	alter := func(format string, args ...any) {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s "+format+";\n", append([]any{table}, args...)...))
+	}
+
+	if old.sqlType() != updated.sqlType() {
+		alter("ALTER COLUMN %s TYPE %s", name, updated.sqlType())
+	}
+
+	switch {
+	case !newSettings.Null && oldSettings.Null:
+		alter("ALTER COLUMN %s SET NOT NULL", name)
+	case newSettings.Null && !oldSettings.Null:
+		alter("ALTER COLUMN %s DROP NOT NULL", name)
+	}
+
+	oldDefault, newDefault := postgresColumnDefault(old), postgresColumnDefault(updated)
+	switch {
+	case newDefault != nil && !ptrEqual(oldDefault, newDefault):
+		alter("ALTER COLUMN %s SET DEFAULT %s", name, *newDefault)
+	case newDefault == nil && oldDefault != nil:
+		alter("ALTER COLUMN %s DROP DEFAULT", name)
+	}
+
+	if oldSettings.Unique != newSettings.Unique {
+		if oldSettings.Unique {
+			alter("DROP CONSTRAINT IF EXISTS %s", pgQuoteIdent(t.Name+"_"+old.Name+"_key"))
+		} else {
+			alter("ADD CONSTRAINT %s UNIQUE (%s)", pgQuoteIdent(t.Name+"_"+updated.Name+"_key"), name)
+		}
+	}
+
+	if !ptrEqual(oldSettings.Check, newSettings.Check) {
+		if oldSettings.Check != nil {
+			alter("DROP CONSTRAINT IF EXISTS %s", pgQuoteIdent(t.Name+"_"+old.Name+"_check"))
+		}
+		if newSettings.Check != nil {
+			alter("ADD CONSTRAINT %s CHECK (%s)", pgQuoteIdent(t.Name+"_"+updated.Name+"_check"), *newSettings.Check)
+		}
+	}
+
+	return statements, nil
+}
+
+// postgresColumnDefault returns the default postgresDefinition writes for
+// the column, or nil.
+func postgresColumnDefault(c *Column) *string {
+	if c.Settings == nil || c.Settings.Default == nil || c.Settings.Increment ||
+		c.Settings.DatabaseDefault || c.ComputedAlias != nil {
+		return nil
+	}
+	value := sqlDefault(*c.Settings.Default, c.Settings.DefaultKind)
+	return &value
+}
+
+// alterTableOptions returns the statements for changed storage parameters,
+// row-level security and table comment.
+func alterTableOptions(table string, old, updated *Table) []string {
+	var statements []string
+
+	var set, reset []string
+	for _, key := range sortedKeys(updated.StorageParams) {
+		if value, ok := old.StorageParams[key]; !ok || value != updated.StorageParams[key] {
+			set = append(set, key+" = "+updated.StorageParams[key])
+		}
+	}
+	for _, key := range sortedKeys(old.StorageParams) {
+		if _, ok := updated.StorageParams[key]; !ok {
+			reset = append(reset, key)
+		}
+	}
+	if len(set) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s SET (%s);\n", table, strings.Join(set, ", ")))
+	}
+	if len(reset) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RESET (%s);\n", table, strings.Join(reset, ", ")))
+	}
+
+	wasEnabled, isEnabled := old.RowLevelSecurity != nil && *old.RowLevelSecurity, updated.RowLevelSecurity != nil && *updated.RowLevelSecurity
+	switch {
+	case isEnabled && !wasEnabled:
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY;\n", table))
+	case wasEnabled && !isEnabled:
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s DISABLE ROW LEVEL SECURITY;\n", table))
+	}
+
+	if !ptrEqual(old.SQLComment, updated.SQLComment) {
+		comment := "NULL"
+		if updated.SQLComment != nil {
+			comment = quoteSQLString(*updated.SQLComment)
+		}
+		statements = append(statements, fmt.Sprintf("COMMENT ON TABLE %s IS %s;\n", table, comment))
+	}
+
+	return statements
+}
+
+// BreakingChangeKind classifies a breaking change.
+type BreakingChangeKind string
+
+const (
+	BreakingTableDropped      BreakingChangeKind = "table_dropped"
+	BreakingColumnDropped     BreakingChangeKind = "column_dropped"
+	BreakingColumnTypeChanged BreakingChangeKind = "column_type_changed"
+	BreakingNotNullAdded      BreakingChangeKind = "not_null_added"
+	BreakingPKChanged         BreakingChangeKind = "pk_changed"
+	BreakingUniqueAdded       BreakingChangeKind = "unique_added" // potentially breaking: existing rows may be duplicates
+	BreakingEnumDropped       BreakingChangeKind = "enum_dropped"
+	BreakingEnumValueRemoved  BreakingChangeKind = "enum_value_removed"
+)
+
+// BreakingChange is a change that can break existing data or clients.
+type BreakingChange struct {
+	Kind        BreakingChangeKind
+	Description string
+}
+
+// BreakingChanges returns the changes in the diff that can break existing
+// data or clients: dropped tables, columns and enums, removed enum values,
+// changes of a column's base type (even widening ones such as int to
+// bigint; varchar(50) to varchar(100) keeps the base type), NOT NULL
+// columns without a default, whether added or changed, primary key changes
+// and added unique constraints. Renamed columns count as dropped. The
+// result is nil for additive diffs.
+func (d *DBDiff) BreakingChanges() []BreakingChange {
+	var changes []BreakingChange
+	add := func(kind BreakingChangeKind, format string, args ...any) {
+		changes = append(changes, BreakingChange{Kind: kind, Description: fmt.Sprintf(format, args...)})
+	}
+
+	for _, removed := range d.TablesRemoved {
+		add(BreakingTableDropped, "table %s dropped", removed.Key)
+	}
+
+	for _, modified := range d.TablesModified {
+		for _, removed := range modified.ColumnsRemoved {
+			add(BreakingColumnDropped, "column %s.%s dropped", modified.Key, removed.Name)
+		}
+		for _, added := range modified.ColumnsAdded {
+			col := added.New
+			if col.Settings != nil && !col.Settings.Null && col.Settings.Default == nil &&
+				!col.Settings.Increment && col.ComputedAlias == nil {
+				add(BreakingNotNullAdded, "column %s.%s added as NOT NULL without a default", modified.Key, added.Name)
+			}
+		}
+		for _, changed := range modified.ColumnsModified {
+			old, updated := changed.Old, changed.New
+			oldBase, oldArray := baseSQLType(old.sqlType())
+			newBase, newArray := baseSQLType(updated.sqlType())
+			if oldBase != newBase || oldArray != newArray {
+				add(BreakingColumnTypeChanged, "column %s.%s type changed from %s to %s",
+					modified.Key, changed.Name, old.sqlType(), updated.sqlType())
+			}
+			wasNullable := old.Settings == nil || old.Settings.Null
+			isNotNull := updated.Settings != nil && !updated.Settings.Null
+			if wasNullable && isNotNull && updated.Settings.Default == nil && !updated.Settings.Increment {
+				add(BreakingNotNullAdded, "column %s.%s made NOT NULL without a default", modified.Key, changed.Name)
+			}
+			if (old.Settings == nil || !old.Settings.Unique) && updated.Settings != nil && updated.Settings.Unique {
+				add(BreakingUniqueAdded, "column %s.%s made unique; existing duplicates would fail", modified.Key, changed.Name)
+			}
+		}
+
+		oldPK, newPK := modified.Old.primaryKeyColumns(), modified.New.primaryKeyColumns()
+		if !slices.Equal(oldPK, newPK) {
+			add(BreakingPKChanged, "table %s primary key changed from (%s) to (%s)",
+				modified.Key, strings.Join(oldPK, ", "), strings.Join(newPK, ", "))
+		}
+
+		for _, added := range modified.IndexesAdded {
+			if !added.New.Unique || added.New.PrimaryKey || hasUniqueIndex(modified.IndexesRemoved, added.New) {
+				continue
+			}
+			add(BreakingUniqueAdded, "unique index %s added on %s; existing duplicates would fail", added.Name, modified.Key)
+		}
+	}
+
+	for _, removed := range d.EnumsRemoved {
+		add(BreakingEnumDropped, "enum %s dropped", removed.Key)
+	}
+	for _, modified := range d.EnumsModified {
+		for _, value := range modified.ValuesRemoved {
+			add(BreakingEnumValueRemoved, "enum %s value %q removed", modified.Key, value)
+		}
+	}
+
+	return changes
+}
+
+// hasUniqueIndex reports whether indexes has a unique index over the same
+// columns as idx, so that replacing it adds no constraint.
+func hasUniqueIndex(indexes []IndexDiff, idx *Index) bool {
+	for _, other := range indexes {
+		if other.Old.Unique && indexColumnsEqual(other.Old.Columns, idx.Columns) {
+			return true
+		}
+	}
+	return false
+}
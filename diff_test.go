@@ -0,0 +1,400 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func newDiffTestProject() *Project {
+	return NewProject("shop").
+		AddEnum(NewEnum("order_status", "pending", "shipped", "cancelled")).
+		AddEnum(NewEnum("currency", "usd", "eur")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar(255)")).
+			AddColumn(NewColumn("nickname", "varchar(50)").WithNull()).
+			AddIndex(NewIndex("email").WithUnique())).
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "order_status"))).
+		AddTable(NewTable("legacy_carts").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey())).
+		AddRef(NewRef(ManyToOne).From("public", "orders", "user_id").To("public", "users", "id"))
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("identical projects", func(t *testing.T) {
+		diff := Diff(newDiffTestProject(), newDiffTestProject())
+		if !diff.IsEmpty() {
+			t.Errorf("Expected empty diff, got %+v", diff)
+		}
+	})
+
+	t.Run("nil projects", func(t *testing.T) {
+		if !Diff(nil, nil).IsEmpty() {
+			t.Error("Expected empty diff for nil projects")
+		}
+
+		diff := Diff(nil, newDiffTestProject())
+		if len(diff.TablesAdded) != 3 || len(diff.EnumsAdded) != 2 || len(diff.RefsAdded) != 1 {
+			t.Errorf("Expected everything added, got %+v", diff)
+		}
+		if diff.TablesAdded[0].Key != "public.legacy_carts" || diff.TablesAdded[0].Old != nil {
+			t.Errorf("Expected sorted additions with nil Old, got %+v", diff.TablesAdded[0])
+		}
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		from := newDiffTestProject()
+		to := newDiffTestProject()
+
+		delete(to.Tables, "public.legacy_carts")
+		to.AddTable(NewTable("payments").
+			WithSchema("billing").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("order_id", "bigint")))
+
+		users := to.Tables["public.users"]
+		users.Columns = users.Columns[:2]
+		users.Columns[1].Type = "text"
+		users.AddColumn(NewColumn("created_at", "timestamp"))
+		users.Indexes = []*Index{NewIndex("email")}
+
+		delete(to.Enums, "public.currency")
+		to.Enums["public.order_status"].Values = []string{"pending", "shipped", "refunded"}
+		to.AddEnum(NewEnum("payment_method", "card"))
+
+		to.Refs[0].WithOnDelete(Cascade)
+		to.AddRef(NewRef(ManyToOne).From("billing", "payments", "order_id").To("public", "orders", "id"))
+
+		diff := Diff(from, to)
+		if diff.IsEmpty() {
+			t.Fatal("Expected non-empty diff")
+		}
+
+		if len(diff.TablesAdded) != 1 || diff.TablesAdded[0].Key != "billing.payments" || diff.TablesAdded[0].New != to.Tables["billing.payments"] {
+			t.Errorf("Expected billing.payments added, got %+v", diff.TablesAdded)
+		}
+		if len(diff.TablesRemoved) != 1 || diff.TablesRemoved[0].Key != "public.legacy_carts" || diff.TablesRemoved[0].New != nil {
+			t.Errorf("Expected public.legacy_carts removed, got %+v", diff.TablesRemoved)
+		}
+		if len(diff.TablesModified) != 1 || diff.TablesModified[0].Key != "public.users" {
+			t.Fatalf("Expected public.users modified, got %+v", diff.TablesModified)
+		}
+
+		modified := diff.TablesModified[0]
+		if modified.Old != from.Tables["public.users"] || modified.New != users {
+			t.Error("Expected modified table to reference old and new tables")
+		}
+		if len(modified.ColumnsAdded) != 1 || modified.ColumnsAdded[0].Name != "created_at" {
+			t.Errorf("Expected created_at added, got %+v", modified.ColumnsAdded)
+		}
+		if len(modified.ColumnsRemoved) != 1 || modified.ColumnsRemoved[0].Name != "nickname" {
+			t.Errorf("Expected nickname removed, got %+v", modified.ColumnsRemoved)
+		}
+		if len(modified.ColumnsModified) != 1 || modified.ColumnsModified[0].Name != "email" ||
+			modified.ColumnsModified[0].Old.Type != "varchar(255)" || modified.ColumnsModified[0].New.Type != "text" {
+			t.Errorf("Expected email type change, got %+v", modified.ColumnsModified)
+		}
+		if len(modified.IndexesAdded) != 1 || modified.IndexesAdded[0].Name != "idx_users_email" || modified.IndexesAdded[0].New.Unique {
+			t.Errorf("Expected non-unique idx_users_email added, got %+v", modified.IndexesAdded)
+		}
+		if len(modified.IndexesRemoved) != 1 || !modified.IndexesRemoved[0].Old.Unique {
+			t.Errorf("Expected unique idx_users_email removed, got %+v", modified.IndexesRemoved)
+		}
+
+		if len(diff.EnumsAdded) != 1 || diff.EnumsAdded[0].Key != "public.payment_method" {
+			t.Errorf("Expected payment_method added, got %+v", diff.EnumsAdded)
+		}
+		if len(diff.EnumsRemoved) != 1 || diff.EnumsRemoved[0].Key != "public.currency" {
+			t.Errorf("Expected currency removed, got %+v", diff.EnumsRemoved)
+		}
+		if len(diff.EnumsModified) != 1 {
+			t.Fatalf("Expected order_status modified, got %+v", diff.EnumsModified)
+		}
+		enum := diff.EnumsModified[0]
+		if len(enum.ValuesAdded) != 1 || enum.ValuesAdded[0] != "refunded" ||
+			len(enum.ValuesRemoved) != 1 || enum.ValuesRemoved[0] != "cancelled" {
+			t.Errorf("Expected refunded added and cancelled removed, got %+v", enum)
+		}
+
+		if len(diff.RefsAdded) != 2 {
+			t.Errorf("Expected changed and new refs added, got %+v", diff.RefsAdded)
+		}
+		if len(diff.RefsRemoved) != 1 || diff.RefsRemoved[0].Old != from.Refs[0] {
+			t.Errorf("Expected original ref removed, got %+v", diff.RefsRemoved)
+		}
+	})
+
+	t.Run("reversed ref is unchanged", func(t *testing.T) {
+		from := newDiffTestProject()
+		to := newDiffTestProject()
+		to.Refs[0] = to.Refs[0].Reverse()
+
+		if diff := Diff(from, to); !diff.IsEmpty() {
+			t.Errorf("Expected reversed ref to match, got added %+v removed %+v", diff.RefsAdded, diff.RefsRemoved)
+		}
+	})
+
+	t.Run("enum reorder", func(t *testing.T) {
+		from := newDiffTestProject()
+		to := newDiffTestProject()
+		to.Enums["public.currency"].Values = []string{"eur", "usd"}
+
+		diff := Diff(from, to)
+		if len(diff.EnumsModified) != 1 || len(diff.EnumsModified[0].ValuesAdded) != 0 || len(diff.EnumsModified[0].ValuesRemoved) != 0 {
+			t.Errorf("Expected reordered enum modified without value changes, got %+v", diff.EnumsModified)
+		}
+	})
+}
+
+func TestDBDiff_MigrationSQL(t *testing.T) {
+	from := newDiffTestProject()
+	to := newDiffTestProject()
+
+	delete(to.Tables, "public.legacy_carts")
+	to.AddTable(NewTable("payments").
+		WithSchema("billing").
+		AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+		AddColumn(NewColumn("order_id", "bigint")).
+		AddColumn(NewColumn("method", "payment_method")))
+	to.AddEnum(NewEnum("payment_method", "card"))
+	delete(to.Enums, "public.currency")
+	to.Enums["public.order_status"].Values = append(to.Enums["public.order_status"].Values, "refunded")
+
+	users := to.Tables["public.users"]
+	users.Columns[1].Type = "text"
+	users.Columns[2] = NewColumn("display_name", "varchar(50)").WithNull()
+	users.AddColumn(NewColumn("created_at", "timestamp"))
+	users.Indexes = []*Index{NewIndex("email")}
+
+	orders := to.Tables["public.orders"]
+	orders.Columns = orders.Columns[:2]
+	orders.Columns[1].WithNull()
+
+	to.Refs[0].WithOnDelete(Cascade)
+	to.AddRef(NewRef(ManyToOne).From("billing", "payments", "order_id").To("public", "orders", "id"))
+
+	diff := Diff(from, to).WithColumnRename("public.users", "nickname", "display_name")
+	output, err := diff.MigrationSQL(DialectPostgres)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"ALTER TABLE \"public\".\"orders\" DROP CONSTRAINT IF EXISTS \"fk_orders_user_id\";\n",
+		"DROP INDEX IF EXISTS \"public\".\"idx_users_email\";\n",
+		"DROP TABLE IF EXISTS \"public\".\"legacy_carts\";\n",
+		"CREATE TYPE \"public\".\"payment_method\" AS ENUM ('card');\n",
+		"ALTER TYPE \"public\".\"order_status\" ADD VALUE IF NOT EXISTS 'refunded';\n",
+		"CREATE TABLE \"billing\".\"payments\" (\n",
+		"ALTER TABLE \"public\".\"orders\" ALTER COLUMN \"user_id\" DROP NOT NULL;\n",
+		"ALTER TABLE \"public\".\"users\" RENAME COLUMN \"nickname\" TO \"display_name\";\n",
+		"ALTER TABLE \"public\".\"users\" ADD COLUMN \"created_at\" timestamp NOT NULL;\n",
+		"ALTER TABLE \"public\".\"users\" ALTER COLUMN \"email\" TYPE text;\n",
+		"ALTER TABLE \"public\".\"orders\" DROP COLUMN IF EXISTS \"status\";\n",
+		"DROP TYPE IF EXISTS \"public\".\"currency\";\n",
+		"CREATE INDEX \"idx_users_email\" ON \"public\".\"users\" (\"email\");\n",
+		"ALTER TABLE \"public\".\"orders\" ADD CONSTRAINT \"fk_orders_user_id\" FOREIGN KEY (\"user_id\") REFERENCES \"public\".\"users\" (\"id\") ON DELETE CASCADE;\n",
+		"ALTER TABLE \"billing\".\"payments\" ADD CONSTRAINT \"fk_payments_order_id\"",
+	}
+	last := -1
+	for _, exp := range expected {
+		i := strings.Index(output, exp)
+		if i < 0 {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+			continue
+		}
+		if i < last {
+			t.Errorf("Expected %q later in the output, got:\n%s", exp, output)
+		}
+		last = i
+	}
+	if strings.Contains(output, "DROP COLUMN IF EXISTS \"nickname\"") || strings.Contains(output, "ADD COLUMN \"display_name\"") {
+		t.Errorf("Expected renamed column not to be dropped and added, got:\n%s", output)
+	}
+
+	t.Run("rename without hint", func(t *testing.T) {
+		output, err := Diff(from, to).MigrationSQL(DialectPostgres)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, exp := range []string{"DROP COLUMN IF EXISTS \"nickname\"", "ADD COLUMN \"display_name\" varchar(50);\n"} {
+			if !strings.Contains(output, exp) {
+				t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+			}
+		}
+	})
+
+	t.Run("constraints and options", func(t *testing.T) {
+		from := newDiffTestProject()
+		from.Tables["public.users"].Columns[1].WithCheck("email <> ''")
+		to := newDiffTestProject()
+		users := to.Tables["public.users"]
+		users.WithStorageParameter("fillfactor", "70").WithRowLevelSecurity(true).WithSQLComment("Registered users")
+		users.Columns[1].WithDefault("'unknown'").WithUnique()
+		users.Columns[1].Settings.Check = nil
+		to.Tables["public.orders"].Columns[1].WithCheck("user_id > 0")
+
+		output, err := Diff(from, to).MigrationSQL(DialectPostgres)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, exp := range []string{
+			"ALTER TABLE \"public\".\"orders\" ADD CONSTRAINT \"orders_user_id_check\" CHECK (user_id > 0);\n",
+			"ALTER TABLE \"public\".\"users\" ALTER COLUMN \"email\" SET DEFAULT 'unknown';\n",
+			"ALTER TABLE \"public\".\"users\" ADD CONSTRAINT \"users_email_key\" UNIQUE (\"email\");\n",
+			"ALTER TABLE \"public\".\"users\" DROP CONSTRAINT IF EXISTS \"users_email_check\";\n",
+			"ALTER TABLE \"public\".\"users\" SET (fillfactor = 70);\n",
+			"ALTER TABLE \"public\".\"users\" ENABLE ROW LEVEL SECURITY;\n",
+			"COMMENT ON TABLE \"public\".\"users\" IS 'Registered users';\n",
+		} {
+			if !strings.Contains(output, exp) {
+				t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+			}
+		}
+
+		output, err = Diff(to, from).MigrationSQL(DialectPostgres)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, exp := range []string{
+			"ALTER TABLE \"public\".\"users\" ALTER COLUMN \"email\" DROP DEFAULT;\n",
+			"ALTER TABLE \"public\".\"users\" DROP CONSTRAINT IF EXISTS \"users_email_key\";\n",
+			"ALTER TABLE \"public\".\"users\" RESET (fillfactor);\n",
+			"ALTER TABLE \"public\".\"users\" DISABLE ROW LEVEL SECURITY;\n",
+			"COMMENT ON TABLE \"public\".\"users\" IS NULL;\n",
+		} {
+			if !strings.Contains(output, exp) {
+				t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+			}
+		}
+	})
+
+	t.Run("unmigratable changes", func(t *testing.T) {
+		tests := map[string]func(p *Project){
+			"primary key":   func(p *Project) { p.Tables["public.orders"].Columns[1].WithPrimaryKey() },
+			"increment":     func(p *Project) { p.Tables["public.orders"].Columns[0].WithIncrement() },
+			"computed":      func(p *Project) { p.Tables["public.users"].Columns[2].WithComputedAlias("lower(email)") },
+			"removed value": func(p *Project) { p.Enums["public.currency"].Values = []string{"usd"} },
+		}
+		for name, change := range tests {
+			to := newDiffTestProject()
+			change(to)
+			if _, err := Diff(newDiffTestProject(), to).MigrationSQL(DialectPostgres); err == nil {
+				t.Errorf("Expected error for %s change", name)
+			}
+		}
+	})
+
+	t.Run("unsupported dialect", func(t *testing.T) {
+		if _, err := diff.MigrationSQL(DialectMySQL); err == nil {
+			t.Error("Expected error for MySQL")
+		}
+	})
+
+	t.Run("empty diff", func(t *testing.T) {
+		output, err := Diff(from, from).MigrationSQL(DialectPostgres)
+		if err != nil || output != "" {
+			t.Errorf("Expected empty output, got %q, %v", output, err)
+		}
+	})
+}
+
+func TestDBDiff_BreakingChanges(t *testing.T) {
+	t.Run("additive diff", func(t *testing.T) {
+		from := newDiffTestProject()
+		to := newDiffTestProject()
+		to.AddTable(NewTable("coupons").AddColumn(NewColumn("code", "text").WithPrimaryKey()))
+		to.Tables["public.users"].AddColumn(NewColumn("created_at", "timestamp").WithNull())
+		to.Enums["public.currency"].Values = append(to.Enums["public.currency"].Values, "gbp")
+		to.Tables["public.users"].Indexes = nil
+
+		if changes := Diff(from, to).BreakingChanges(); changes != nil {
+			t.Errorf("Expected no breaking changes, got %+v", changes)
+		}
+	})
+
+	t.Run("breaking diff", func(t *testing.T) {
+		from := newDiffTestProject()
+		from.Tables["public.users"].Columns[2].WithNull()
+		to := newDiffTestProject()
+
+		delete(to.Tables, "public.legacy_carts")
+		users := to.Tables["public.users"]
+		users.Columns[1].Type = "text"
+		users.Columns[2].Settings.Null = false
+		users.Columns[2].Settings.Unique = true
+		orders := to.Tables["public.orders"]
+		orders.Columns = orders.Columns[:2]
+		orders.AddIndex(NewIndex("id", "user_id").WithPrimaryKey())
+		orders.AddIndex(NewIndex("user_id").WithUnique())
+		to.Enums["public.order_status"].Values = []string{"pending", "shipped"}
+		delete(to.Enums, "public.currency")
+
+		changes := Diff(from, to).BreakingChanges()
+
+		expected := map[BreakingChangeKind]string{
+			BreakingTableDropped:      "table public.legacy_carts dropped",
+			BreakingColumnDropped:     "column public.orders.status dropped",
+			BreakingColumnTypeChanged: "column public.users.email type changed from varchar(255) to text",
+			BreakingNotNullAdded:      "column public.users.nickname made NOT NULL without a default",
+			BreakingPKChanged:         "table public.orders primary key changed from (id) to (id, user_id)",
+			BreakingEnumDropped:       "enum public.currency dropped",
+			BreakingEnumValueRemoved:  "enum public.order_status value \"cancelled\" removed",
+		}
+		found := map[BreakingChangeKind]int{}
+		for _, change := range changes {
+			found[change.Kind]++
+			if want, ok := expected[change.Kind]; ok && change.Description != want {
+				t.Errorf("Expected %s description %q, got %q", change.Kind, want, change.Description)
+			}
+		}
+		for kind := range expected {
+			if found[kind] != 1 {
+				t.Errorf("Expected one %s change, got %d in %+v", kind, found[kind], changes)
+			}
+		}
+		if found[BreakingUniqueAdded] != 2 {
+			t.Errorf("Expected unique column and unique index flagged, got %+v", changes)
+		}
+	})
+
+	t.Run("added not null column", func(t *testing.T) {
+		from := newDiffTestProject()
+		to := newDiffTestProject()
+		to.Tables["public.users"].
+			AddColumn(NewColumn("phone", "text")).
+			AddColumn(NewColumn("country", "text").WithDefault("'US'")).
+			AddColumn(NewColumn("email_lower", "text").WithComputedAlias("lower(email)"))
+
+		changes := Diff(from, to).BreakingChanges()
+		if len(changes) != 1 || changes[0].Kind != BreakingNotNullAdded ||
+			changes[0].Description != "column public.users.phone added as NOT NULL without a default" {
+			t.Errorf("Expected only phone flagged, got %+v", changes)
+		}
+	})
+
+	t.Run("same base type", func(t *testing.T) {
+		from := newDiffTestProject()
+		to := newDiffTestProject()
+		to.Tables["public.users"].Columns[1].Type = "VARCHAR(500)"
+
+		if changes := Diff(from, to).BreakingChanges(); changes != nil {
+			t.Errorf("Expected a length change to keep the base type, got %+v", changes)
+		}
+	})
+
+	t.Run("not null with default", func(t *testing.T) {
+		from := newDiffTestProject()
+		from.Tables["public.users"].Columns[2].WithNull()
+		to := newDiffTestProject()
+		to.Tables["public.users"].Columns[2].Settings.Null = false
+		to.Tables["public.users"].Columns[2].WithDefault("'anonymous'")
+
+		if changes := Diff(from, to).BreakingChanges(); changes != nil {
+			t.Errorf("Expected NOT NULL with a default to be safe, got %+v", changes)
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package dbml
+
+import (
+	"maps"
+	"slices"
+)
+
+// Equal reports whether two projects describe the same schema.
+// Tables and enums are compared by key; refs and table groups are compared in order.
+func (p *Project) Equal(other *Project) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	if p.Name != other.Name || !ptrEqual(p.DatabaseType, other.DatabaseType) || !ptrEqual(p.Note, other.Note) {
+		return false
+	}
+
+	if len(p.Schemas) != len(other.Schemas) {
+		return false
+	}
+	for name, schema := range p.Schemas {
+		if !schema.Equal(other.Schemas[name]) {
+			return false
+		}
+	}
+
+	if len(p.Tables) != len(other.Tables) || len(p.Enums) != len(other.Enums) ||
+		len(p.Refs) != len(other.Refs) || len(p.TableGroups) != len(other.TableGroups) {
+		return false
+	}
+
+	if p.TotalRefCount() != other.TotalRefCount() {
+		return false
+	}
+
+	for key, table := range p.Tables {
+		if !table.Equal(other.Tables[key]) {
+			return false
+		}
+	}
+
+	for key, enum := range p.Enums {
+		if !enum.Equal(other.Enums[key]) {
+			return false
+		}
+	}
+
+	for i, ref := range p.Refs {
+		if !ref.Equal(other.Refs[i]) {
+			return false
+		}
+	}
+
+	for i, group := range p.TableGroups {
+		if !group.Equal(other.TableGroups[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether two schemas have the same definition.
+func (s *Schema) Equal(other *Schema) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+
+	return s.Name == other.Name && s.SearchPath == other.SearchPath &&
+		ptrEqual(s.Owner, other.Owner) && ptrEqual(s.Comment, other.Comment)
+}
+
+// Equal reports whether two tables have the same definition.
+func (t *Table) Equal(other *Table) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+
+	if t.Schema != other.Schema || t.Name != other.Name || t.ExcludeFromDDL != other.ExcludeFromDDL ||
+		!ptrEqual(t.Alias, other.Alias) || !ptrEqual(t.Note, other.Note) ||
+		!ptrEqual(t.RowLevelSecurity, other.RowLevelSecurity) || !ptrEqual(t.SQLComment, other.SQLComment) {
+		return false
+	}
+
+	if len(t.Settings) != len(other.Settings) {
+		return false
+	}
+	for key, value := range t.Settings {
+		if otherValue, ok := other.Settings[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	if !maps.Equal(t.StorageParams, other.StorageParams) {
+		return false
+	}
+
+	if len(t.Columns) != len(other.Columns) || len(t.Indexes) != len(other.Indexes) {
+		return false
+	}
+
+	for i, col := range t.Columns {
+		if !col.Equal(other.Columns[i]) {
+			return false
+		}
+	}
+
+	for i, idx := range t.Indexes {
+		if !idx.Equal(other.Indexes[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether two columns have the same definition.
+// A nil Settings is equivalent to zero-valued settings.
+func (c *Column) Equal(other *Column) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+
+	if c.Name != other.Name || c.Type != other.Type || !ptrEqual(c.Note, other.Note) ||
+		!ptrEqual(c.ComputedAlias, other.ComputedAlias) || !ptrEqual(c.TypeAlias, other.TypeAlias) {
+		return false
+	}
+
+	if !ptrEqual(c.InlineRef, other.InlineRef) {
+		return false
+	}
+
+	if len(c.Tags) != len(other.Tags) {
+		return false
+	}
+	for tag, value := range c.Tags {
+		if otherValue, ok := other.Tags[tag]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	return c.settings().equal(other.settings())
+}
+
+func (c *Column) settings() *ColumnSettings {
+	if c.Settings == nil {
+		return &ColumnSettings{}
+	}
+	return c.Settings
+}
+
+func (s *ColumnSettings) equal(other *ColumnSettings) bool {
+	return s.PrimaryKey == other.PrimaryKey &&
+		s.Null == other.Null &&
+		s.Unique == other.Unique &&
+		s.Increment == other.Increment &&
+		s.DatabaseDefault == other.DatabaseDefault &&
+		s.DefaultKind == other.DefaultKind &&
+		ptrEqual(s.Default, other.Default) &&
+		ptrEqual(s.Check, other.Check)
+}
+
+// Equal reports whether two indexes have the same definition.
+func (i *Index) Equal(other *Index) bool {
+	if i == nil || other == nil {
+		return i == other
+	}
+
+	if i.Unique != other.Unique || i.PrimaryKey != other.PrimaryKey ||
+		!ptrEqual(i.Type, other.Type) || !ptrEqual(i.Name, other.Name) || !ptrEqual(i.Note, other.Note) {
+		return false
+	}
+
+	return indexColumnsEqual(i.Columns, other.Columns)
+}
+
+// indexColumnsEqual reports whether two indexes cover the same columns and
+// expressions in the same order.
+func indexColumnsEqual(a, b []IndexColumn) bool {
+	return slices.EqualFunc(a, b, func(x, y IndexColumn) bool {
+		return ptrEqual(x.Name, y.Name) && ptrEqual(x.Expression, y.Expression)
+	})
+}
+
+// Equal reports whether two refs have the same definition.
+func (r *Ref) Equal(other *Ref) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+
+	return r.Type == other.Type &&
+		ptrEqual(r.Name, other.Name) &&
+		ptrEqual(r.OnDelete, other.OnDelete) &&
+		ptrEqual(r.OnUpdate, other.OnUpdate) &&
+		ptrEqual(r.Color, other.Color) &&
+		ptrEqual(r.Deferrable, other.Deferrable) &&
+		r.Left.Equal(other.Left) &&
+		r.Right.Equal(other.Right)
+}
+
+// Equal reports whether two endpoints refer to the same columns.
+func (e *RefEndpoint) Equal(other *RefEndpoint) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+
+	return e.Schema == other.Schema && e.Table == other.Table && slices.Equal(e.Columns, other.Columns)
+}
+
+// Equal reports whether two enums have the same definition.
+func (e *Enum) Equal(other *Enum) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+
+	return e.Schema == other.Schema &&
+		e.Name == other.Name &&
+		ptrEqual(e.Note, other.Note) &&
+		maps.Equal(e.ValueNotes, other.ValueNotes) &&
+		slices.Equal(e.Values, other.Values)
+}
+
+// Equal reports whether two table groups have the same definition.
+func (g *TableGroup) Equal(other *TableGroup) bool {
+	if g == nil || other == nil {
+		return g == other
+	}
+
+	return g.Name == other.Name && ptrEqual(g.ParentGroup, other.ParentGroup) && slices.Equal(g.Tables, other.Tables)
+}
+
+func ptrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,78 @@
+package dbml
+
+import (
+	"testing"
+)
+
+func newEqualTestProject() *Project {
+	project := NewProject("test").
+		WithDatabaseType("PostgreSQL").
+		AddEnum(NewEnum("status", "active", "inactive")).
+		AddTable(NewTable("users").
+			WithHeaderColor("#fff").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar").WithDefault("''")).
+			AddIndex(NewIndex("email").WithUnique())).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")))
+
+	project.AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id"))
+	project.AddTableGroup(NewTableGroup("core").AddTable("public", "users"))
+
+	return project
+}
+
+func TestProject_Equal(t *testing.T) {
+	t.Run("identical projects", func(t *testing.T) {
+		if !newEqualTestProject().Equal(newEqualTestProject()) {
+			t.Error("Expected identical projects to be equal")
+		}
+	})
+
+	t.Run("nil handling", func(t *testing.T) {
+		var nilProject *Project
+		if !nilProject.Equal(nil) {
+			t.Error("Expected nil projects to be equal")
+		}
+		if newEqualTestProject().Equal(nil) {
+			t.Error("Expected project not to equal nil")
+		}
+	})
+
+	modifications := map[string]func(p *Project){
+		"name":         func(p *Project) { p.Name = "other" },
+		"table note":   func(p *Project) { p.Tables["public.users"].WithNote("note") },
+		"setting":      func(p *Project) { p.Tables["public.users"].WithSetting("engine", "InnoDB") },
+		"exclude ddl":  func(p *Project) { p.Tables["public.users"].WithExcludeFromDDL() },
+		"column type":  func(p *Project) { p.Tables["public.users"].Columns[1].Type = "text" },
+		"type alias":   func(p *Project) { p.Tables["public.users"].Columns[1].WithTypeAlias("text") },
+		"column null":  func(p *Project) { p.Tables["public.users"].Columns[1].WithNull() },
+		"default":      func(p *Project) { p.Tables["public.users"].Columns[1].WithDefault("'x'") },
+		"inline ref":   func(p *Project) { p.Tables["public.posts"].Columns[0].InlineRef.Column = "uuid" },
+		"index":        func(p *Project) { p.Tables["public.users"].Indexes[0].Unique = false },
+		"enum value":   func(p *Project) { p.Enums["public.status"].Values[1] = "banned" },
+		"ref action":   func(p *Project) { p.Refs[0].WithOnDelete(Cascade) },
+		"ref endpoint": func(p *Project) { p.Refs[0].Right.Columns = []string{"uuid"} },
+		"table group":  func(p *Project) { p.TableGroups[0].AddTable("public", "posts") },
+		"extra table":  func(p *Project) { p.AddTable(NewTable("tags").AddColumn(NewColumn("id", "int"))) },
+	}
+
+	for name, modify := range modifications {
+		t.Run("differs by "+name, func(t *testing.T) {
+			modified := newEqualTestProject()
+			modify(modified)
+			if newEqualTestProject().Equal(modified) {
+				t.Errorf("Expected projects differing by %s to be unequal", name)
+			}
+		})
+	}
+}
+
+func TestColumn_Equal_NilSettings(t *testing.T) {
+	a := &Column{Name: "id", Type: "int"}
+	b := &Column{Name: "id", Type: "int", Settings: &ColumnSettings{}}
+
+	if !a.Equal(b) {
+		t.Error("Expected nil settings to equal zero-valued settings")
+	}
+}
@@ -0,0 +1,35 @@
+package dbml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+)
+
+// ERDService identifies an online entity-relationship diagram service.
+type ERDService string
+
+// Supported ERD services. QuickDBD is not among them: it reads its own
+// schema syntax rather than DBML.
+const (
+	ERDServiceDBDiagram ERDService = "dbdiagram"
+)
+
+// GenerateERDiagramURL returns a URL that opens the project's DBML in the
+// given diagram service. The schema travels base64-encoded in the code
+// query parameter.
+func (p *Project) GenerateERDiagramURL(service ERDService) (string, error) {
+	var base string
+	switch service {
+	case ERDServiceDBDiagram:
+		base = "https://dbdiagram.io/d"
+	default:
+		return "", &ValidationError{
+			Field:   "ERDService",
+			Message: fmt.Sprintf("unsupported ERD service: %s", service),
+		}
+	}
+
+	code := base64.StdEncoding.EncodeToString([]byte(p.Generate()))
+	return base + "?code=" + url.QueryEscape(code), nil
+}
@@ -0,0 +1,43 @@
+package dbml
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateERDiagramURL(t *testing.T) {
+	project := NewProject("shop").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	t.Run("dbdiagram", func(t *testing.T) {
+		link, err := project.GenerateERDiagramURL(ERDServiceDBDiagram)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !strings.HasPrefix(link, "https://dbdiagram.io/d?code=") {
+			t.Fatalf("Expected dbdiagram.io URL, got %s", link)
+		}
+
+		parsed, err := url.Parse(link)
+		if err != nil {
+			t.Fatalf("Expected a valid URL, got: %v", err)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(parsed.Query().Get("code"))
+		if err != nil {
+			t.Fatalf("Expected base64 code, got: %v", err)
+		}
+		if string(decoded) != project.Generate() {
+			t.Errorf("Expected code to decode to the generated DBML, got:\n%s", decoded)
+		}
+	})
+
+	t.Run("unsupported service", func(t *testing.T) {
+		for _, service := range []ERDService{"lucidchart", "quickdbd"} {
+			if _, err := project.GenerateERDiagramURL(service); err == nil {
+				t.Errorf("Expected error for unsupported service %s", service)
+			}
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateFlywayMigration generates a Flyway versioned migration for a Project.
+// It returns the canonical file name (V<version>__<description>.sql) and the
+// SQL DDL for the project's database type.
+func (p *Project) GenerateFlywayMigration(version, description string) (filename, sql string) {
+	filename = fmt.Sprintf("V%s__%s.sql", version, migrationSlug(description))
+	sql = p.GenerateSQL(p.sqlDialect())
+	return filename, sql
+}
+
+// migrationSlug lowercases a description and replaces runs of non-alphanumeric
+// characters with single underscores.
+func migrationSlug(description string) string {
+	var b strings.Builder
+	pendingSep := false
+	for _, r := range strings.ToLower(strings.TrimSpace(description)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingSep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pendingSep = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingSep = true
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateFlywayMigration(t *testing.T) {
+	project := NewProject("test").
+		WithDatabaseType("PostgreSQL").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "bigint").WithPrimaryKey()))
+
+	filename, sql := project.GenerateFlywayMigration("1", "Create users")
+
+	if filename != "V1__create_users.sql" {
+		t.Errorf("Expected filename 'V1__create_users.sql', got '%s'", filename)
+	}
+
+	if !strings.Contains(sql, `CREATE TABLE "public"."users"`) {
+		t.Errorf("Expected SQL to contain users table, got:\n%s", sql)
+	}
+}
+
+func TestMigrationSlug(t *testing.T) {
+	tests := map[string]string{
+		"Create users":           "create_users",
+		"  add  FK -- to posts ": "add_fk_to_posts",
+		"v2.1 schema":            "v2_1_schema",
+	}
+
+	for input, want := range tests {
+		if got := migrationSlug(input); got != want {
+			t.Errorf("migrationSlug(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -2,13 +2,81 @@ package dbml
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 const defaultSchema = "public"
 
+// Generator generates DBML with a configurable default schema. Names in the
+// default schema are written unqualified; all others are schema-qualified.
+type Generator struct {
+	DefaultSchema string
+}
+
+// NewGenerator creates a Generator whose default schema is "public".
+func NewGenerator() *Generator {
+	return &Generator{DefaultSchema: defaultSchema}
+}
+
+// defaultGenerator backs the Generate methods on the model types.
+var defaultGenerator = NewGenerator()
+
+// SetDefaultSchema sets the default schema used by the package-level
+// Generate methods. Generator instances are not affected.
+func SetDefaultSchema(schema string) {
+	defaultGenerator.DefaultSchema = schema
+}
+
+// GenerateOptions controls optional aspects of DBML generation.
+type GenerateOptions struct {
+	// DBMLVersion selects the syntax: DBMLv1 (the default when empty) or
+	// DBMLv2, which writes multi-line notes as triple-quoted strings and refs in
+	// the one-line `Ref name: a.id < b.a_id` form.
+	DBMLVersion string
+	// OmitRedundantConstraints suppresses `not null` on primary key columns
+	// and `pk` on columns already covered by a primary key index.
+	OmitRedundantConstraints bool
+	// CompactSingleColumnIndexes writes single-column indexes without
+	// parentheses, e.g. `email [unique]` instead of `(email) [unique]`.
+	CompactSingleColumnIndexes bool
+	// UseShorthandRefs writes refs without a name or settings in the
+	// one-line form, e.g. `Ref: posts.user_id > users.id`.
+	UseShorthandRefs bool
+	// InlineTableNotes writes table notes as a setting of the table
+	// declaration, e.g. `Table users [note: 'text'] {`, instead of a Note
+	// line inside the block.
+	InlineTableNotes bool
+}
+
+// DBML syntax versions for GenerateOptions.DBMLVersion.
+const (
+	DBMLv1 = "v1"
+	DBMLv2 = "v2"
+)
+
 // Generate generates the DBML syntax from a Project.
 func (p *Project) Generate() string {
+	return defaultGenerator.Generate(p)
+}
+
+// GenerateDBMLv2 generates DBML v2 syntax from a Project.
+func (p *Project) GenerateDBMLv2() string {
+	return p.GenerateWithOptions(GenerateOptions{DBMLVersion: DBMLv2})
+}
+
+// GenerateWithOptions generates the DBML syntax from a Project using the given options.
+func (p *Project) GenerateWithOptions(opts GenerateOptions) string {
+	return defaultGenerator.GenerateWithOptions(p, opts)
+}
+
+// Generate generates the DBML syntax from a Project.
+func (g *Generator) Generate(p *Project) string {
+	return g.GenerateWithOptions(p, GenerateOptions{})
+}
+
+// GenerateWithOptions generates the DBML syntax from a Project using the given options.
+func (g *Generator) GenerateWithOptions(p *Project, opts GenerateOptions) string {
 	var b strings.Builder
 
 	// Project definition
@@ -18,47 +86,112 @@ func (p *Project) Generate() string {
 			b.WriteString(fmt.Sprintf("  database_type: '%s'\n", *p.DatabaseType))
 		}
 		if p.Note != nil {
-			b.WriteString(fmt.Sprintf("  Note: '%s'\n", escapeString(*p.Note)))
+			b.WriteString(fmt.Sprintf("  Note: %s\n", formatNote(*p.Note, opts)))
 		}
 		b.WriteString("}\n\n")
 	}
 
+	// Schemas with options
+	for _, name := range sortedKeys(p.Schemas) {
+		if schema := p.Schemas[name]; schema.hasOptions() {
+			b.WriteString(schema.generate(opts))
+			b.WriteString("\n")
+		}
+	}
+
 	// Enums
 	for _, enum := range p.Enums {
-		b.WriteString(enum.Generate())
+		b.WriteString(g.generateEnum(enum, opts))
 		b.WriteString("\n")
 	}
 
 	// Tables
-	for _, table := range p.Tables {
-		b.WriteString(table.Generate())
+	for _, key := range p.orderedTableKeys() {
+		b.WriteString(g.generateTable(p.Tables[key], opts))
 		b.WriteString("\n")
 	}
 
 	// Relationships
 	for _, ref := range p.Refs {
-		b.WriteString(ref.Generate())
+		b.WriteString(g.generateRef(ref, opts))
 		b.WriteString("\n")
 	}
 
 	// Table Groups
 	for _, group := range p.TableGroups {
-		b.WriteString(group.Generate())
+		b.WriteString(g.GenerateTableGroup(group))
 		b.WriteString("\n")
 	}
 
 	return b.String()
 }
 
+// DBML returns the action as written in DBML ref settings, e.g. "set null".
+func (a RefAction) DBML() string {
+	return strings.ToLower(string(a))
+}
+
+// Generate generates the DBML syntax for a Schema.
+func (s *Schema) Generate() string {
+	return s.generate(GenerateOptions{})
+}
+
+func (s *Schema) generate(opts GenerateOptions) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Schema %s {\n", s.Name))
+	if s.Owner != nil {
+		b.WriteString(fmt.Sprintf("  owner: '%s'\n", escapeString(*s.Owner)))
+	}
+	if s.SearchPath {
+		b.WriteString("  search_path: true\n")
+	}
+	if s.Comment != nil {
+		b.WriteString(fmt.Sprintf("  Note: %s\n", formatNote(*s.Comment, opts)))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (s *Schema) hasOptions() bool {
+	return s.Owner != nil || s.Comment != nil || s.SearchPath
+}
+
+// orderedTableKeys returns the keys listed in TableOrder that exist in the
+// project, followed by the remaining table keys in sorted order.
+func (p *Project) orderedTableKeys() []string {
+	keys := make([]string, 0, len(p.Tables))
+	seen := make(map[string]bool, len(p.Tables))
+	for _, key := range p.TableOrder {
+		if _, ok := p.Tables[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	for _, key := range sortedKeys(p.Tables) {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // Generate generates the DBML syntax for a Table.
 func (t *Table) Generate() string {
+	return defaultGenerator.GenerateTable(t)
+}
+
+// GenerateTable generates the DBML syntax for a Table.
+func (g *Generator) GenerateTable(t *Table) string {
+	return g.generateTable(t, GenerateOptions{})
+}
+
+func (g *Generator) generateTable(t *Table, opts GenerateOptions) string {
 	var b strings.Builder
 
 	// Table header
-	tableName := t.Name
-	if t.Schema != defaultSchema {
-		tableName = t.Schema + "." + t.Name
-	}
+	tableName := g.qualifiedName(t.Schema, t.Name)
 	if t.Alias != nil {
 		tableName += " as " + *t.Alias
 	}
@@ -66,12 +199,25 @@ func (t *Table) Generate() string {
 	b.WriteString(fmt.Sprintf("Table %s", tableName))
 
 	// Table settings
-	if len(t.Settings) > 0 {
-		b.WriteString(" [")
-		settings := []string{}
-		for key, value := range t.Settings {
-			settings = append(settings, fmt.Sprintf("%s: %s", key, value))
+	settings := []string{}
+	for key, value := range t.Settings {
+		settings = append(settings, fmt.Sprintf("%s: %s", key, value))
+	}
+	for _, key := range sortedKeys(t.StorageParams) {
+		settings = append(settings, fmt.Sprintf("%s: %s", key, t.StorageParams[key]))
+	}
+	if t.RowLevelSecurity != nil {
+		if *t.RowLevelSecurity {
+			settings = append(settings, "rls: enabled")
+		} else {
+			settings = append(settings, "rls: disabled")
 		}
+	}
+	if opts.InlineTableNotes && t.Note != nil {
+		settings = append(settings, "note: "+formatNote(*t.Note, opts))
+	}
+	if len(settings) > 0 {
+		b.WriteString(" [")
 		b.WriteString(strings.Join(settings, ", "))
 		b.WriteString("]")
 	}
@@ -80,8 +226,9 @@ func (t *Table) Generate() string {
 
 	// Columns
 	for _, col := range t.Columns {
+		omitPK := opts.OmitRedundantConstraints && t.hasPrimaryKeyIndexOn(col.Name)
 		b.WriteString("  ")
-		b.WriteString(col.Generate())
+		b.WriteString(col.generate(opts, omitPK))
 		b.WriteString("\n")
 	}
 
@@ -90,15 +237,15 @@ func (t *Table) Generate() string {
 		b.WriteString("\n  indexes {\n")
 		for _, idx := range t.Indexes {
 			b.WriteString("    ")
-			b.WriteString(idx.Generate())
+			b.WriteString(idx.generate(opts))
 			b.WriteString("\n")
 		}
 		b.WriteString("  }\n")
 	}
 
 	// Table note
-	if t.Note != nil {
-		b.WriteString(fmt.Sprintf("\n  Note: '%s'\n", escapeString(*t.Note)))
+	if t.Note != nil && !opts.InlineTableNotes {
+		b.WriteString(fmt.Sprintf("\n  Note: %s\n", formatNote(*t.Note, opts)))
 	}
 
 	b.WriteString("}\n")
@@ -108,60 +255,77 @@ func (t *Table) Generate() string {
 
 // Generate generates the DBML syntax for a Column.
 func (c *Column) Generate() string {
+	return c.generate(GenerateOptions{}, false)
+}
+
+func (c *Column) generate(opts GenerateOptions, omitPK bool) string {
 	var b strings.Builder
 
 	b.WriteString(fmt.Sprintf("%s %s", c.Name, c.Type))
 
-	// Column settings
+	settings := c.dbmlSettings(opts, omitPK)
+
+	// Column note
+	if c.Note != nil {
+		settings = append(settings, "note: "+formatNote(*c.Note, opts))
+	}
+
+	if len(settings) > 0 {
+		b.WriteString(" [")
+		b.WriteString(strings.Join(settings, ", "))
+		b.WriteString("]")
+	}
+
+	return b.String()
+}
+
+// dbmlSettings returns the column's DBML settings other than its note.
+func (c *Column) dbmlSettings(opts GenerateOptions, omitPK bool) []string {
 	settings := []string{}
 
 	if c.Settings != nil {
-		if c.Settings.PrimaryKey {
+		if c.Settings.PrimaryKey && !omitPK {
 			settings = append(settings, "pk")
 		}
 		if c.Settings.Unique {
 			settings = append(settings, "unique")
 		}
-		if !c.Settings.Null {
+		if !c.Settings.Null && !(opts.OmitRedundantConstraints && c.Settings.PrimaryKey) {
 			settings = append(settings, "not null")
 		}
 		if c.Settings.Increment {
 			settings = append(settings, "increment")
 		}
 		if c.Settings.Default != nil {
-			settings = append(settings, fmt.Sprintf("default: %s", *c.Settings.Default))
+			settings = append(settings, "default: "+formatDefault(*c.Settings.Default, c.Settings.DefaultKind))
 		}
 		if c.Settings.Check != nil {
 			settings = append(settings, fmt.Sprintf("check: '%s'", escapeString(*c.Settings.Check)))
 		}
 	}
 
+	if c.ComputedAlias != nil {
+		settings = append(settings, fmt.Sprintf("computed: '%s'", escapeString(*c.ComputedAlias)))
+	}
+
 	// Inline relationship
 	if c.InlineRef != nil {
 		refTarget := fmt.Sprintf("%s.%s.%s", c.InlineRef.Schema, c.InlineRef.Table, c.InlineRef.Column)
 		settings = append(settings, fmt.Sprintf("ref: %s %s", c.InlineRef.Type, refTarget))
 	}
 
-	// Column note
-	if c.Note != nil {
-		settings = append(settings, fmt.Sprintf("note: '%s'", escapeString(*c.Note)))
-	}
-
-	if len(settings) > 0 {
-		b.WriteString(" [")
-		b.WriteString(strings.Join(settings, ", "))
-		b.WriteString("]")
-	}
-
-	return b.String()
+	return settings
 }
 
 // Generate generates the DBML syntax for an Index.
 func (i *Index) Generate() string {
+	return i.generate(GenerateOptions{})
+}
+
+func (i *Index) generate(opts GenerateOptions) string {
 	var b strings.Builder
 
 	// Index columns
-	b.WriteString("(")
 	columns := []string{}
 	for _, col := range i.Columns {
 		if col.Name != nil {
@@ -170,8 +334,11 @@ func (i *Index) Generate() string {
 			columns = append(columns, fmt.Sprintf("`%s`", *col.Expression))
 		}
 	}
-	b.WriteString(strings.Join(columns, ", "))
-	b.WriteString(")")
+	if opts.CompactSingleColumnIndexes && len(i.Columns) == 1 && i.Columns[0].Name != nil {
+		b.WriteString(columns[0])
+	} else {
+		b.WriteString("(" + strings.Join(columns, ", ") + ")")
+	}
 
 	// Index settings
 	settings := []string{}
@@ -189,7 +356,7 @@ func (i *Index) Generate() string {
 		settings = append(settings, fmt.Sprintf("name: '%s'", escapeString(*i.Name)))
 	}
 	if i.Note != nil {
-		settings = append(settings, fmt.Sprintf("note: '%s'", escapeString(*i.Note)))
+		settings = append(settings, "note: "+formatNote(*i.Note, opts))
 	}
 
 	if len(settings) > 0 {
@@ -203,11 +370,37 @@ func (i *Index) Generate() string {
 
 // Generate generates the DBML syntax for a Ref.
 func (r *Ref) Generate() string {
+	return defaultGenerator.GenerateRef(r)
+}
+
+// GenerateRef generates the DBML syntax for a Ref.
+func (g *Generator) GenerateRef(r *Ref) string {
+	return g.generateRef(r, GenerateOptions{})
+}
+
+// GenerateShorthand generates the one-line DBML syntax for a Ref,
+// e.g. `Ref: posts.user_id > users.id`.
+func (r *Ref) GenerateShorthand() string {
+	return defaultGenerator.GenerateRefShorthand(r)
+}
+
+// GenerateRefShorthand generates the one-line DBML syntax for a Ref.
+func (g *Generator) GenerateRefShorthand(r *Ref) string {
+	return g.generateRef(r, GenerateOptions{DBMLVersion: DBMLv2})
+}
+
+// hasSettings reports whether the ref has a name or settings, which
+// UseShorthandRefs keeps in block syntax.
+func (r *Ref) hasSettings() bool {
+	return r.Name != nil || r.OnDelete != nil || r.OnUpdate != nil || r.Color != nil
+}
+
+func (g *Generator) generateRef(r *Ref, opts GenerateOptions) string {
 	var b strings.Builder
 
 	// Ref name (optional)
 	if r.Name != nil {
-		b.WriteString(fmt.Sprintf("Ref %s", *r.Name))
+		b.WriteString(fmt.Sprintf("Ref %s", formatName(*r.Name)))
 	} else {
 		b.WriteString("Ref")
 	}
@@ -215,15 +408,31 @@ func (r *Ref) Generate() string {
 	// Relationship settings
 	settings := []string{}
 	if r.OnDelete != nil {
-		settings = append(settings, fmt.Sprintf("delete: %s", *r.OnDelete))
+		settings = append(settings, "delete: "+r.OnDelete.DBML())
 	}
 	if r.OnUpdate != nil {
-		settings = append(settings, fmt.Sprintf("update: %s", *r.OnUpdate))
+		settings = append(settings, "update: "+r.OnUpdate.DBML())
 	}
 	if r.Color != nil {
 		settings = append(settings, fmt.Sprintf("color: %s", *r.Color))
 	}
 
+	// Left side
+	leftRef := g.formatRefEndpoint(r.Left)
+
+	// Right side
+	rightRef := g.formatRefEndpoint(r.Right)
+
+	// Short form: Ref name: left > right [settings]
+	if opts.DBMLVersion == DBMLv2 || (opts.UseShorthandRefs && !r.hasSettings()) {
+		b.WriteString(fmt.Sprintf(": %s %s %s", leftRef, r.Type, rightRef))
+		if len(settings) > 0 {
+			b.WriteString(" [" + strings.Join(settings, ", ") + "]")
+		}
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	if len(settings) > 0 {
 		b.WriteString(" [")
 		b.WriteString(strings.Join(settings, ", "))
@@ -231,13 +440,6 @@ func (r *Ref) Generate() string {
 	}
 
 	b.WriteString(" {\n")
-
-	// Left side
-	leftRef := formatRefEndpoint(r.Left)
-
-	// Right side
-	rightRef := formatRefEndpoint(r.Right)
-
 	b.WriteString(fmt.Sprintf("  %s %s %s\n", leftRef, r.Type, rightRef))
 	b.WriteString("}\n")
 
@@ -246,26 +448,31 @@ func (r *Ref) Generate() string {
 
 // Generate generates the DBML syntax for an Enum.
 func (e *Enum) Generate() string {
+	return defaultGenerator.GenerateEnum(e)
+}
+
+// GenerateEnum generates the DBML syntax for an Enum.
+func (g *Generator) GenerateEnum(e *Enum) string {
+	return g.generateEnum(e, GenerateOptions{})
+}
+
+func (g *Generator) generateEnum(e *Enum, opts GenerateOptions) string {
 	var b strings.Builder
 
-	enumName := e.Name
-	if e.Schema != defaultSchema {
-		enumName = e.Schema + "." + e.Name
-	}
+	enumName := g.qualifiedName(e.Schema, e.Name)
 
 	b.WriteString(fmt.Sprintf("Enum %s {\n", enumName))
 
 	for _, value := range e.Values {
-		// Quote values if they contain spaces
-		if strings.Contains(value, " ") {
-			b.WriteString(fmt.Sprintf("  %q\n", value))
-		} else {
-			b.WriteString(fmt.Sprintf("  %s\n", value))
+		b.WriteString("  " + formatName(value))
+		if note, ok := e.ValueNotes[value]; ok {
+			b.WriteString(fmt.Sprintf(" [note: %s]", formatNote(note, opts)))
 		}
+		b.WriteString("\n")
 	}
 
 	if e.Note != nil {
-		b.WriteString(fmt.Sprintf("\n  Note: '%s'\n", escapeString(*e.Note)))
+		b.WriteString(fmt.Sprintf("\n  Note: %s\n", formatNote(*e.Note, opts)))
 	}
 
 	b.WriteString("}\n")
@@ -275,16 +482,17 @@ func (e *Enum) Generate() string {
 
 // Generate generates the DBML syntax for a TableGroup.
 func (tg *TableGroup) Generate() string {
+	return defaultGenerator.GenerateTableGroup(tg)
+}
+
+// GenerateTableGroup generates the DBML syntax for a TableGroup.
+func (g *Generator) GenerateTableGroup(tg *TableGroup) string {
 	var b strings.Builder
 
 	b.WriteString(fmt.Sprintf("TableGroup %s {\n", tg.Name))
 
 	for _, tableRef := range tg.Tables {
-		tableName := tableRef.Name
-		if tableRef.Schema != defaultSchema {
-			tableName = tableRef.Schema + "." + tableRef.Name
-		}
-		b.WriteString(fmt.Sprintf("  %s\n", tableName))
+		b.WriteString(fmt.Sprintf("  %s\n", g.qualifiedName(tableRef.Schema, tableRef.Name)))
 	}
 
 	b.WriteString("}\n")
@@ -294,15 +502,30 @@ func (tg *TableGroup) Generate() string {
 
 // Helper functions
 
-func formatRefEndpoint(endpoint *RefEndpoint) string {
+// hasPrimaryKeyIndexOn reports whether a primary key index covers the named column.
+func (t *Table) hasPrimaryKeyIndexOn(column string) bool {
+	for _, idx := range t.Indexes {
+		if idx.PrimaryKey && idx.ContainsColumn(column) {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedName prefixes name with its schema unless it is the default schema.
+func (g *Generator) qualifiedName(schema, name string) string {
+	if schema != g.DefaultSchema {
+		return schema + "." + name
+	}
+	return name
+}
+
+func (g *Generator) formatRefEndpoint(endpoint *RefEndpoint) string {
 	if endpoint == nil {
 		return ""
 	}
 
-	tableName := endpoint.Table
-	if endpoint.Schema != defaultSchema {
-		tableName = endpoint.Schema + "." + endpoint.Table
-	}
+	tableName := g.qualifiedName(endpoint.Schema, endpoint.Table)
 
 	if len(endpoint.Columns) == 1 {
 		return fmt.Sprintf("%s.%s", tableName, endpoint.Columns[0])
@@ -312,6 +535,69 @@ func formatRefEndpoint(endpoint *RefEndpoint) string {
 	return fmt.Sprintf("%s.(%s)", tableName, strings.Join(endpoint.Columns, ", "))
 }
 
+// formatDefault writes a default value in DBML: expressions in backticks,
+// strings in single quotes, and numbers, booleans and null bare.
+func formatDefault(value string, kind DefaultKind) string {
+	switch kind {
+	case DefaultKindExpression:
+		if strings.HasPrefix(value, "`") && strings.HasSuffix(value, "`") && len(value) >= 2 {
+			return value
+		}
+		return "`" + value + "`"
+	case DefaultKindString:
+		// SQL-quoted defaults are unquoted and re-escaped for DBML
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+		return "'" + escapeString(value) + "'"
+	default:
+		return value
+	}
+}
+
+// formatName writes a name bare if it is a plain identifier and
+// double-quoted otherwise, e.g. for enum values with spaces or colons.
+func formatName(name string) string {
+	if name == "" || strings.IndexFunc(name, func(r rune) bool { return !isIdentRune(r) }) >= 0 {
+		return strconv.Quote(name)
+	}
+	return name
+}
+
+// formatNote quotes a note. DBML v2 writes multi-line notes as triple-quoted
+// strings; everything else is single-quoted.
+func formatNote(note string, opts GenerateOptions) string {
+	if opts.DBMLVersion == DBMLv2 && strings.Contains(note, "\n") {
+		return "'''" + escapeTripleQuoted(note) + "'''"
+	}
+	return "'" + escapeString(note) + "'"
+}
+
+// escapeTripleQuoted escapes the quotes of a triple-quoted string that could
+// end it early: those in runs of three or more, and those at the end, which
+// would run into the closing quotes.
+func escapeTripleQuoted(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\'' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		run := 1
+		for i+run < len(s) && s[i+run] == '\'' {
+			run++
+		}
+		if run >= 3 || i+run == len(s) {
+			b.WriteString(strings.Repeat(`\'`, run))
+		} else {
+			b.WriteString(strings.Repeat("'", run))
+		}
+		i += run
+	}
+	return b.String()
+}
+
 func escapeString(s string) string {
 	s = strings.ReplaceAll(s, "'", "\\'")
 	return s
@@ -0,0 +1,346 @@
+package dbml
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// GoInterfaceOptions controls which repository methods GenerateFlyweightInterfaces emits.
+type GoInterfaceOptions struct {
+	Package       string // package clause, defaults to "models"
+	IncludeCRUD   bool   // Create, Update and Delete methods
+	IncludeFilter bool   // a <Struct>Filter type and a List method
+}
+
+const defaultGoPackage = "models"
+
+// GenerateFlyweightInterfaces generates a Go repository interface per table.
+// Every table gets lookup methods for its primary key and unique columns,
+// returning the table's struct type (users -> *User).
+func (p *Project) GenerateFlyweightInterfaces(opts GoInterfaceOptions) string {
+	var b strings.Builder
+
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = defaultGoPackage
+	}
+
+	var body strings.Builder
+	for _, key := range sortedKeys(p.Tables) {
+		body.WriteString("\n")
+		body.WriteString(p.Tables[key].goRepositoryInterface(opts))
+	}
+
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("package %s\n\n", pkg))
+	writeGoImports(&b, goImportsFor(body.String(), "context"))
+	b.WriteString(body.String())
+
+	return b.String()
+}
+
+// GenerateGoStructs generates a Go struct per table in package models.
+// Every field gets a db tag with the column name; tags set with
+// Column.WithTagValue are added after it, and may override db.
+func (p *Project) GenerateGoStructs() string {
+	var b strings.Builder
+
+	var body strings.Builder
+	for _, key := range sortedKeys(p.Tables) {
+		body.WriteString("\n")
+		body.WriteString(p.Tables[key].goStruct())
+	}
+
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("package %s\n\n", defaultGoPackage))
+	writeGoImports(&b, goImportsFor(body.String()))
+	b.WriteString(body.String())
+
+	return b.String()
+}
+
+// goImportsFor returns the base imports plus the standard library packages
+// referenced by generated Go code, sorted.
+func goImportsFor(code string, base ...string) []string {
+	imports := map[string]bool{}
+	for _, pkg := range base {
+		imports[pkg] = true
+	}
+	if strings.Contains(code, "time.Time") {
+		imports["time"] = true
+	}
+	if strings.Contains(code, "json.RawMessage") {
+		imports["encoding/json"] = true
+	}
+	return sortedKeys(imports)
+}
+
+func writeGoImports(b *strings.Builder, imports []string) {
+	switch len(imports) {
+	case 0:
+		return
+	case 1:
+		b.WriteString(fmt.Sprintf("import %q\n", imports[0]))
+	default:
+		b.WriteString("import (\n")
+		for _, pkg := range imports {
+			b.WriteString(fmt.Sprintf("\t%q\n", pkg))
+		}
+		b.WriteString(")\n")
+	}
+}
+
+func (t *Table) goRepositoryInterface(opts GoInterfaceOptions) string {
+	var b strings.Builder
+
+	structName := goStructName(t.Name)
+	pkColumns := t.columnsByName(t.primaryKeyColumns())
+
+	if opts.IncludeFilter {
+		b.WriteString(fmt.Sprintf("// %sFilter selects %s rows; nil fields are ignored.\n", structName, t.Name))
+		b.WriteString(fmt.Sprintf("type %sFilter struct {\n", structName))
+		for _, col := range t.Columns {
+			b.WriteString(fmt.Sprintf("\t%s *%s\n", goExportedName(col.Name), strings.TrimPrefix(col.goType(), "*")))
+		}
+		b.WriteString("\tLimit  int\n")
+		b.WriteString("\tOffset int\n")
+		b.WriteString("}\n\n")
+	}
+
+	b.WriteString(fmt.Sprintf("// %sRepository provides access to the %s table.\n", structName, t.Name))
+	b.WriteString(fmt.Sprintf("type %sRepository interface {\n", structName))
+
+	if len(pkColumns) > 0 {
+		b.WriteString(fmt.Sprintf("\t%s(ctx context.Context, %s) (*%s, error)\n",
+			goFindByName(pkColumns), goParams(pkColumns), structName))
+	}
+
+	for _, col := range t.Columns {
+		if col.Settings == nil || !col.Settings.Unique || (len(pkColumns) == 1 && pkColumns[0] == col) {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("\t%s(ctx context.Context, %s) (*%s, error)\n",
+			goFindByName([]*Column{col}), goParams([]*Column{col}), structName))
+	}
+
+	if opts.IncludeFilter {
+		b.WriteString(fmt.Sprintf("\tList(ctx context.Context, filter %sFilter) ([]*%s, error)\n", structName, structName))
+	}
+
+	if opts.IncludeCRUD {
+		record := goParamName(structName)
+		b.WriteString(fmt.Sprintf("\tCreate(ctx context.Context, %s *%s) error\n", record, structName))
+		b.WriteString(fmt.Sprintf("\tUpdate(ctx context.Context, %s *%s) error\n", record, structName))
+		if len(pkColumns) > 0 {
+			b.WriteString(fmt.Sprintf("\tDelete(ctx context.Context, %s) error\n", goParams(pkColumns)))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (t *Table) goStruct() string {
+	var b strings.Builder
+
+	structName := goStructName(t.Name)
+
+	names := make([]string, len(t.Columns))
+	types := make([]string, len(t.Columns))
+	nameWidth, typeWidth := 0, 0
+	for i, col := range t.Columns {
+		names[i] = goExportedName(col.Name)
+		types[i] = col.goType()
+		nameWidth = max(nameWidth, len(names[i]))
+		typeWidth = max(typeWidth, len(types[i]))
+	}
+
+	b.WriteString(fmt.Sprintf("// %s is a row of the %s table.\n", structName, t.Name))
+	b.WriteString(fmt.Sprintf("type %s struct {\n", structName))
+	for i, col := range t.Columns {
+		b.WriteString(fmt.Sprintf("\t%-*s %-*s `%s`\n", nameWidth, names[i], typeWidth, types[i], col.goStructTag()))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// goStructTag builds the field tag: db first, then the column's tags sorted by key.
+func (c *Column) goStructTag() string {
+	tags := []string{}
+	if _, ok := c.Tags["db"]; !ok {
+		tags = append(tags, fmt.Sprintf("db:%q", c.Name))
+	}
+	for _, tag := range sortedKeys(c.Tags) {
+		tags = append(tags, fmt.Sprintf("%s:%q", tag, c.Tags[tag]))
+	}
+	return strings.Join(tags, " ")
+}
+
+// columnsByName returns the table's columns matching names, in the order given.
+func (t *Table) columnsByName(names []string) []*Column {
+	columns := []*Column{}
+	for _, name := range names {
+		for _, col := range t.Columns {
+			if col.Name == name {
+				columns = append(columns, col)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+func goFindByName(columns []*Column) string {
+	var b strings.Builder
+	b.WriteString("FindBy")
+	for i, col := range columns {
+		if i > 0 {
+			b.WriteString("And")
+		}
+		b.WriteString(goExportedName(col.Name))
+	}
+	return b.String()
+}
+
+func goParams(columns []*Column) string {
+	params := make([]string, len(columns))
+	for i, col := range columns {
+		params[i] = goParamName(col.Name) + " " + strings.TrimPrefix(col.goType(), "*")
+	}
+	return strings.Join(params, ", ")
+}
+
+// goType maps the column's SQL type, its type alias if set, to a Go type.
+// Nullable columns and columns with a database-generated default map to
+// pointers, so that they can be left unset.
+func (c *Column) goType() string {
+	base, isArray := baseSQLType(c.sqlType())
+
+	var goType string
+	switch base {
+	case "bigint", "int8", "bigserial", "serial8":
+		goType = "int64"
+	case "int", "integer", "int4", "serial", "serial4", "mediumint":
+		goType = "int32"
+	case "smallint", "int2", "smallserial", "serial2", "tinyint":
+		goType = "int16"
+	case "boolean", "bool":
+		goType = "bool"
+	case "real", "float4", "float":
+		goType = "float32"
+	case "double", "double precision", "float8", "numeric", "decimal", "money":
+		goType = "float64"
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone",
+		"date", "datetime", "time", "timetz":
+		goType = "time.Time"
+	case "json", "jsonb":
+		goType = "json.RawMessage"
+	case "bytea", "blob", "binary", "varbinary":
+		goType = "[]byte"
+	default:
+		goType = "string"
+	}
+
+	if isArray {
+		return "[]" + goType
+	}
+	if c.Settings != nil && (c.Settings.Null || c.Settings.DatabaseDefault) && !strings.HasPrefix(goType, "[]") && goType != "json.RawMessage" {
+		return "*" + goType
+	}
+	return goType
+}
+
+// baseSQLType lowercases a column type and strips size arguments and array
+// suffixes: "VARCHAR(255)" -> "varchar", "int[]" -> "int" (array).
+func baseSQLType(colType string) (base string, isArray bool) {
+	base = strings.ToLower(strings.TrimSpace(colType))
+	if strings.HasSuffix(base, "[]") {
+		isArray = true
+		base = strings.TrimSuffix(base, "[]")
+	}
+	if i := strings.Index(base, "("); i >= 0 {
+		base = strings.TrimSpace(base[:i])
+	}
+	return base, isArray
+}
+
+// goInitialisms are name parts written in upper case in Go identifiers.
+var goInitialisms = map[string]bool{
+	"api": true, "db": true, "html": true, "http": true, "id": true, "ip": true,
+	"json": true, "sql": true, "ssn": true, "uri": true, "url": true, "uuid": true,
+}
+
+// goExportedName converts a snake_case name to an exported Go identifier: user_id -> UserID.
+func goExportedName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		lower := strings.ToLower(part)
+		if goInitialisms[lower] {
+			b.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		runes := []rune(part)
+		b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	if first := []rune(b.String())[0]; unicode.IsDigit(first) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// goParamName converts a name to an unexported Go identifier: user_id -> userID.
+func goParamName(name string) string {
+	exported := goExportedName(name)
+
+	// Lower the leading run of upper case letters, keeping the last one of a
+	// run that starts a new word (IDNumber -> idNumber).
+	runes := []rune(exported)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	if i > 1 && i < len(runes) && unicode.IsLower(runes[i]) {
+		i--
+	}
+	if i == 0 {
+		i = 1
+	}
+	param := strings.ToLower(string(runes[:i])) + string(runes[i:])
+
+	if token.IsKeyword(param) {
+		return param + "_"
+	}
+	return param
+}
+
+// goStructName converts a table name to a singular exported Go type name: user_roles -> UserRole.
+func goStructName(tableName string) string {
+	return goExportedName(singularize(tableName))
+}
+
+// singularize applies simple English plural rules to the last word of a name.
+func singularize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), strings.HasSuffix(lower, "is"):
+		return name
+	case strings.HasSuffix(lower, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	default:
+		return name
+	}
+}
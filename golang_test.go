@@ -0,0 +1,175 @@
+package dbml
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+)
+
+func newGoTestProject() *Project {
+	return NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique()).
+			AddColumn(NewColumn("created_at", "timestamp"))).
+		AddTable(NewTable("categories").
+			AddColumn(NewColumn("code", "varchar").WithPrimaryKey()).
+			AddColumn(NewColumn("type", "varchar").WithNull())).
+		AddTable(NewTable("memberships").
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("group_id", "int")).
+			AddIndex(NewIndex("user_id", "group_id").WithPrimaryKey()))
+}
+
+func TestProject_GenerateFlyweightInterfaces(t *testing.T) {
+	output := newGoTestProject().GenerateFlyweightInterfaces(GoInterfaceOptions{})
+
+	if _, err := format.Source([]byte(output)); err != nil {
+		t.Fatalf("Expected valid Go source, got error: %v\n%s", err, output)
+	}
+
+	expected := []string{
+		"package models",
+		`import "context"`,
+		"type UserRepository interface {",
+		"FindByID(ctx context.Context, id int64) (*User, error)",
+		"FindByEmail(ctx context.Context, email string) (*User, error)",
+		"type CategoryRepository interface {",
+		"FindByCode(ctx context.Context, code string) (*Category, error)",
+		"FindByUserIDAndGroupID(ctx context.Context, userID int64, groupID int32) (*Membership, error)",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "Create(") || strings.Contains(output, "List(") {
+		t.Errorf("Expected no CRUD or filter methods by default, got:\n%s", output)
+	}
+}
+
+func TestProject_GenerateFlyweightInterfaces_Options(t *testing.T) {
+	output := newGoTestProject().GenerateFlyweightInterfaces(GoInterfaceOptions{
+		Package:       "repo",
+		IncludeCRUD:   true,
+		IncludeFilter: true,
+	})
+
+	if _, err := format.Source([]byte(output)); err != nil {
+		t.Fatalf("Expected valid Go source, got error: %v\n%s", err, output)
+	}
+
+	expected := []string{
+		"package repo",
+		"\t\"time\"",
+		"type UserFilter struct {",
+		"CreatedAt *time.Time",
+		"Type *string",
+		"List(ctx context.Context, filter UserFilter) ([]*User, error)",
+		"Create(ctx context.Context, user *User) error",
+		"Update(ctx context.Context, category *Category) error",
+		"Delete(ctx context.Context, userID int64, groupID int32) error",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestGoNaming(t *testing.T) {
+	exported := map[string]string{
+		"user_id":    "UserID",
+		"api_url":    "APIURL",
+		"created_at": "CreatedAt",
+		"2fa_code":   "X2faCode",
+	}
+	for input, want := range exported {
+		if got := goExportedName(input); got != want {
+			t.Errorf("goExportedName(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	params := map[string]string{
+		"user_id":   "userID",
+		"id":        "id",
+		"id_number": "idNumber",
+		"type":      "type_",
+	}
+	for input, want := range params {
+		if got := goParamName(input); got != want {
+			t.Errorf("goParamName(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	structs := map[string]string{
+		"users":      "User",
+		"categories": "Category",
+		"addresses":  "Address",
+		"boxes":      "Box",
+		"status":     "Status",
+		"user_roles": "UserRole",
+	}
+	for input, want := range structs {
+		if got := goStructName(input); got != want {
+			t.Errorf("goStructName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestProject_GenerateGoStructs(t *testing.T) {
+	project := newGoTestProject()
+	project.Tables["public.users"].Columns[0].WithTagValue("gorm", "primaryKey").WithTagValue("json", "id")
+	project.Tables["public.users"].Columns[1].WithTagValue("db", "email_address")
+
+	output := project.GenerateGoStructs()
+
+	formatted, err := format.Source([]byte(output))
+	if err != nil {
+		t.Fatalf("Expected valid Go source, got error: %v\n%s", err, output)
+	}
+	if string(formatted) != output {
+		t.Errorf("Expected gofmt-formatted output, got:\n%s", output)
+	}
+
+	expected := []string{
+		"package models",
+		`import "time"`,
+		"type User struct {",
+		"ID        int64     `db:\"id\" gorm:\"primaryKey\" json:\"id\"`",
+		"Email     string    `db:\"email_address\"`",
+		"CreatedAt time.Time `db:\"created_at\"`",
+		"type Category struct {",
+		"Type *string `db:\"type\"`",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestProject_GenerateGoStructs_DatabaseDefaultAndTypeAlias(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("sessions").
+			AddColumn(NewColumn("token", "uuid").WithDefault("gen_random_uuid()").WithDatabaseDefault()).
+			AddColumn(NewColumn("hits", "counter").WithTypeAlias("bigint")).
+			AddColumn(NewColumn("note", "text")))
+
+	output := project.GenerateGoStructs()
+
+	expected := []string{
+		"Token *string `db:\"token\"`",
+		"Hits  int64   `db:\"hits\"`",
+		"Note  string  `db:\"note\"`",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
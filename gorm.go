@@ -0,0 +1,186 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gormField is one field of a generated GORM model.
+type gormField struct {
+	name string
+	typ  string
+	tag  string
+}
+
+// GenerateGORMModels generates GORM v2 models in package models. Columns get
+// gorm tags for their column name and constraints; foreign keys become
+// belongs-to fields on the child model and has-many (or has-one) fields on
+// the parent, tagged with foreignKey and references. Each model has a
+// TableName method so that GORM's naming strategy is not relied upon.
+func (p *Project) GenerateGORMModels() string {
+	var b strings.Builder
+
+	relations := map[string][]ormRelation{}
+	inverse := map[string][]ormRelation{}
+	for _, rel := range p.ormRelations() {
+		relations[rel.childKey] = append(relations[rel.childKey], rel)
+		inverse[rel.parentKey] = append(inverse[rel.parentKey], rel)
+	}
+
+	var body strings.Builder
+	for _, key := range sortedKeys(p.Tables) {
+		body.WriteString("\n")
+		body.WriteString(p.gormModel(p.Tables[key], relations[key], inverse[key]))
+	}
+
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("package %s\n\n", defaultGoPackage))
+	writeGoImports(&b, goImportsFor(body.String()))
+	b.WriteString(body.String())
+
+	return b.String()
+}
+
+func (p *Project) gormModel(t *Table, relations, inverse []ormRelation) string {
+	var b strings.Builder
+
+	structName := goStructName(t.Name)
+	pkColumns := t.primaryKeyColumns()
+
+	columns := make([]gormField, len(t.Columns))
+	for i, col := range t.Columns {
+		isPK := false
+		for _, name := range pkColumns {
+			if name == col.Name {
+				isPK = true
+			}
+		}
+		columns[i] = gormField{name: goExportedName(col.Name), typ: col.goType(), tag: col.gormStructTag(isPK)}
+	}
+
+	associations := []gormField{}
+	for _, rel := range relations {
+		tag := gormForeignKeyTag(rel)
+		if constraint := gormConstraint(rel.ref, p.sqlDialect()); constraint != "" {
+			tag += ";" + constraint
+		}
+		associations = append(associations, gormField{
+			name: goExportedName(rel.field),
+			typ:  "*" + goStructName(rel.parent.Table),
+			tag:  fmt.Sprintf("gorm:%q", tag),
+		})
+	}
+	for _, rel := range inverse {
+		typ := "[]" + goStructName(rel.child.Table)
+		if rel.ref.Type == OneToOne {
+			typ = "*" + goStructName(rel.child.Table)
+		}
+		associations = append(associations, gormField{
+			name: goExportedName(rel.backField),
+			typ:  typ,
+			tag:  fmt.Sprintf("gorm:%q", gormForeignKeyTag(rel)),
+		})
+	}
+
+	b.WriteString(fmt.Sprintf("// %s is a row of the %s table.\n", structName, t.Name))
+	b.WriteString(fmt.Sprintf("type %s struct {\n", structName))
+	writeGormFields(&b, columns)
+	if len(associations) > 0 {
+		b.WriteString("\n")
+		writeGormFields(&b, associations)
+	}
+	b.WriteString("}\n\n")
+
+	tableName := t.Name
+	if t.Schema != defaultSchema {
+		tableName = t.Schema + "." + t.Name
+	}
+	b.WriteString(fmt.Sprintf("// TableName returns the table name used by GORM for %s.\n", structName))
+	b.WriteString(fmt.Sprintf("func (%s) TableName() string {\n", structName))
+	b.WriteString(fmt.Sprintf("\treturn %q\n", tableName))
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// writeGormFields writes fields aligned the way gofmt aligns a block.
+func writeGormFields(b *strings.Builder, fields []gormField) {
+	nameWidth, typeWidth := 0, 0
+	for _, field := range fields {
+		nameWidth = max(nameWidth, len(field.name))
+		typeWidth = max(typeWidth, len(field.typ))
+	}
+	for _, field := range fields {
+		b.WriteString(fmt.Sprintf("\t%-*s %-*s `%s`\n", nameWidth, field.name, typeWidth, field.typ, field.tag))
+	}
+}
+
+// gormStructTag builds the field tag: a gorm tag derived from the column
+// settings, then the column's own tags sorted by key. A gorm tag set with
+// Column.WithTagValue replaces the derived one.
+func (c *Column) gormStructTag(isPK bool) string {
+	tags := []string{}
+	if _, ok := c.Tags["gorm"]; !ok {
+		tags = append(tags, fmt.Sprintf("gorm:%q", c.gormSettings(isPK)))
+	}
+	for _, tag := range sortedKeys(c.Tags) {
+		tags = append(tags, fmt.Sprintf("%s:%q", tag, c.Tags[tag]))
+	}
+	return strings.Join(tags, " ")
+}
+
+func (c *Column) gormSettings(isPK bool) string {
+	settings := []string{"column:" + c.Name}
+	if isPK {
+		settings = append(settings, "primaryKey")
+	}
+	if c.Settings != nil {
+		if c.Settings.Increment {
+			settings = append(settings, "autoIncrement")
+		}
+		if !c.Settings.Null && !isPK {
+			settings = append(settings, "not null")
+		}
+		if c.Settings.Unique && !isPK {
+			settings = append(settings, "unique")
+		}
+		if c.Settings.Default != nil && !c.Settings.Increment && !c.Settings.DatabaseDefault {
+			value := sqlDefault(*c.Settings.Default, c.Settings.DefaultKind)
+			if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+				value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+			}
+			settings = append(settings, "default:"+value)
+		}
+	}
+	return strings.Join(settings, ";")
+}
+
+// gormForeignKeyTag names the child's foreign key fields and the parent's
+// referenced fields.
+func gormForeignKeyTag(rel ormRelation) string {
+	return fmt.Sprintf("foreignKey:%s;references:%s", gormFieldNames(rel.child.Columns), gormFieldNames(rel.parent.Columns))
+}
+
+// gormConstraint returns the constraint setting for the ref's actions,
+// e.g. constraint:OnDelete:CASCADE,OnUpdate:SET NULL.
+func gormConstraint(ref *Ref, dialect SQLDialect) string {
+	actions := []string{}
+	if ref.OnDelete != nil {
+		actions = append(actions, "OnDelete:"+ref.OnDelete.SQL(dialect))
+	}
+	if ref.OnUpdate != nil {
+		actions = append(actions, "OnUpdate:"+ref.OnUpdate.SQL(dialect))
+	}
+	if len(actions) == 0 {
+		return ""
+	}
+	return "constraint:" + strings.Join(actions, ",")
+}
+
+func gormFieldNames(columns []string) string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = goExportedName(col)
+	}
+	return strings.Join(names, ",")
+}
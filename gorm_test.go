@@ -0,0 +1,58 @@
+package dbml
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateGORMModels(t *testing.T) {
+	project := NewProject("blog").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique().WithTagValue("json", "email")).
+			AddColumn(NewColumn("created_at", "timestamp").WithDefault("now()"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "varchar").WithDefault("'draft'")).
+			AddColumn(NewColumn("body", "text").WithNull())).
+		AddTable(NewTable("profiles").WithSchema("app").
+			AddColumn(NewColumn("user_id", "bigint").WithPrimaryKey().WithTagValue("gorm", "primaryKey;autoIncrement:false"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id").WithOnDelete(Cascade)).
+		AddRef(NewRef(OneToOne).From("app", "profiles", "user_id").To("public", "users", "id"))
+
+	output := project.GenerateGORMModels()
+
+	formatted, err := format.Source([]byte(output))
+	if err != nil {
+		t.Fatalf("Expected valid Go source, got error %v:\n%s", err, output)
+	}
+	if string(formatted) != output {
+		t.Errorf("Expected gofmt-formatted output, got:\n%s", output)
+	}
+
+	expected := []string{
+		"package models\n\nimport \"time\"\n",
+		"type User struct {\n" +
+			"\tID        int64     `gorm:\"column:id;primaryKey;autoIncrement\"`\n" +
+			"\tEmail     string    `gorm:\"column:email;not null;unique\" json:\"email\"`\n" +
+			"\tCreatedAt time.Time `gorm:\"column:created_at;not null;default:now()\"`\n" +
+			"\n" +
+			"\tPosts    []Post   `gorm:\"foreignKey:UserID;references:ID\"`\n" +
+			"\tProfiles *Profile `gorm:\"foreignKey:UserID;references:ID\"`\n" +
+			"}\n",
+		"\tStatus string  `gorm:\"column:status;not null;default:draft\"`\n",
+		"\tBody   *string `gorm:\"column:body\"`\n",
+		"\tUser *User `gorm:\"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE\"`\n",
+		"\tUserID int64 `gorm:\"primaryKey;autoIncrement:false\"`\n",
+		"func (Post) TableName() string {\n\treturn \"posts\"\n}\n",
+		"func (Profile) TableName() string {\n\treturn \"app.profiles\"\n}\n",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package dbml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// graphqlField is one field of a GraphQL object type.
+type graphqlField struct {
+	description *string
+	name        string
+	typ         string
+}
+
+// GenerateGraphQL generates GraphQL SDL type definitions from a Project.
+// Enums become GraphQL enums and each table becomes an object type named
+// after its singular table name, with a field per column; single-column
+// primary keys are typed ID. Foreign keys add an object field on the
+// referencing type and a list field (an object field for one-to-one refs)
+// on the referenced type, named as in the ORM generators. A Query type
+// scaffolds a get<Type> field per table with a primary key. Notes become
+// descriptions. Many-to-many refs have no foreign key and are not emitted.
+func (p *Project) GenerateGraphQL() string {
+	var b strings.Builder
+
+	b.WriteString("# Code generated by dbml. DO NOT EDIT.\n")
+
+	for _, key := range sortedKeys(p.Enums) {
+		b.WriteString("\n")
+		b.WriteString(p.Enums[key].graphqlEnum())
+	}
+
+	relations := p.graphqlRelationFields()
+	for _, key := range sortedKeys(p.Tables) {
+		b.WriteString("\n")
+		b.WriteString(p.graphqlType(p.Tables[key], relations[key]))
+	}
+
+	queries := []string{}
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		pkColumns := table.primaryKeyColumns()
+		if len(pkColumns) == 0 {
+			continue
+		}
+		args := make([]string, len(pkColumns))
+		for i, name := range pkColumns {
+			col := table.columnsByName([]string{name})
+			typ := "ID"
+			if len(pkColumns) > 1 && len(col) == 1 {
+				typ = p.graphqlScalarType(col[0])
+			}
+			args[i] = fmt.Sprintf("%s: %s!", name, typ)
+		}
+		typeName := goStructName(table.Name)
+		queries = append(queries, fmt.Sprintf("  get%s(%s): %s\n", typeName, strings.Join(args, ", "), typeName))
+	}
+	if len(queries) > 0 {
+		b.WriteString("\ntype Query {\n")
+		b.WriteString(strings.Join(queries, ""))
+		b.WriteString("}\n")
+	}
+
+	return b.String()
+}
+
+func (e *Enum) graphqlEnum() string {
+	var b strings.Builder
+
+	b.WriteString(graphqlDescription(e.Note, ""))
+	b.WriteString(fmt.Sprintf("enum %s {\n", goExportedName(e.Name)))
+	for _, value := range e.Values {
+		if note, ok := e.ValueNotes[value]; ok {
+			b.WriteString(graphqlDescription(&note, "  "))
+		}
+		b.WriteString("  " + graphqlEnumValue(value) + "\n")
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (p *Project) graphqlType(t *Table, relations []graphqlField) string {
+	var b strings.Builder
+
+	pkColumns := t.primaryKeyColumns()
+
+	fields := make([]graphqlField, 0, len(t.Columns)+len(relations))
+	for _, col := range t.Columns {
+		isID := len(pkColumns) == 1 && pkColumns[0] == col.Name
+		typ := p.graphqlScalarType(col)
+		if _, isArray := baseSQLType(col.sqlType()); isArray {
+			typ = "[" + typ + "]"
+		} else if isID {
+			typ = "ID"
+		}
+		if col.Settings == nil || !col.Settings.Null || isID {
+			typ += "!"
+		}
+		fields = append(fields, graphqlField{description: col.Note, name: col.Name, typ: typ})
+	}
+	fields = append(fields, relations...)
+
+	b.WriteString(graphqlDescription(t.Note, ""))
+	b.WriteString(fmt.Sprintf("type %s {\n", goStructName(t.Name)))
+	for _, field := range fields {
+		b.WriteString(graphqlDescription(field.description, "  "))
+		b.WriteString(fmt.Sprintf("  %s: %s\n", field.name, field.typ))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// graphqlRelationFields returns the relation fields each type gains from
+// foreign keys, keyed by table key.
+func (p *Project) graphqlRelationFields() map[string][]graphqlField {
+	fields := map[string][]graphqlField{}
+	for _, rel := range p.ormRelations() {
+		childType := goStructName(rel.child.Table)
+		parentType := goStructName(rel.parent.Table)
+
+		typ := parentType
+		if !rel.nullable {
+			typ += "!"
+		}
+		fields[rel.childKey] = append(fields[rel.childKey], graphqlField{name: rel.field, typ: typ})
+
+		backType := "[" + childType + "!]!"
+		if rel.ref.Type == OneToOne {
+			backType = childType
+		}
+		fields[rel.parentKey] = append(fields[rel.parentKey], graphqlField{name: rel.backField, typ: backType})
+	}
+
+	return fields
+}
+
+// graphqlScalarType maps a column to a built-in GraphQL scalar or to its
+// enum type, without list or non-null wrapping.
+func (p *Project) graphqlScalarType(c *Column) string {
+	if enum := p.columnEnum(c); enum != nil {
+		return goExportedName(enum.Name)
+	}
+
+	base, _ := baseSQLType(c.sqlType())
+	switch base {
+	case "int", "integer", "int4", "serial", "serial4", "smallint", "int2", "smallserial", "serial2",
+		"mediumint", "tinyint", "bigint", "int8", "bigserial", "serial8":
+		return "Int"
+	case "real", "float4", "float", "double", "double precision", "float8", "numeric", "decimal", "money":
+		return "Float"
+	case "boolean", "bool":
+		return "Boolean"
+	case "uuid":
+		return "ID"
+	default:
+		return "String"
+	}
+}
+
+// graphqlEnumValue converts an enum value to an upper-case GraphQL name:
+// in transit -> IN_TRANSIT.
+func graphqlEnumValue(value string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if b.Len() > 0 {
+			b.WriteString("_")
+		}
+		b.WriteString(strings.ToUpper(part))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// graphqlDescription writes a note as a GraphQL description line.
+func graphqlDescription(note *string, indent string) string {
+	if note == nil {
+		return ""
+	}
+	return indent + strconv.Quote(*note) + "\n"
+}
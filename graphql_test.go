@@ -0,0 +1,83 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateGraphQL(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "in review", "published").SetValueNote("draft", "Not visible")).
+		AddTable(NewTable("users").
+			WithNote("Registered users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique()).
+			AddColumn(NewColumn("bio", "text").WithNull().WithNote("Shown on the profile"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "uuid").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("editor_id", "bigint").WithNull()).
+			AddColumn(NewColumn("status", "post_status")).
+			AddColumn(NewColumn("rating", "numeric(3, 1)").WithNull()).
+			AddColumn(NewColumn("tags", "text[]").WithNull()).
+			AddColumn(NewColumn("published", "boolean"))).
+		AddTable(NewTable("profiles").
+			AddColumn(NewColumn("user_id", "bigint").WithPrimaryKey())).
+		AddTable(NewTable("post_tags").
+			AddColumn(NewColumn("post_id", "uuid")).
+			AddColumn(NewColumn("tag", "text")).
+			AddIndex(NewIndex("post_id", "tag").WithPrimaryKey())).
+		AddTable(NewTable("audit_log").
+			AddColumn(NewColumn("message", "text"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id")).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "editor_id").To("public", "users", "id")).
+		AddRef(NewRef(OneToOne).From("public", "profiles", "user_id").To("public", "users", "id"))
+
+	output := project.GenerateGraphQL()
+
+	expected := []string{
+		"# Code generated by dbml. DO NOT EDIT.\n",
+		"enum PostStatus {\n  \"Not visible\"\n  DRAFT\n  IN_REVIEW\n  PUBLISHED\n}\n",
+		"\"Registered users\"\ntype User {\n  id: ID!\n  email: String!\n  \"Shown on the profile\"\n  bio: String\n",
+		"  posts_user: [Post!]!\n  posts_editor: [Post!]!\n  profiles: Profile\n}\n",
+		"type Post {\n  id: ID!\n  user_id: Int!\n  editor_id: Int\n  status: PostStatus!\n  rating: Float\n  tags: [String]\n  published: Boolean!\n  user: User!\n  editor: User\n}\n",
+		"type Profile {\n  user_id: ID!\n  user: User!\n}\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+		}
+	}
+
+	queries := output[strings.Index(output, "type Query {"):]
+	expectedQueries := []string{
+		"  getPost(id: ID!): Post\n",
+		"  getPostTag(post_id: ID!, tag: String!): PostTag\n",
+		"  getProfile(user_id: ID!): Profile\n",
+		"  getUser(id: ID!): User\n",
+	}
+	for _, exp := range expectedQueries {
+		if !strings.Contains(queries, exp) {
+			t.Errorf("Expected Query to contain %q, got:\n%s", exp, queries)
+		}
+	}
+	if strings.Contains(queries, "AuditLog") {
+		t.Errorf("Expected no query for a table without a primary key, got:\n%s", queries)
+	}
+}
+
+func TestGraphqlEnumValue(t *testing.T) {
+	tests := map[string]string{
+		"active":       "ACTIVE",
+		"in transit":   "IN_TRANSIT",
+		"on-hold":      "ON_HOLD",
+		"2fa_required": "_2FA_REQUIRED",
+		"!!":           "_",
+	}
+
+	for value, want := range tests {
+		if got := graphqlEnumValue(value); got != want {
+			t.Errorf("Expected %s for %q, got %s", want, value, got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dbml
+
+import "encoding/json"
+
+// hasuraTable is a table entry of Hasura's tables metadata.
+type hasuraTable struct {
+	Table               hasuraTableName      `json:"table"`
+	ObjectRelationships []hasuraRelationship `json:"object_relationships,omitempty"`
+	ArrayRelationships  []hasuraRelationship `json:"array_relationships,omitempty"`
+}
+
+type hasuraTableName struct {
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+}
+
+type hasuraRelationship struct {
+	Using hasuraUsing `json:"using"`
+	Name  string      `json:"name"`
+}
+
+type hasuraUsing struct {
+	ForeignKeyConstraintOn any `json:"foreign_key_constraint_on"`
+}
+
+// hasuraRemoteForeignKey points at a foreign key held by another table.
+type hasuraRemoteForeignKey struct {
+	Column  string          `json:"column,omitempty"`
+	Table   hasuraTableName `json:"table"`
+	Columns []string        `json:"columns,omitempty"`
+}
+
+// GenerateHasuraMetadata generates Hasura GraphQL engine table metadata as
+// JSON. Every table gets an entry; each foreign key adds an object
+// relationship on the referencing table and an array relationship (an object
+// relationship for one-to-one refs) on the referenced table.
+func (p *Project) GenerateHasuraMetadata() ([]byte, error) {
+	entries := map[string]*hasuraTable{}
+	for key, table := range p.Tables {
+		entries[key] = &hasuraTable{Table: hasuraTableName{Schema: table.Schema, Name: table.Name}}
+	}
+
+	for _, rel := range p.ormRelations() {
+		child, parent := entries[rel.childKey], entries[rel.parentKey]
+
+		var local any = rel.child.Columns
+		remote := hasuraRemoteForeignKey{Table: child.Table, Columns: rel.child.Columns}
+		if len(rel.child.Columns) == 1 {
+			local = rel.child.Columns[0]
+			remote = hasuraRemoteForeignKey{Table: child.Table, Column: rel.child.Columns[0]}
+		}
+
+		child.ObjectRelationships = append(child.ObjectRelationships, hasuraRelationship{
+			Name:  rel.field,
+			Using: hasuraUsing{ForeignKeyConstraintOn: local},
+		})
+
+		back := hasuraRelationship{Name: rel.backField, Using: hasuraUsing{ForeignKeyConstraintOn: remote}}
+		if rel.ref.Type == OneToOne {
+			parent.ObjectRelationships = append(parent.ObjectRelationships, back)
+		} else {
+			parent.ArrayRelationships = append(parent.ArrayRelationships, back)
+		}
+	}
+
+	tables := make([]*hasuraTable, 0, len(entries))
+	for _, key := range sortedKeys(entries) {
+		tables = append(tables, entries[key])
+	}
+
+	return json.MarshalIndent(tables, "", "  ")
+}
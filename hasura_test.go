@@ -0,0 +1,90 @@
+package dbml
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProject_GenerateHasuraMetadata(t *testing.T) {
+	project := NewProject("blog").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "int"))).
+		AddTable(NewTable("profiles").WithSchema("app").
+			AddColumn(NewColumn("user_id", "int").WithPrimaryKey())).
+		AddTable(NewTable("tags").
+			AddColumn(NewColumn("name", "text").WithPrimaryKey())).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id")).
+		AddRef(NewRef(OneToOne).From("app", "profiles", "user_id").To("public", "users", "id"))
+
+	data, err := project.GenerateHasuraMetadata()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var tables []map[string]any
+	if err := json.Unmarshal(data, &tables); err != nil {
+		t.Fatalf("Expected valid JSON, got %v:\n%s", err, data)
+	}
+	if len(tables) != 4 {
+		t.Fatalf("Expected 4 tables, got %d", len(tables))
+	}
+
+	byName := map[string]map[string]any{}
+	for _, table := range tables {
+		name := table["table"].(map[string]any)
+		byName[name["schema"].(string)+"."+name["name"].(string)] = table
+	}
+
+	var posts struct {
+		ObjectRelationships []struct {
+			Name  string `json:"name"`
+			Using struct {
+				ForeignKeyConstraintOn string `json:"foreign_key_constraint_on"`
+			} `json:"using"`
+		} `json:"object_relationships"`
+	}
+	raw, _ := json.Marshal(byName["public.posts"])
+	if err := json.Unmarshal(raw, &posts); err != nil {
+		t.Fatalf("Expected posts entry to decode, got %v", err)
+	}
+	if len(posts.ObjectRelationships) != 1 || posts.ObjectRelationships[0].Name != "user" ||
+		posts.ObjectRelationships[0].Using.ForeignKeyConstraintOn != "user_id" {
+		t.Errorf("Expected posts.user object relationship on user_id, got %s", raw)
+	}
+
+	var users struct {
+		ObjectRelationships []struct {
+			Name string `json:"name"`
+		} `json:"object_relationships"`
+		ArrayRelationships []struct {
+			Name  string `json:"name"`
+			Using struct {
+				ForeignKeyConstraintOn struct {
+					Column string          `json:"column"`
+					Table  hasuraTableName `json:"table"`
+				} `json:"foreign_key_constraint_on"`
+			} `json:"using"`
+		} `json:"array_relationships"`
+	}
+	raw, _ = json.Marshal(byName["public.users"])
+	if err := json.Unmarshal(raw, &users); err != nil {
+		t.Fatalf("Expected users entry to decode, got %v", err)
+	}
+	if len(users.ArrayRelationships) != 1 || users.ArrayRelationships[0].Name != "posts" {
+		t.Fatalf("Expected users.posts array relationship, got %s", raw)
+	}
+	fk := users.ArrayRelationships[0].Using.ForeignKeyConstraintOn
+	if fk.Column != "user_id" || fk.Table.Schema != "public" || fk.Table.Name != "posts" {
+		t.Errorf("Expected array relationship on posts.user_id, got %+v", fk)
+	}
+	if len(users.ObjectRelationships) != 1 || users.ObjectRelationships[0].Name != "profiles" {
+		t.Errorf("Expected users.profiles object relationship for the one-to-one ref, got %s", raw)
+	}
+
+	if _, ok := byName["public.tags"]["object_relationships"]; ok {
+		t.Errorf("Expected no relationships on tags, got %v", byName["public.tags"])
+	}
+}
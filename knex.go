@@ -0,0 +1,350 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GenerateKnexMigration generates a Knex.js migration file. exports.up
+// creates every DDL table with knex.schema.createTable and then adds foreign
+// keys with alterTable, so that table order does not matter; exports.down
+// drops the foreign keys, the tables in reverse order and the native enum
+// types the migration created. Tables outside the default schema are
+// addressed as schema.table. A created_at and updated_at timestamp pair
+// becomes table.timestamps(true, true). Expression index columns have no
+// Knex builder equivalent and are left out.
+func (p *Project) GenerateKnexMigration() string {
+	tables := p.GetDDLTables()
+
+	foreignKeys := map[string][]*Ref{}
+	for _, ref := range p.allRefs() {
+		child, _, ok := ref.foreignKey()
+		if !ok || p.refTouchesMigrationTable(ref) || p.refTouchesExcludedTable(ref) {
+			continue
+		}
+		key := child.Schema + "." + child.Table
+		foreignKeys[key] = append(foreignKeys[key], ref)
+	}
+
+	enums := map[string]bool{}
+	up := []string{}
+	for _, table := range tables {
+		up = append(up, fmt.Sprintf(".createTable(%s, (table) => {\n%s    })",
+			knexString(knexTableName(table.Schema, table.Name)), p.knexTableBody(table, enums)))
+	}
+	for _, table := range tables {
+		refs := foreignKeys[table.Schema+"."+table.Name]
+		if len(refs) == 0 {
+			continue
+		}
+		var body strings.Builder
+		for _, ref := range refs {
+			body.WriteString(knexForeignKey(ref, p.sqlDialect()))
+		}
+		up = append(up, fmt.Sprintf(".alterTable(%s, (table) => {\n%s    })",
+			knexString(knexTableName(table.Schema, table.Name)), body.String()))
+	}
+
+	down := []string{}
+	for _, table := range slices.Backward(tables) {
+		refs := foreignKeys[table.Schema+"."+table.Name]
+		if len(refs) == 0 {
+			continue
+		}
+		var body strings.Builder
+		for _, ref := range refs {
+			child, _, _ := ref.foreignKey()
+			body.WriteString(fmt.Sprintf("      table.dropForeign(%s, %s);\n",
+				knexStringList(child.Columns), knexString(ref.foreignKeyName(child))))
+		}
+		down = append(down, fmt.Sprintf(".alterTable(%s, (table) => {\n%s    })",
+			knexString(knexTableName(table.Schema, table.Name)), body.String()))
+	}
+	for _, table := range slices.Backward(tables) {
+		down = append(down, fmt.Sprintf(".dropTable(%s)", knexString(knexTableName(table.Schema, table.Name))))
+	}
+	for _, key := range sortedKeys(enums) {
+		enum := p.Enums[key]
+		down = append(down, fmt.Sprintf(".raw(%s)", knexString("DROP TYPE IF EXISTS "+pgQualifiedName(enum.Schema, enum.Name))))
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString("exports.up = function (knex) {\n")
+	b.WriteString(knexChain(up))
+	b.WriteString("};\n\n")
+	b.WriteString("exports.down = function (knex) {\n")
+	b.WriteString(knexChain(down))
+	b.WriteString("};\n")
+
+	return b.String()
+}
+
+// knexChain returns a knex.schema statement chaining calls, one per line.
+func knexChain(calls []string) string {
+	if len(calls) == 0 {
+		return "  return Promise.resolve();\n"
+	}
+	return "  return knex.schema\n    " + strings.Join(calls, "\n    ") + ";\n"
+}
+
+// knexTableBody returns the createTable callback body. Native enum types are
+// created by their first column; enums records them so later columns reuse
+// the existing type.
+func (p *Project) knexTableBody(t *Table, enums map[string]bool) string {
+	var b strings.Builder
+
+	pkColumns := t.primaryKeyColumns()
+	timestamps := t.knexHasTimestamps()
+
+	for _, col := range t.Columns {
+		if timestamps && (col.Name == "created_at" || col.Name == "updated_at") {
+			if col.Name == "created_at" {
+				b.WriteString("      table.timestamps(true, true);\n")
+			}
+			continue
+		}
+		b.WriteString("      " + p.knexColumn(col, pkColumns, enums) + ";\n")
+	}
+
+	if len(pkColumns) > 1 {
+		b.WriteString(fmt.Sprintf("      table.primary(%s);\n", knexStringList(pkColumns)))
+	}
+
+	for _, idx := range t.Indexes {
+		if idx.PrimaryKey {
+			continue
+		}
+		columns := []string{}
+		for _, col := range idx.Columns {
+			if col.Name != nil {
+				columns = append(columns, *col.Name)
+			}
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		if idx.Unique {
+			b.WriteString(fmt.Sprintf("      table.unique(%s, { indexName: %s });\n",
+				knexStringList(columns), knexString(idx.indexName(t))))
+			continue
+		}
+		options := ""
+		if idx.Type != nil {
+			options = fmt.Sprintf(", { indexType: %s }", knexString(*idx.Type))
+		}
+		b.WriteString(fmt.Sprintf("      table.index(%s, %s%s);\n",
+			knexStringList(columns), knexString(idx.indexName(t)), options))
+	}
+
+	if t.SQLComment != nil {
+		b.WriteString(fmt.Sprintf("      table.comment(%s);\n", knexString(*t.SQLComment)))
+	}
+
+	return b.String()
+}
+
+// knexHasTimestamps reports whether the table has both created_at and
+// updated_at timestamp columns, which Knex creates with table.timestamps.
+func (t *Table) knexHasTimestamps() bool {
+	found := 0
+	for _, col := range t.Columns {
+		if col.Name != "created_at" && col.Name != "updated_at" {
+			continue
+		}
+		switch base, isArray := baseSQLType(col.sqlType()); {
+		case isArray:
+			return false
+		case base == "timestamp", base == "timestamptz", base == "timestamp with time zone",
+			base == "timestamp without time zone", base == "datetime":
+			found++
+		}
+	}
+	return found == 2
+}
+
+func (p *Project) knexColumn(c *Column, pkColumns []string, enums map[string]bool) string {
+	name := knexString(c.Name)
+	singlePK := len(pkColumns) == 1 && pkColumns[0] == c.Name
+	increment := c.Settings != nil && c.Settings.Increment
+
+	var b strings.Builder
+	b.WriteString("table.")
+
+	base, isArray := baseSQLType(c.sqlType())
+	switch {
+	case increment:
+		method := "increments"
+		if base == "bigint" || base == "int8" || base == "bigserial" || base == "serial8" {
+			method = "bigIncrements"
+		}
+		b.WriteString(fmt.Sprintf("%s(%s", method, name))
+		if !singlePK {
+			b.WriteString(", { primaryKey: false }")
+		}
+		b.WriteString(")")
+	case p.columnEnum(c) != nil && !isArray:
+		enum := p.columnEnum(c)
+		key := enum.Schema + "." + enum.Name
+		existing := ""
+		if enums[key] {
+			existing = ", existingType: true"
+		}
+		enums[key] = true
+		b.WriteString(fmt.Sprintf("enu(%s, %s, { useNative: true, enumName: %s%s })",
+			name, knexStringList(enum.Values), knexString(enum.Name), existing))
+	case isArray:
+		b.WriteString(fmt.Sprintf("specificType(%s, %s)", name, knexString(c.sqlType())))
+	default:
+		b.WriteString(knexTypeCall(name, base, c.sqlType()))
+	}
+
+	if singlePK && !increment {
+		b.WriteString(".primary()")
+	}
+	if c.Settings != nil {
+		if !c.Settings.Null && !singlePK && !increment {
+			b.WriteString(".notNullable()")
+		}
+		if c.Settings.Unique && !singlePK {
+			b.WriteString(".unique()")
+		}
+		if c.Settings.Default != nil && !increment && !c.Settings.DatabaseDefault && c.ComputedAlias == nil {
+			b.WriteString(".defaultTo(" + knexDefault(*c.Settings.Default, c.Settings.DefaultKind) + ")")
+		}
+	}
+	if c.Note != nil {
+		b.WriteString(fmt.Sprintf(".comment(%s)", knexString(*c.Note)))
+	}
+
+	return b.String()
+}
+
+// knexTypeCall maps a SQL type to a Knex column builder call, falling back
+// to specificType for types Knex has no builder for.
+func knexTypeCall(name, base, colType string) string {
+	args := []string{}
+	if match := typeArgsPattern.FindStringSubmatch(colType); match != nil {
+		for _, arg := range strings.Split(match[1], ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+	withArgs := func(method string) string {
+		return method + "(" + strings.Join(append([]string{name}, args...), ", ") + ")"
+	}
+
+	switch base {
+	case "int", "integer", "int4", "mediumint":
+		return "integer(" + name + ")"
+	case "bigint", "int8":
+		return "bigInteger(" + name + ")"
+	case "smallint", "int2":
+		return "smallint(" + name + ")"
+	case "tinyint":
+		return "tinyint(" + name + ")"
+	case "varchar", "character varying":
+		return withArgs("string")
+	case "char", "character":
+		return "specificType(" + name + ", " + knexString(colType) + ")"
+	case "text", "longtext", "mediumtext", "tinytext":
+		return "text(" + name + ")"
+	case "boolean", "bool":
+		return "boolean(" + name + ")"
+	case "numeric", "decimal":
+		return withArgs("decimal")
+	case "real", "float4", "float":
+		return "float(" + name + ")"
+	case "double", "double precision", "float8":
+		return "double(" + name + ")"
+	case "date":
+		return "date(" + name + ")"
+	case "time":
+		return "time(" + name + ")"
+	case "timestamp", "timestamp without time zone", "datetime":
+		return "timestamp(" + name + ")"
+	case "timestamptz", "timestamp with time zone":
+		return "timestamp(" + name + ", { useTz: true })"
+	case "json":
+		return "json(" + name + ")"
+	case "jsonb":
+		return "jsonb(" + name + ")"
+	case "uuid":
+		return "uuid(" + name + ")"
+	case "bytea", "blob", "binary", "varbinary":
+		return "binary(" + name + ")"
+	default:
+		return "specificType(" + name + ", " + knexString(colType) + ")"
+	}
+}
+
+// knexDefault writes a column default: literals as JavaScript values, and
+// expressions (or defaults of unknown kind) through knex.raw.
+func knexDefault(value string, kind DefaultKind) string {
+	switch kind {
+	case DefaultKindString:
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+		return knexString(value)
+	case DefaultKindNumber:
+		return value
+	case DefaultKindBoolean:
+		return strings.ToLower(value)
+	case DefaultKindNull:
+		return "null"
+	default:
+		return "knex.raw(" + knexString(sqlDefault(value, kind)) + ")"
+	}
+}
+
+func knexForeignKey(ref *Ref, dialect SQLDialect) string {
+	child, parent, _ := ref.foreignKey()
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("      table.foreign(%s, %s).references(%s).inTable(%s)",
+		knexStringList(child.Columns), knexString(ref.foreignKeyName(child)),
+		knexStringList(parent.Columns), knexString(knexTableName(parent.Schema, parent.Table))))
+	if ref.OnDelete != nil {
+		b.WriteString(fmt.Sprintf(".onDelete(%s)", knexString(ref.OnDelete.SQL(dialect))))
+	}
+	if ref.OnUpdate != nil {
+		b.WriteString(fmt.Sprintf(".onUpdate(%s)", knexString(ref.OnUpdate.SQL(dialect))))
+	}
+	if ref.Deferrable != nil {
+		mode := "deferred"
+		if *ref.Deferrable == DeferrableInitiallyImmediate {
+			mode = "immediate"
+		}
+		b.WriteString(fmt.Sprintf(".deferrable(%s)", knexString(mode)))
+	}
+	b.WriteString(";\n")
+
+	return b.String()
+}
+
+// knexTableName returns the table name, prefixed with the schema outside the
+// default schema.
+func knexTableName(schema, name string) string {
+	if schema == "" || schema == defaultSchemaName {
+		return name
+	}
+	return schema + "." + name
+}
+
+// knexString returns s as a single-quoted JavaScript string.
+func knexString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`, "\n", `\n`).Replace(s) + "'"
+}
+
+// knexStringList returns a single column as a string and several as an array.
+func knexStringList(values []string) string {
+	if len(values) == 1 {
+		return knexString(values[0])
+	}
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = knexString(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,107 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateKnexMigration(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique().WithNote("Login name")).
+			AddColumn(NewColumn("created_at", "timestamptz").WithDefault("now()")).
+			AddColumn(NewColumn("updated_at", "timestamptz").WithDefault("now()"))).
+		AddTable(NewTable("posts").WithSchema("blog").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "post_status").WithDefault("'draft'")).
+			AddColumn(NewColumn("price", "numeric(10,2)").WithNull().WithDefault("0")).
+			AddColumn(NewColumn("tags", "text[]").WithNull()).
+			AddIndex(NewIndex("user_id", "status").WithType("btree"))).
+		AddRef(NewRef(ManyToOne).From("blog", "posts", "user_id").To("public", "users", "id").WithOnDelete(Cascade))
+
+	output := project.GenerateKnexMigration()
+
+	expected := `// Code generated by dbml. DO NOT EDIT.
+
+exports.up = function (knex) {
+  return knex.schema
+    .createTable('blog.posts', (table) => {
+      table.integer('id').primary();
+      table.bigInteger('user_id').notNullable();
+      table.enu('status', ['draft', 'published'], { useNative: true, enumName: 'post_status' }).notNullable().defaultTo('draft');
+      table.decimal('price', 10, 2).defaultTo(0);
+      table.specificType('tags', 'text[]');
+      table.index(['user_id', 'status'], 'idx_posts_user_id_status', { indexType: 'btree' });
+    })
+    .createTable('users', (table) => {
+      table.bigIncrements('id');
+      table.string('email', 255).notNullable().unique().comment('Login name');
+      table.timestamps(true, true);
+    })
+    .alterTable('blog.posts', (table) => {
+      table.foreign('user_id', 'fk_posts_user_id').references('id').inTable('users').onDelete('CASCADE');
+    });
+};
+
+exports.down = function (knex) {
+  return knex.schema
+    .alterTable('blog.posts', (table) => {
+      table.dropForeign('user_id', 'fk_posts_user_id');
+    })
+    .dropTable('users')
+    .dropTable('blog.posts')
+    .raw('DROP TYPE IF EXISTS "public"."post_status"');
+};
+`
+	if output != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+	}
+}
+
+func TestProject_GenerateKnexMigration_CompositeKeysAndDefaults(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("role", "admin", "member")).
+		AddTable(NewTable("memberships").
+			AddColumn(NewColumn("user_id", "int")).
+			AddColumn(NewColumn("team_id", "int")).
+			AddColumn(NewColumn("role", "role")).
+			AddColumn(NewColumn("active", "boolean").WithDefault("true")).
+			AddColumn(NewColumn("nickname", "text").WithDefault("'it''s me'")).
+			AddColumn(NewColumn("created_at", "timestamp").WithDefault("now()")).
+			AddIndex(NewIndex("user_id", "team_id").WithPrimaryKey()).
+			AddIndex(NewIndex("team_id", "role").WithUnique().WithName("uq_team_role"))).
+		AddTable(NewTable("teams").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("default_role", "role")))
+
+	output := project.GenerateKnexMigration()
+
+	expected := []string{
+		"      table.enu('role', ['admin', 'member'], { useNative: true, enumName: 'role' }).notNullable();\n",
+		"      table.boolean('active').notNullable().defaultTo(true);\n",
+		"      table.text('nickname').notNullable().defaultTo('it\\'s me');\n",
+		"      table.timestamp('created_at').notNullable().defaultTo(knex.raw('now()'));\n",
+		"      table.primary(['user_id', 'team_id']);\n",
+		"      table.unique(['team_id', 'role'], { indexName: 'uq_team_role' });\n",
+		"      table.enu('default_role', ['admin', 'member'], { useNative: true, enumName: 'role', existingType: true }).notNullable();\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "timestamps(") {
+		t.Error("Expected timestamps() only when both created_at and updated_at exist")
+	}
+}
+
+func TestProject_GenerateKnexMigration_Empty(t *testing.T) {
+	output := NewProject("empty").GenerateKnexMigration()
+
+	if strings.Count(output, "  return Promise.resolve();\n") != 2 {
+		t.Errorf("Expected empty up and down functions, got:\n%s", output)
+	}
+}
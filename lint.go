@@ -0,0 +1,540 @@
+package dbml
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LintSeverity ranks lint findings. Higher values are more severe.
+type LintSeverity int
+
+const (
+	// LintSeverityWarning marks findings that should be fixed.
+	LintSeverityWarning LintSeverity = iota
+	// LintSeverityError marks findings that must be fixed, e.g. because the
+	// generated DDL would fail.
+	LintSeverityError
+)
+
+func (s LintSeverity) String() string {
+	switch s {
+	case LintSeverityWarning:
+		return "warning"
+	case LintSeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("LintSeverity(%d)", int(s))
+	}
+}
+
+// LintOptions selects the checks run by Lint.
+type LintOptions struct {
+	// Dialect selects the reserved keyword list; empty means PostgreSQL.
+	Dialect SQLDialect
+	// MinSeverity drops findings below this severity; the zero value keeps all.
+	MinSeverity            LintSeverity
+	NamingConventions      bool // LintNamingConventions
+	MissingPrimaryKeys     bool // LintMissingPrimaryKeys
+	ReservedKeywords       bool // LintReservedKeywords
+	EnumUsages             bool // ValidateEnumUsages
+	UniqueAliases          bool // ValidateUniqueAliases
+	CyclicEnumRefs         bool // LintCyclicEnumReferences
+	RowLevelSecurityTenant bool // LintRowLevelSecurityTenant
+}
+
+// Lint runs the checks enabled in opts and returns their findings sorted by
+// schema, table, column and rule.
+func (p *Project) Lint(opts LintOptions) []LintError {
+	errs := []LintError{}
+
+	if opts.NamingConventions {
+		errs = append(errs, p.LintNamingConventions()...)
+	}
+	if opts.MissingPrimaryKeys {
+		errs = append(errs, p.LintMissingPrimaryKeys()...)
+	}
+	if opts.ReservedKeywords {
+		errs = append(errs, p.LintReservedKeywords(opts.Dialect)...)
+	}
+	if opts.EnumUsages {
+		errs = append(errs, p.ValidateEnumUsages()...)
+	}
+	if opts.UniqueAliases {
+		errs = append(errs, p.ValidateUniqueAliases()...)
+	}
+	if opts.CyclicEnumRefs {
+		errs = append(errs, p.LintCyclicEnumReferences()...)
+	}
+	if opts.RowLevelSecurityTenant {
+		errs = append(errs, p.LintRowLevelSecurityTenant()...)
+	}
+
+	kept := errs[:0]
+	for _, err := range errs {
+		if err.Severity >= opts.MinSeverity {
+			kept = append(kept, err)
+		}
+	}
+	errs = kept
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Rule < b.Rule
+	})
+
+	return errs
+}
+
+// LintError reports a schema problem found by a lint check.
+// Table and Column are empty when the problem is not specific to one.
+type LintError struct {
+	Rule     string
+	Message  string
+	Schema   string
+	Table    string
+	Column   string
+	Severity LintSeverity
+}
+
+func (e LintError) Error() string {
+	location := e.Schema
+	if e.Table != "" {
+		location += "." + e.Table
+	}
+	if e.Column != "" {
+		location += "." + e.Column
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", e.Severity, location, e.Message, e.Rule)
+}
+
+// builtinColumnTypes are SQL types that never refer to a user-defined enum.
+var builtinColumnTypes = map[string]bool{
+	"bigint": true, "bigserial": true, "binary": true, "bit": true, "blob": true,
+	"bool": true, "boolean": true, "bytea": true, "char": true, "character": true,
+	"character varying": true, "cidr": true, "citext": true, "date": true, "datetime": true,
+	"decimal": true, "double": true, "double precision": true, "float": true, "float4": true,
+	"float8": true, "inet": true, "int": true, "int2": true, "int4": true, "int8": true,
+	"integer": true, "interval": true, "json": true, "jsonb": true, "longtext": true,
+	"macaddr": true, "mediumint": true, "mediumtext": true, "money": true, "numeric": true,
+	"real": true, "serial": true, "serial2": true, "serial4": true, "serial8": true,
+	"smallint": true, "smallserial": true, "text": true, "time": true, "timestamp": true,
+	"timestamp with time zone": true, "timestamp without time zone": true, "timestamptz": true,
+	"timetz": true, "tinyint": true, "tinytext": true, "tsvector": true, "uuid": true,
+	"varbinary": true, "varchar": true, "xml": true, "year": true,
+	"nchar": true, "nvarchar": true, "ntext": true, "geometry": true, "geography": true,
+	"hstore": true, "ltree": true, "point": true, "polygon": true, "line": true,
+	"box": true, "circle": true, "path": true, "lseg": true, "varbit": true,
+	"int4range": true, "int8range": true, "numrange": true, "daterange": true,
+	"tsrange": true, "tstzrange": true, "tsquery": true, "oid": true,
+}
+
+// ValidateUniqueAliases reports every table whose alias is already used by
+// another table. Tables are visited by schema and name, so the first table
+// with an alias keeps it and the later ones are reported.
+func (p *Project) ValidateUniqueAliases() []LintError {
+	errs := []LintError{}
+
+	owners := map[string]*Table{}
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		if table.Alias == nil {
+			continue
+		}
+		if owner, ok := owners[*table.Alias]; ok {
+			errs = append(errs, LintError{
+				Rule:     "duplicate-alias",
+				Message:  fmt.Sprintf("alias %s is already used by table %s.%s", *table.Alias, owner.Schema, owner.Name),
+				Schema:   table.Schema,
+				Table:    table.Name,
+				Severity: LintSeverityError,
+			})
+			continue
+		}
+		owners[*table.Alias] = table
+	}
+
+	return errs
+}
+
+// ValidateEnumUsages reports, as warnings, columns whose type looks like an
+// enum name but is neither a built-in SQL type nor an enum defined in the
+// project. Parameterized and multi-word types, such as nvarchar(50) or
+// smallint unsigned, and columns with a type alias are never enums and are
+// skipped. Types may be schema-qualified (public.order_status); unqualified
+// types resolve to the default schema.
+func (p *Project) ValidateEnumUsages() []LintError {
+	errs := []LintError{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		for _, col := range table.Columns {
+			base, _ := baseSQLType(col.Type)
+			if builtinColumnTypes[base] || col.TypeAlias != nil || strings.ContainsAny(col.Type, "( \t") {
+				continue
+			}
+
+			schema, name := defaultSchemaName, strings.TrimSuffix(strings.TrimSpace(col.Type), "[]")
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				schema, name = name[:i], name[i+1:]
+			}
+			if _, ok := p.Enums[schema+"."+name]; ok {
+				continue
+			}
+
+			errs = append(errs, LintError{
+				Rule:     "undefined-enum",
+				Message:  fmt.Sprintf("type %s does not match a built-in type or an enum %s.%s", col.Type, schema, name),
+				Schema:   table.Schema,
+				Table:    table.Name,
+				Column:   col.Name,
+				Severity: LintSeverityWarning,
+			})
+		}
+	}
+
+	return errs
+}
+
+// cyclicEnumReference is an enum column whose referenced table has an enum
+// column with a value naming the column's own table.
+type cyclicEnumReference struct {
+	table  *Table
+	column *Column
+	enum   *Enum
+	target *Table
+	back   *Column
+	other  *Enum
+}
+
+func (r cyclicEnumReference) String() string {
+	return fmt.Sprintf("%s.%s.%s [%s] -> %s.%s.%s [%s] -> %s.%s",
+		r.table.Schema, r.table.Name, r.column.Name, r.enum.Name,
+		r.target.Schema, r.target.Name, r.back.Name, r.other.Name,
+		r.table.Schema, r.table.Name)
+}
+
+// GetCyclicEnumReferences reports enum columns that reference a table whose
+// own enum columns have a value matching the first table's name, e.g.
+// orders.status referencing shipments, where shipments.kind has the value
+// "orders". Each cycle is described as
+// schema.table.column [enum] -> schema.table.column [enum] -> schema.table,
+// in table and column order.
+func (p *Project) GetCyclicEnumReferences() []string {
+	cycles := []string{}
+	for _, cycle := range p.cyclicEnumReferences() {
+		cycles = append(cycles, cycle.String())
+	}
+	return cycles
+}
+
+// LintCyclicEnumReferences reports the cycles found by GetCyclicEnumReferences
+// as warnings on the first column.
+func (p *Project) LintCyclicEnumReferences() []LintError {
+	errs := []LintError{}
+	for _, cycle := range p.cyclicEnumReferences() {
+		errs = append(errs, LintError{
+			Rule:     "cyclic-enum-reference",
+			Message:  "enum value names the referencing table: " + cycle.String(),
+			Schema:   cycle.table.Schema,
+			Table:    cycle.table.Name,
+			Column:   cycle.column.Name,
+			Severity: LintSeverityWarning,
+		})
+	}
+	return errs
+}
+
+func (p *Project) cyclicEnumReferences() []cyclicEnumReference {
+	refs := p.allRefs()
+	cycles := []cyclicEnumReference{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		for _, col := range table.Columns {
+			enum := p.columnEnum(col)
+			if enum == nil {
+				continue
+			}
+
+			targets := map[string]bool{}
+			for _, ref := range refs {
+				for _, pair := range [][2]*RefEndpoint{{ref.Left, ref.Right}, {ref.Right, ref.Left}} {
+					from, to := pair[0], pair[1]
+					if from == nil || to == nil || from.Schema+"."+from.Table != key || !slices.Contains(from.Columns, col.Name) {
+						continue
+					}
+					targets[to.Schema+"."+to.Table] = true
+				}
+			}
+
+			for _, targetKey := range sortedKeys(targets) {
+				target, ok := p.Tables[targetKey]
+				if !ok {
+					continue
+				}
+				for _, back := range target.Columns {
+					other := p.columnEnum(back)
+					if other != nil && slices.Contains(other.Values, table.Name) {
+						cycles = append(cycles, cyclicEnumReference{table, col, enum, target, back, other})
+					}
+				}
+			}
+		}
+	}
+
+	return cycles
+}
+
+// snakeCasePattern matches lower snake_case names.
+var snakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// LintNamingConventions reports tables and columns whose names are not lower snake_case.
+func (p *Project) LintNamingConventions() []LintError {
+	errs := []LintError{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		if !snakeCasePattern.MatchString(table.Name) {
+			errs = append(errs, LintError{
+				Rule:     "naming-convention",
+				Message:  fmt.Sprintf("table name %s is not snake_case", table.Name),
+				Schema:   table.Schema,
+				Table:    table.Name,
+				Severity: LintSeverityWarning,
+			})
+		}
+		for _, col := range table.Columns {
+			if !snakeCasePattern.MatchString(col.Name) {
+				errs = append(errs, LintError{
+					Rule:     "naming-convention",
+					Message:  fmt.Sprintf("column name %s is not snake_case", col.Name),
+					Schema:   table.Schema,
+					Table:    table.Name,
+					Column:   col.Name,
+					Severity: LintSeverityWarning,
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+// LintMissingPrimaryKeys reports tables without a primary key column or index.
+func (p *Project) LintMissingPrimaryKeys() []LintError {
+	errs := []LintError{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		if len(table.primaryKeyColumns()) == 0 {
+			errs = append(errs, LintError{
+				Rule:     "missing-primary-key",
+				Message:  fmt.Sprintf("table %s has no primary key", table.Name),
+				Schema:   table.Schema,
+				Table:    table.Name,
+				Severity: LintSeverityWarning,
+			})
+		}
+	}
+
+	return errs
+}
+
+// LintRowLevelSecurityTenant warns about tables with row-level security
+// enabled but no tenant_id column, which multi-tenant policies usually
+// filter on.
+func (p *Project) LintRowLevelSecurityTenant() []LintError {
+	errs := []LintError{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		if table.RowLevelSecurity == nil || !*table.RowLevelSecurity || table.GetColumnIndex("tenant_id") >= 0 {
+			continue
+		}
+		errs = append(errs, LintError{
+			Rule:     "rls-tenant-column",
+			Message:  fmt.Sprintf("table %s has row-level security enabled but no tenant_id column", table.Name),
+			Schema:   table.Schema,
+			Table:    table.Name,
+			Severity: LintSeverityWarning,
+		})
+	}
+
+	return errs
+}
+
+// IndexSuggestion recommends an index that the schema is missing.
+type IndexSuggestion struct {
+	Schema string
+	Table  string
+	Column string // comma-separated for composite foreign keys
+	Reason string
+}
+
+// SuggestIndexes returns a suggestion for every foreign key, standalone or
+// inline, whose columns are not the leading columns of an index, primary
+// key or unique column. PostgreSQL does not index referencing columns
+// automatically, so such foreign keys make joins and cascading deletes scan
+// the whole table. Suggestions are sorted by schema, table and column.
+func (p *Project) SuggestIndexes() []IndexSuggestion {
+	suggestions := []IndexSuggestion{}
+	seen := map[string]bool{}
+
+	for _, ref := range p.allRefs() {
+		child, parent, ok := ref.foreignKey()
+		if !ok {
+			continue
+		}
+		table, ok := p.Tables[child.Schema+"."+child.Table]
+		if !ok || table.hasLeadingIndexOn(child.Columns) {
+			continue
+		}
+
+		column := strings.Join(child.Columns, ", ")
+		key := child.Schema + "." + child.Table + "." + column
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		suggestions = append(suggestions, IndexSuggestion{
+			Schema: child.Schema,
+			Table:  child.Table,
+			Column: column,
+			Reason: fmt.Sprintf("foreign key to %s.%s(%s) has no index", parent.Schema, parent.Table, strings.Join(parent.Columns, ", ")),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		return a.Column < b.Column
+	})
+
+	return suggestions
+}
+
+// hasLeadingIndexOn reports whether an index, the primary key or a unique
+// column starts with the given columns, in order.
+func (t *Table) hasLeadingIndexOn(columns []string) bool {
+	if len(columns) == 0 {
+		return true
+	}
+
+	candidates := [][]string{t.primaryKeyColumns()}
+	for _, idx := range t.Indexes {
+		names := []string{}
+		for _, col := range idx.Columns {
+			if col.Name == nil {
+				break
+			}
+			names = append(names, *col.Name)
+		}
+		candidates = append(candidates, names)
+	}
+	for _, col := range t.Columns {
+		if col.Settings != nil && col.Settings.Unique {
+			candidates = append(candidates, []string{col.Name})
+		}
+	}
+
+	for _, names := range candidates {
+		if len(names) >= len(columns) && slices.Equal(names[:len(columns)], columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedKeywords lists reserved words per dialect that cannot be used as
+// unquoted identifiers.
+var reservedKeywords = map[SQLDialect]map[string]bool{
+	DialectPostgres: keywordSet(
+		"all", "analyse", "analyze", "and", "any", "array", "as", "asc", "asymmetric", "both",
+		"case", "cast", "check", "collate", "column", "constraint", "create", "current_catalog",
+		"current_date", "current_role", "current_time", "current_timestamp", "current_user",
+		"default", "deferrable", "desc", "distinct", "do", "else", "end", "except", "false",
+		"fetch", "for", "foreign", "from", "grant", "group", "having", "in", "initially",
+		"intersect", "into", "lateral", "leading", "limit", "localtime", "localtimestamp", "not",
+		"null", "offset", "on", "only", "or", "order", "placing", "primary", "references",
+		"returning", "select", "session_user", "some", "symmetric", "table", "then", "to",
+		"trailing", "true", "union", "unique", "user", "using", "variadic", "when", "where",
+		"window", "with",
+	),
+	DialectMySQL: keywordSet(
+		"add", "all", "alter", "analyze", "and", "as", "asc", "before", "between", "by",
+		"call", "cascade", "case", "change", "check", "collate", "column", "condition",
+		"constraint", "convert", "create", "cross", "current_date", "current_time",
+		"current_timestamp", "current_user", "database", "default", "delete", "desc",
+		"describe", "distinct", "drop", "else", "exists", "explain", "false", "fetch", "for",
+		"foreign", "from", "grant", "group", "having", "if", "in", "index", "inner", "insert",
+		"interval", "into", "is", "join", "key", "keys", "kill", "leading", "left", "like",
+		"limit", "lock", "match", "natural", "not", "null", "on", "option", "or", "order",
+		"outer", "primary", "range", "read", "references", "rename", "replace", "require",
+		"restrict", "right", "select", "set", "show", "table", "then", "to", "trailing",
+		"trigger", "true", "union", "unique", "update", "usage", "use", "using", "values",
+		"when", "where", "with", "write",
+	),
+}
+
+func keywordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// LintReservedKeywords reports tables and columns named after reserved words
+// of the dialect. An empty dialect means PostgreSQL.
+func (p *Project) LintReservedKeywords(dialect SQLDialect) []LintError {
+	if dialect == "" {
+		dialect = DialectPostgres
+	}
+	keywords := reservedKeywords[dialect]
+	errs := []LintError{}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		if keywords[strings.ToLower(table.Name)] {
+			errs = append(errs, LintError{
+				Rule:     "reserved-keyword",
+				Message:  fmt.Sprintf("table name %s is a reserved word in %s", table.Name, dialect),
+				Schema:   table.Schema,
+				Table:    table.Name,
+				Severity: LintSeverityError,
+			})
+		}
+		for _, col := range table.Columns {
+			if keywords[strings.ToLower(col.Name)] {
+				errs = append(errs, LintError{
+					Rule:     "reserved-keyword",
+					Message:  fmt.Sprintf("column name %s is a reserved word in %s", col.Name, dialect),
+					Schema:   table.Schema,
+					Table:    table.Name,
+					Column:   col.Name,
+					Severity: LintSeverityError,
+				})
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,313 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_ValidateEnumUsages(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("order_status", "pending", "shipped")).
+		AddEnum(NewEnum("priority", "low", "high").WithSchema("ops")).
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("id", "bigint")).
+			AddColumn(NewColumn("name", "VARCHAR(255)")).
+			AddColumn(NewColumn("status", "order_status")).
+			AddColumn(NewColumn("history", "public.order_status[]")).
+			AddColumn(NewColumn("priority", "ops.priority")).
+			AddColumn(NewColumn("kind", "order_kind")).
+			AddColumn(NewColumn("level", "priority")).
+			AddColumn(NewColumn("label", "nvarchar(50)")).
+			AddColumn(NewColumn("area", "geometry")).
+			AddColumn(NewColumn("attrs", "hstore")).
+			AddColumn(NewColumn("span", "daterange")).
+			AddColumn(NewColumn("opens", "time with time zone")).
+			AddColumn(NewColumn("flags", "bit varying(5)")).
+			AddColumn(NewColumn("rank", "smallint unsigned")).
+			AddColumn(NewColumn("mode", "enum('a','b')")).
+			AddColumn(NewColumn("contact", "email_address").WithTypeAlias("text")))
+
+	errs := project.ValidateEnumUsages()
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 lint errors, got %d: %v", len(errs), errs)
+	}
+
+	if errs[0].Column != "kind" || errs[0].Table != "orders" || errs[0].Schema != "public" {
+		t.Errorf("Expected error for public.orders.kind, got %+v", errs[0])
+	}
+	if errs[0].Rule != "undefined-enum" {
+		t.Errorf("Expected rule 'undefined-enum', got '%s'", errs[0].Rule)
+	}
+	if errs[0].Severity != LintSeverityWarning {
+		t.Errorf("Expected warning severity, got %s", errs[0].Severity)
+	}
+	if errs[1].Column != "level" {
+		t.Errorf("Expected error for unqualified enum in another schema, got %+v", errs[1])
+	}
+}
+
+func TestLintError_Error(t *testing.T) {
+	err := LintError{
+		Rule:     "undefined-enum",
+		Message:  "bad type",
+		Schema:   "public",
+		Table:    "orders",
+		Column:   "kind",
+		Severity: LintSeverityError,
+	}
+
+	expected := "error: public.orders.kind: bad type (undefined-enum)"
+	if err.Error() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func newLintTestProject() *Project {
+	return NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("userName", "varchar")).
+			AddColumn(NewColumn("order", "int"))).
+		AddTable(NewTable("Events").
+			AddColumn(NewColumn("key", "varchar")).
+			AddColumn(NewColumn("kind", "event_kind")))
+}
+
+func TestProject_LintNamingConventions(t *testing.T) {
+	errs := newLintTestProject().LintNamingConventions()
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 lint errors, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Table != "Events" || errs[0].Column != "" {
+		t.Errorf("Expected table naming error for Events, got %+v", errs[0])
+	}
+	if errs[1].Column != "userName" {
+		t.Errorf("Expected column naming error for userName, got %+v", errs[1])
+	}
+}
+
+func TestProject_LintMissingPrimaryKeys(t *testing.T) {
+	errs := newLintTestProject().LintMissingPrimaryKeys()
+
+	if len(errs) != 1 || errs[0].Table != "Events" || errs[0].Rule != "missing-primary-key" {
+		t.Errorf("Expected one missing-primary-key error for Events, got %v", errs)
+	}
+}
+
+func TestProject_LintRowLevelSecurityTenant(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("orders").WithRowLevelSecurity(true).
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("tenant_id", "int"))).
+		AddTable(NewTable("invoices").WithRowLevelSecurity(true).
+			AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("plans").WithRowLevelSecurity(false).
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	errs := project.Lint(LintOptions{RowLevelSecurityTenant: true})
+
+	if len(errs) != 1 || errs[0].Table != "invoices" || errs[0].Rule != "rls-tenant-column" {
+		t.Fatalf("Expected one rls-tenant-column finding for invoices, got %v", errs)
+	}
+	if errs[0].Severity != LintSeverityWarning {
+		t.Errorf("Expected a warning, got %s", errs[0].Severity)
+	}
+}
+
+func TestProject_LintReservedKeywords(t *testing.T) {
+	project := newLintTestProject()
+
+	postgres := project.LintReservedKeywords("")
+	if len(postgres) != 1 || postgres[0].Column != "order" {
+		t.Errorf("Expected only 'order' to be reserved in PostgreSQL, got %v", postgres)
+	}
+
+	mysql := project.LintReservedKeywords(DialectMySQL)
+	if len(mysql) != 2 {
+		t.Errorf("Expected 'order' and 'key' to be reserved in MySQL, got %v", mysql)
+	}
+}
+
+func TestProject_Lint(t *testing.T) {
+	project := newLintTestProject()
+
+	if errs := project.Lint(LintOptions{}); len(errs) != 0 {
+		t.Errorf("Expected no lint errors with all checks disabled, got %v", errs)
+	}
+
+	errs := project.Lint(LintOptions{
+		Dialect:            DialectMySQL,
+		NamingConventions:  true,
+		MissingPrimaryKeys: true,
+		ReservedKeywords:   true,
+		EnumUsages:         true,
+	})
+
+	expected := []struct{ table, column, rule string }{
+		{"Events", "", "missing-primary-key"},
+		{"Events", "", "naming-convention"},
+		{"Events", "key", "reserved-keyword"},
+		{"Events", "kind", "undefined-enum"},
+		{"users", "order", "reserved-keyword"},
+		{"users", "userName", "naming-convention"},
+	}
+
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d lint errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, want := range expected {
+		if errs[i].Table != want.table || errs[i].Column != want.column || errs[i].Rule != want.rule {
+			t.Errorf("Expected error %d to be %s.%s (%s), got %+v", i, want.table, want.column, want.rule, errs[i])
+		}
+	}
+}
+
+func TestProject_Lint_MinSeverity(t *testing.T) {
+	project := newLintTestProject()
+	opts := LintOptions{
+		NamingConventions:  true,
+		MissingPrimaryKeys: true,
+		ReservedKeywords:   true,
+		EnumUsages:         true,
+	}
+
+	all := project.Lint(opts)
+	for _, err := range all {
+		want := LintSeverityWarning
+		if err.Rule == "reserved-keyword" {
+			want = LintSeverityError
+		}
+		if err.Severity != want {
+			t.Errorf("Expected %s severity for %s, got %s", want, err.Rule, err.Severity)
+		}
+	}
+
+	opts.MinSeverity = LintSeverityError
+	errs := project.Lint(opts)
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error at error severity, got %d: %v", len(errs), errs)
+	}
+	for _, err := range errs {
+		if err.Severity != LintSeverityError {
+			t.Errorf("Expected only errors, got %+v", err)
+		}
+	}
+}
+
+func TestProject_ValidateUniqueAliases(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").WithAlias("U")).
+		AddTable(NewTable("uploads").WithAlias("U")).
+		AddTable(NewTable("posts").WithAlias("P")).
+		AddTable(NewTable("tags"))
+
+	errs := project.ValidateUniqueAliases()
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if errs[0].Rule != "duplicate-alias" || errs[0].Table != "users" || errs[0].Severity != LintSeverityError {
+		t.Errorf("Expected duplicate-alias error on users, got %+v", errs[0])
+	}
+	if !strings.Contains(errs[0].Message, "public.uploads") {
+		t.Errorf("Expected message to name the table owning the alias, got %s", errs[0].Message)
+	}
+
+	if lint := project.Lint(LintOptions{UniqueAliases: true}); len(lint) != 1 {
+		t.Errorf("Expected Lint to report 1 duplicate alias, got %v", lint)
+	}
+}
+
+func TestProject_SuggestIndexes(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "int")).
+			AddColumn(NewColumn("editor_id", "int").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("reviewer_id", "int")).
+			AddIndex(NewIndex("reviewer_id", "id"))).
+		AddTable(NewTable("profiles").
+			AddColumn(NewColumn("user_id", "int").WithPrimaryKey())).
+		AddTable(NewTable("sessions").WithSchema("auth").
+			AddColumn(NewColumn("token", "text").WithPrimaryKey()).
+			AddColumn(NewColumn("tenant_id", "int")).
+			AddColumn(NewColumn("user_id", "int")).
+			AddIndex(NewIndex("user_id"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id")).
+		AddRef(NewRef(OneToMany).From("public", "users", "id").To("public", "posts", "user_id")).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "reviewer_id").To("public", "users", "id")).
+		AddRef(NewRef(OneToOne).From("public", "profiles", "user_id").To("public", "users", "id")).
+		AddRef(NewRef(ManyToOne).From("auth", "sessions", "tenant_id", "user_id").To("public", "memberships", "tenant_id", "user_id")).
+		AddRef(NewRef(ManyToMany).From("public", "posts", "id").To("public", "users", "id"))
+
+	suggestions := project.SuggestIndexes()
+
+	expected := []IndexSuggestion{
+		{Schema: "auth", Table: "sessions", Column: "tenant_id, user_id",
+			Reason: "foreign key to public.memberships(tenant_id, user_id) has no index"},
+		{Schema: "public", Table: "posts", Column: "editor_id", Reason: "foreign key to public.users(id) has no index"},
+		{Schema: "public", Table: "posts", Column: "user_id", Reason: "foreign key to public.users(id) has no index"},
+	}
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %d: %+v", len(expected), len(suggestions), suggestions)
+	}
+	for i, want := range expected {
+		if suggestions[i] != want {
+			t.Errorf("Expected suggestion %d to be %+v, got %+v", i, want, suggestions[i])
+		}
+	}
+}
+
+func TestProject_GetCyclicEnumReferences(t *testing.T) {
+	project := NewProject("shop").
+		AddEnum(NewEnum("order_status", "pending", "shipped")).
+		AddEnum(NewEnum("shipment_kind", "orders", "returns")).
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("status", "order_status"))).
+		AddTable(NewTable("shipments").
+			AddColumn(NewColumn("status", "order_status").WithPrimaryKey()).
+			AddColumn(NewColumn("kind", "shipment_kind"))).
+		AddRef(NewRef(ManyToOne).From("public", "orders", "status").To("public", "shipments", "status"))
+
+	cycles := project.GetCyclicEnumReferences()
+
+	expected := "public.orders.status [order_status] -> public.shipments.kind [shipment_kind] -> public.orders"
+	if len(cycles) != 1 || cycles[0] != expected {
+		t.Fatalf("Expected [%s], got %v", expected, cycles)
+	}
+
+	errs := project.Lint(LintOptions{CyclicEnumRefs: true})
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 lint warning, got %v", errs)
+	}
+	if errs[0].Rule != "cyclic-enum-reference" || errs[0].Severity != LintSeverityWarning ||
+		errs[0].Table != "orders" || errs[0].Column != "status" {
+		t.Errorf("Expected cyclic-enum-reference warning on orders.status, got %+v", errs[0])
+	}
+}
+
+func TestProject_GetCyclicEnumReferences_None(t *testing.T) {
+	project := NewProject("shop").
+		AddEnum(NewEnum("order_status", "pending", "shipped")).
+		AddEnum(NewEnum("shipment_kind", "parcel", "freight")).
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("status", "order_status")).
+			AddColumn(NewColumn("shipment_id", "int").WithRef(ManyToOne, "public", "shipments", "id"))).
+		AddTable(NewTable("shipments").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("kind", "shipment_kind")))
+
+	if cycles := project.GetCyclicEnumReferences(); len(cycles) != 0 {
+		t.Errorf("Expected no cycles, got %v", cycles)
+	}
+
+	// A matching value only counts when the enum column itself is in the ref
+	project.Enums["public.shipment_kind"].Values = append(project.Enums["public.shipment_kind"].Values, "orders")
+	if cycles := project.GetCyclicEnumReferences(); len(cycles) != 0 {
+		t.Errorf("Expected no cycles for a non-enum ref column, got %v", cycles)
+	}
+}
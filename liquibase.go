@@ -0,0 +1,210 @@
+package dbml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const liquibaseAuthor = "dbml"
+
+var numericLiteral = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+var xmlEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// GenerateLiquibaseChangelog generates a Liquibase XML changelog from a Project.
+// Sequences are created first, followed by tables, indexes, and foreign keys.
+// Tables excluded from DDL are skipped along with their foreign keys.
+func (p *Project) GenerateLiquibaseChangelog() string {
+	var b strings.Builder
+
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString("<databaseChangeLog\n")
+	b.WriteString(`    xmlns="http://www.liquibase.org/xml/ns/dbchangelog"` + "\n")
+	b.WriteString(`    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` + "\n")
+	b.WriteString(`    xsi:schemaLocation="http://www.liquibase.org/xml/ns/dbchangelog http://www.liquibase.org/xml/ns/dbchangelog/dbchangelog-latest.xsd">` + "\n")
+
+	tables := p.GetDDLTables()
+
+	// Sequences
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if col.Settings == nil || !col.Settings.Increment {
+				continue
+			}
+			seq := sequenceName(table, col)
+			writeChangeSetOpen(&b, "create-sequence-"+seq)
+			b.WriteString(fmt.Sprintf("    <createSequence schemaName=\"%s\" sequenceName=\"%s\" startValue=\"1\"/>\n",
+				xmlEscaper.Replace(table.Schema), xmlEscaper.Replace(seq)))
+			b.WriteString("  </changeSet>\n")
+		}
+	}
+
+	// Tables
+	for _, table := range tables {
+		b.WriteString(table.liquibaseCreateTable())
+	}
+
+	// Indexes
+	for _, table := range tables {
+		for _, idx := range table.Indexes {
+			b.WriteString(idx.liquibaseChangeSet(table))
+		}
+	}
+
+	// Foreign keys
+	for _, ref := range p.allRefs() {
+		child, parent, ok := ref.foreignKey()
+		if !ok || p.refTouchesExcludedTable(ref) {
+			continue
+		}
+		name := ref.foreignKeyName(child)
+		writeChangeSetOpen(&b, "add-fk-"+name)
+		b.WriteString(fmt.Sprintf(
+			"    <addForeignKeyConstraint constraintName=\"%s\" baseTableSchemaName=\"%s\" baseTableName=\"%s\" baseColumnNames=\"%s\" referencedTableSchemaName=\"%s\" referencedTableName=\"%s\" referencedColumnNames=\"%s\"",
+			xmlEscaper.Replace(name),
+			xmlEscaper.Replace(child.Schema), xmlEscaper.Replace(child.Table), xmlEscaper.Replace(strings.Join(child.Columns, ",")),
+			xmlEscaper.Replace(parent.Schema), xmlEscaper.Replace(parent.Table), xmlEscaper.Replace(strings.Join(parent.Columns, ",")),
+		))
+		if ref.OnDelete != nil {
+			b.WriteString(fmt.Sprintf(" onDelete=\"%s\"", ref.OnDelete.SQL(p.sqlDialect())))
+		}
+		if ref.OnUpdate != nil {
+			b.WriteString(fmt.Sprintf(" onUpdate=\"%s\"", ref.OnUpdate.SQL(p.sqlDialect())))
+		}
+		if ref.Deferrable != nil {
+			b.WriteString(fmt.Sprintf(" deferrable=\"true\" initiallyDeferred=\"%t\"", *ref.Deferrable == DeferrableInitiallyDeferred))
+		}
+		b.WriteString("/>\n")
+		b.WriteString("  </changeSet>\n")
+	}
+
+	b.WriteString("</databaseChangeLog>\n")
+
+	return b.String()
+}
+
+func (t *Table) liquibaseCreateTable() string {
+	var b strings.Builder
+
+	writeChangeSetOpen(&b, "create-table-"+t.Schema+"-"+t.Name)
+	b.WriteString(fmt.Sprintf("    <createTable schemaName=\"%s\" tableName=\"%s\"",
+		xmlEscaper.Replace(t.Schema), xmlEscaper.Replace(t.Name)))
+	if t.Note != nil {
+		b.WriteString(fmt.Sprintf(" remarks=\"%s\"", xmlEscaper.Replace(*t.Note)))
+	}
+	b.WriteString(">\n")
+
+	for _, col := range t.Columns {
+		b.WriteString(fmt.Sprintf("      <column name=\"%s\" type=\"%s\"",
+			xmlEscaper.Replace(col.Name), xmlEscaper.Replace(col.sqlType())))
+		if col.Settings != nil {
+			if col.Settings.Increment {
+				b.WriteString(fmt.Sprintf(" defaultValueSequenceNext=\"%s\"", xmlEscaper.Replace(sequenceName(t, col))))
+			} else if col.Settings.Default != nil && !col.Settings.DatabaseDefault {
+				b.WriteString(liquibaseDefaultAttr(sqlDefault(*col.Settings.Default, col.Settings.DefaultKind)))
+			}
+		}
+		if col.Note != nil {
+			b.WriteString(fmt.Sprintf(" remarks=\"%s\"", xmlEscaper.Replace(*col.Note)))
+		}
+		b.WriteString(">\n")
+
+		constraints := []string{}
+		if col.Settings != nil {
+			if col.Settings.PrimaryKey {
+				constraints = append(constraints, `primaryKey="true"`)
+			}
+			if col.Settings.Unique {
+				constraints = append(constraints, `unique="true"`)
+			}
+			if !col.Settings.Null {
+				constraints = append(constraints, `nullable="false"`)
+			}
+			if col.Settings.Check != nil {
+				constraints = append(constraints, fmt.Sprintf("checkConstraint=\"%s\"", xmlEscaper.Replace(*col.Settings.Check)))
+			}
+		}
+		if len(constraints) > 0 {
+			b.WriteString("        <constraints " + strings.Join(constraints, " ") + "/>\n")
+		}
+		b.WriteString("      </column>\n")
+	}
+
+	b.WriteString("    </createTable>\n")
+	b.WriteString("  </changeSet>\n")
+
+	return b.String()
+}
+
+func (i *Index) liquibaseChangeSet(table *Table) string {
+	var b strings.Builder
+
+	name := i.indexName(table)
+
+	if i.PrimaryKey {
+		columns := []string{}
+		for _, col := range i.Columns {
+			if col.Name != nil {
+				columns = append(columns, *col.Name)
+			}
+		}
+		writeChangeSetOpen(&b, "add-pk-"+name)
+		b.WriteString(fmt.Sprintf("    <addPrimaryKey constraintName=\"%s\" schemaName=\"%s\" tableName=\"%s\" columnNames=\"%s\"/>\n",
+			xmlEscaper.Replace(name), xmlEscaper.Replace(table.Schema), xmlEscaper.Replace(table.Name),
+			xmlEscaper.Replace(strings.Join(columns, ","))))
+		b.WriteString("  </changeSet>\n")
+		return b.String()
+	}
+
+	writeChangeSetOpen(&b, "create-index-"+name)
+	b.WriteString(fmt.Sprintf("    <createIndex indexName=\"%s\" schemaName=\"%s\" tableName=\"%s\"",
+		xmlEscaper.Replace(name), xmlEscaper.Replace(table.Schema), xmlEscaper.Replace(table.Name)))
+	if i.Unique {
+		b.WriteString(` unique="true"`)
+	}
+	b.WriteString(">\n")
+
+	for _, col := range i.Columns {
+		if col.Name != nil {
+			b.WriteString(fmt.Sprintf("      <column name=\"%s\"/>\n", xmlEscaper.Replace(*col.Name)))
+		} else if col.Expression != nil {
+			b.WriteString(fmt.Sprintf("      <column name=\"%s\" computed=\"true\"/>\n", xmlEscaper.Replace(*col.Expression)))
+		}
+	}
+
+	b.WriteString("    </createIndex>\n")
+	b.WriteString("  </changeSet>\n")
+
+	return b.String()
+}
+
+func writeChangeSetOpen(b *strings.Builder, id string) {
+	b.WriteString(fmt.Sprintf("  <changeSet id=\"%s\" author=\"%s\">\n", xmlEscaper.Replace(id), liquibaseAuthor))
+}
+
+// liquibaseDefaultAttr picks the Liquibase default attribute matching the value's shape.
+func liquibaseDefaultAttr(value string) string {
+	switch {
+	case value == "true" || value == "false":
+		return fmt.Sprintf(" defaultValueBoolean=\"%s\"", value)
+	case isNumeric(value):
+		return fmt.Sprintf(" defaultValueNumeric=\"%s\"", value)
+	case len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"):
+		return fmt.Sprintf(" defaultValue=\"%s\"", xmlEscaper.Replace(value[1:len(value)-1]))
+	default:
+		return fmt.Sprintf(" defaultValueComputed=\"%s\"", xmlEscaper.Replace(value))
+	}
+}
+
+// isNumeric reports whether s is a plain numeric literal like 42, -1.5 or
+// 1e3. Go-only spellings such as NaN, Inf and hex floats are not.
+func isNumeric(s string) bool {
+	return numericLiteral.MatchString(s)
+}
@@ -0,0 +1,92 @@
+package dbml
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateLiquibaseChangelog(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			WithNote("User accounts").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique()).
+			AddColumn(NewColumn("active", "boolean").WithDefault("true")).
+			AddIndex(NewIndex("email").WithName("idx_users_email")).
+			AddIndex(NewExpressionIndex("lower(email)"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint").
+				WithRef(ManyToOne, "public", "users", "id")))
+
+	project.AddRef(NewRef(OneToMany).
+		WithName("fk_users_posts").
+		From("public", "users", "id").
+		To("public", "posts", "user_id").
+		WithOnDelete(Cascade))
+
+	output := project.GenerateLiquibaseChangelog()
+
+	if err := xml.Unmarshal([]byte(output), new(struct{})); err != nil {
+		t.Fatalf("Expected well-formed XML, got error: %v\n%s", err, output)
+	}
+
+	expected := []string{
+		`<createSequence schemaName="public" sequenceName="public_users_id_seq" startValue="1"/>`,
+		`<createTable schemaName="public" tableName="users" remarks="User accounts">`,
+		`<column name="id" type="bigint" defaultValueSequenceNext="public_users_id_seq">`,
+		`<constraints primaryKey="true" nullable="false"/>`,
+		`<column name="active" type="boolean" defaultValueBoolean="true">`,
+		`<createIndex indexName="idx_users_email" schemaName="public" tableName="users">`,
+		`<column name="lower(email)" computed="true"/>`,
+		`baseTableName="posts" baseColumnNames="user_id" referencedTableSchemaName="public" referencedTableName="users" referencedColumnNames="id" onDelete="CASCADE"/>`,
+		`constraintName="fk_posts_user_id"`,
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Index(output, "<createSequence") > strings.Index(output, "<createTable") {
+		t.Error("Expected sequences to be created before tables")
+	}
+}
+
+func TestProject_GenerateLiquibaseChangelog_TypeAliasAndExcludedTables(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithTypeAlias("int8").WithPrimaryKey())).
+		AddTable(NewTable("audit").WithExcludeFromDDL().
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")).
+			AddIndex(NewIndex("user_id")))
+
+	output := project.GenerateLiquibaseChangelog()
+
+	if !strings.Contains(output, `<column name="id" type="int8">`) {
+		t.Errorf("Expected the type alias to be used, got:\n%s", output)
+	}
+	for _, unwanted := range []string{`tableName="audit"`, "<createSequence", "<addForeignKeyConstraint"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected excluded table to be skipped, found %q in:\n%s", unwanted, output)
+		}
+	}
+}
+
+func TestLiquibaseDefaultAttr(t *testing.T) {
+	tests := map[string]string{
+		"0":        ` defaultValueNumeric="0"`,
+		"false":    ` defaultValueBoolean="false"`,
+		"'active'": ` defaultValue="active"`,
+		"now()":    ` defaultValueComputed="now()"`,
+	}
+
+	for value, want := range tests {
+		if got := liquibaseDefaultAttr(value); got != want {
+			t.Errorf("liquibaseDefaultAttr(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
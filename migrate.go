@@ -0,0 +1,39 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GenerateMigrateFiles generates the up and down SQL of a golang-migrate
+// migration. up is the PostgreSQL DDL from GenerateSQL. down drops the DDL
+// tables with DROP TABLE IF EXISTS in reverse dependency order, so that
+// referencing tables go before the tables they reference, and then drops the
+// enum types. Tables in a foreign key cycle are dropped with CASCADE.
+func (p *Project) GenerateMigrateFiles() (up, down string) {
+	up = p.GenerateSQL(DialectPostgres)
+
+	var b strings.Builder
+	ordered, cyclic := p.ddlTablesInDependencyOrder()
+	for _, table := range slices.Backward(ordered) {
+		b.WriteString("DROP TABLE IF EXISTS " + pgQualifiedName(table.Schema, table.Name))
+		if cyclic[table.Schema+"."+table.Name] {
+			b.WriteString(" CASCADE")
+		}
+		b.WriteString(";\n")
+	}
+	for _, key := range sortedKeys(p.Enums) {
+		enum := p.Enums[key]
+		b.WriteString("DROP TYPE IF EXISTS " + pgQualifiedName(enum.Schema, enum.Name) + ";\n")
+	}
+
+	return up, b.String()
+}
+
+// MigrateFilename returns the golang-migrate file names for a migration,
+// e.g. 000001_create_users.up.sql and 000001_create_users.down.sql.
+func (p *Project) MigrateFilename(version int, description string) (upName, downName string) {
+	prefix := fmt.Sprintf("%06d_%s", version, migrationSlug(description))
+	return prefix + ".up.sql", prefix + ".down.sql"
+}
@@ -0,0 +1,75 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateMigrateFiles(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("comments").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("post_id", "int").WithRef(ManyToOne, "public", "posts", "id"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "int")).
+			AddColumn(NewColumn("status", "post_status"))).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("manager_id", "int").WithRef(ManyToOne, "public", "users", "id"))).
+		AddTable(NewTable("legacy").WithExcludeFromDDL().
+			AddColumn(NewColumn("id", "int"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id"))
+
+	up, down := project.GenerateMigrateFiles()
+
+	if up != project.GenerateSQL(DialectPostgres) {
+		t.Errorf("Expected up to be the PostgreSQL DDL, got:\n%s", up)
+	}
+
+	expected := `DROP TABLE IF EXISTS "public"."comments";
+DROP TABLE IF EXISTS "public"."posts";
+DROP TABLE IF EXISTS "public"."users";
+DROP TYPE IF EXISTS "public"."post_status";
+`
+	if down != expected {
+		t.Errorf("Expected down:\n%s\ngot:\n%s", expected, down)
+	}
+}
+
+func TestProject_GenerateMigrateFiles_Cycle(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("a").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("b_id", "int").WithRef(ManyToOne, "public", "b", "id"))).
+		AddTable(NewTable("b").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("a_id", "int").WithRef(ManyToOne, "public", "a", "id"))).
+		AddTable(NewTable("c").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	_, down := project.GenerateMigrateFiles()
+
+	expected := []string{
+		`DROP TABLE IF EXISTS "public"."a" CASCADE;`,
+		`DROP TABLE IF EXISTS "public"."b" CASCADE;`,
+		`DROP TABLE IF EXISTS "public"."c";`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(down, exp) {
+			t.Errorf("Expected down to contain %q, got:\n%s", exp, down)
+		}
+	}
+}
+
+func TestProject_MigrateFilename(t *testing.T) {
+	upName, downName := NewProject("test").MigrateFilename(1, "Create users")
+
+	if upName != "000001_create_users.up.sql" {
+		t.Errorf("Expected 000001_create_users.up.sql, got %s", upName)
+	}
+	if downName != "000001_create_users.down.sql" {
+		t.Errorf("Expected 000001_create_users.down.sql, got %s", downName)
+	}
+}
@@ -0,0 +1,207 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mysqlIndexTypes are the index methods MySQL accepts in USING clauses.
+var mysqlIndexTypes = map[string]bool{
+	"BTREE": true,
+	"HASH":  true,
+}
+
+// GenerateMySQLSQL generates MySQL 8 DDL from a Project. Tables outside the
+// default schema are created in a database of the same name, which is
+// created first. Enum columns use inline ENUM(...) types. Primary keys,
+// indexes and foreign keys are declared in the CREATE TABLE body, so tables
+// are created after the tables they reference; if foreign keys form a cycle,
+// FOREIGN_KEY_CHECKS is disabled around the script. Index types other than
+// BTREE and HASH are left out. Tables excluded from DDL are skipped along
+// with their foreign keys.
+func (p *Project) GenerateMySQLSQL() string {
+	var b strings.Builder
+
+	tables, cyclic := p.ddlTablesInDependencyOrder()
+
+	databases := map[string]bool{}
+	for _, table := range tables {
+		if table.Schema != defaultSchemaName {
+			databases[table.Schema] = true
+		}
+	}
+	for _, name := range sortedKeys(databases) {
+		b.WriteString(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s;\n", mysqlQuoteIdent(name)))
+	}
+	if len(databases) > 0 {
+		b.WriteString("\n")
+	}
+
+	foreignKeys := map[string][]*Ref{}
+	for _, ref := range p.allRefs() {
+		child, _, ok := ref.foreignKey()
+		if !ok || p.refTouchesMigrationTable(ref) || p.refTouchesExcludedTable(ref) {
+			continue
+		}
+		key := child.Schema + "." + child.Table
+		foreignKeys[key] = append(foreignKeys[key], ref)
+	}
+
+	if len(cyclic) > 0 {
+		b.WriteString("SET FOREIGN_KEY_CHECKS = 0;\n\n")
+	}
+	for _, table := range tables {
+		b.WriteString(p.mysqlCreateTable(table, foreignKeys[table.Schema+"."+table.Name]))
+		b.WriteString("\n")
+	}
+	if len(cyclic) > 0 {
+		b.WriteString("SET FOREIGN_KEY_CHECKS = 1;\n")
+	}
+
+	return b.String()
+}
+
+func (p *Project) mysqlCreateTable(t *Table, foreignKeys []*Ref) string {
+	var b strings.Builder
+
+	pkColumns := t.primaryKeyColumns()
+	inlinePK := len(pkColumns) == 1 && !t.hasPrimaryKeyIndex()
+
+	lines := []string{}
+	for _, col := range t.Columns {
+		lines = append(lines, "  "+p.mysqlDefinition(col, inlinePK))
+	}
+	if !inlinePK && len(pkColumns) > 0 {
+		lines = append(lines, fmt.Sprintf("  PRIMARY KEY (%s)", mysqlQuoteIdentList(pkColumns)))
+	}
+	for _, idx := range t.Indexes {
+		if !idx.PrimaryKey {
+			lines = append(lines, "  "+idx.mysqlIndex(t))
+		}
+	}
+	for _, ref := range foreignKeys {
+		lines = append(lines, "  "+mysqlForeignKey(ref))
+	}
+
+	b.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", mysqlQualifiedName(t.Schema, t.Name)))
+	b.WriteString(strings.Join(lines, ",\n"))
+	b.WriteString("\n)")
+	if t.SQLComment != nil {
+		b.WriteString(" COMMENT=" + quoteSQLString(*t.SQLComment))
+	}
+	b.WriteString(";\n")
+
+	return b.String()
+}
+
+func (p *Project) mysqlDefinition(c *Column, inlinePK bool) string {
+	colType := c.sqlType()
+	if enum := p.columnEnum(c); enum != nil {
+		values := make([]string, len(enum.Values))
+		for i, value := range enum.Values {
+			values[i] = quoteSQLString(value)
+		}
+		colType = "ENUM(" + strings.Join(values, ", ") + ")"
+	}
+	parts := []string{mysqlQuoteIdent(c.Name), colType}
+
+	if c.ComputedAlias != nil {
+		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) VIRTUAL", *c.ComputedAlias))
+	}
+
+	if c.Settings != nil {
+		if !c.Settings.Null && !(c.Settings.PrimaryKey && inlinePK) {
+			parts = append(parts, "NOT NULL")
+		}
+		if c.Settings.Default != nil && !c.Settings.Increment && !c.Settings.DatabaseDefault && c.ComputedAlias == nil {
+			parts = append(parts, "DEFAULT "+mysqlDefault(*c.Settings.Default, c.Settings.DefaultKind))
+		}
+		if c.Settings.Increment {
+			parts = append(parts, "AUTO_INCREMENT")
+		}
+		if c.Settings.Unique {
+			parts = append(parts, "UNIQUE")
+		}
+		if c.Settings.PrimaryKey && inlinePK {
+			parts = append(parts, "PRIMARY KEY")
+		}
+		if c.Settings.Check != nil {
+			parts = append(parts, fmt.Sprintf("CHECK (%s)", *c.Settings.Check))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// mysqlDefault writes a column default. MySQL 8 requires expression
+// defaults to be parenthesized.
+func mysqlDefault(value string, kind DefaultKind) string {
+	if kind == DefaultKindExpression {
+		return "(" + sqlDefault(value, kind) + ")"
+	}
+	return sqlDefault(value, kind)
+}
+
+func (i *Index) mysqlIndex(table *Table) string {
+	columns := []string{}
+	for _, col := range i.Columns {
+		if col.Name != nil {
+			columns = append(columns, mysqlQuoteIdent(*col.Name))
+		} else if col.Expression != nil {
+			columns = append(columns, "("+*col.Expression+")")
+		}
+	}
+
+	kind := "INDEX"
+	if i.Unique {
+		kind = "UNIQUE INDEX"
+	}
+
+	using := ""
+	if i.Type != nil && mysqlIndexTypes[strings.ToUpper(*i.Type)] {
+		using = " USING " + strings.ToUpper(*i.Type)
+	}
+
+	return fmt.Sprintf("%s %s (%s)%s", kind, mysqlQuoteIdent(i.indexName(table)), strings.Join(columns, ", "), using)
+}
+
+func mysqlForeignKey(r *Ref) string {
+	child, parent, _ := r.foreignKey()
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		mysqlQuoteIdent(r.foreignKeyName(child)),
+		mysqlQuoteIdentList(child.Columns),
+		mysqlQualifiedName(parent.Schema, parent.Table),
+		mysqlQuoteIdentList(parent.Columns),
+	))
+	if r.OnDelete != nil {
+		b.WriteString(" ON DELETE " + r.OnDelete.SQL(DialectMySQL))
+	}
+	if r.OnUpdate != nil {
+		b.WriteString(" ON UPDATE " + r.OnUpdate.SQL(DialectMySQL))
+	}
+
+	return b.String()
+}
+
+func mysqlQuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func mysqlQuoteIdentList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = mysqlQuoteIdent(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// mysqlQualifiedName returns the table name, qualified with its database
+// outside the default schema.
+func mysqlQualifiedName(schema, name string) string {
+	if schema == "" || schema == defaultSchemaName {
+		return mysqlQuoteIdent(name)
+	}
+	return mysqlQuoteIdent(schema) + "." + mysqlQuoteIdent(name)
+}
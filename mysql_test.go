@@ -0,0 +1,88 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateMySQLSQL(t *testing.T) {
+	project := NewProject("shop").
+		AddEnum(NewEnum("order_status", "pending", "shipped")).
+		AddTable(NewTable("orders").WithSchema("sales").
+			WithSQLComment("Customer orders").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "order_status").WithDefault("'pending'")).
+			AddColumn(NewColumn("total", "decimal(10,2)").WithCheck("total >= 0")).
+			AddColumn(NewColumn("created_at", "datetime").WithDefault("now()")).
+			AddColumn(NewColumn("code", "varchar(20)").WithNull().WithUnique()).
+			AddColumn(NewColumn("code_upper", "varchar(20)").WithComputedAlias("upper(code)")).
+			AddIndex(NewIndex("user_id", "created_at").WithType("btree")).
+			AddIndex(NewExpressionIndex("lower(code)").WithUnique().WithName("uq_orders_code").WithType("gin"))).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement())).
+		AddTable(NewTable("order_lines").WithSchema("sales").
+			AddColumn(NewColumn("order_id", "bigint")).
+			AddColumn(NewColumn("line", "int")).
+			AddIndex(NewIndex("order_id", "line").WithPrimaryKey())).
+		AddTable(NewTable("legacy").WithExcludeFromDDL().
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id"))).
+		AddRef(NewRef(ManyToOne).From("sales", "orders", "user_id").To("public", "users", "id").WithOnDelete(Cascade)).
+		AddRef(NewRef(ManyToOne).From("sales", "order_lines", "order_id").To("sales", "orders", "id"))
+
+	output := project.GenerateMySQLSQL()
+
+	expected := []string{
+		"CREATE DATABASE IF NOT EXISTS `sales`;\n\n",
+		"CREATE TABLE `users` (\n  `id` bigint AUTO_INCREMENT PRIMARY KEY\n);\n",
+		"  `id` bigint AUTO_INCREMENT PRIMARY KEY,\n",
+		"  `status` ENUM('pending', 'shipped') NOT NULL DEFAULT 'pending',\n",
+		"  `total` decimal(10,2) NOT NULL CHECK (total >= 0),\n",
+		"  `created_at` datetime NOT NULL DEFAULT (now()),\n",
+		"  `code` varchar(20) UNIQUE,\n",
+		"  `code_upper` varchar(20) GENERATED ALWAYS AS (upper(code)) VIRTUAL NOT NULL,\n",
+		"  INDEX `idx_orders_user_id_created_at` (`user_id`, `created_at`) USING BTREE,\n",
+		"  UNIQUE INDEX `uq_orders_code` ((lower(code))),\n",
+		"  CONSTRAINT `fk_orders_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE\n) COMMENT='Customer orders';\n",
+		"  `line` int NOT NULL,\n  PRIMARY KEY (`order_id`, `line`),\n",
+		"  CONSTRAINT `fk_order_lines_order_id` FOREIGN KEY (`order_id`) REFERENCES `sales`.`orders` (`id`)\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+		}
+	}
+
+	users := strings.Index(output, "CREATE TABLE `users`")
+	orders := strings.Index(output, "CREATE TABLE `sales`.`orders`")
+	lines := strings.Index(output, "CREATE TABLE `sales`.`order_lines`")
+	if users > orders || orders > lines {
+		t.Errorf("Expected referenced tables to be created first, got:\n%s", output)
+	}
+
+	for _, unexpected := range []string{"legacy", "CREATE TYPE", "FOREIGN_KEY_CHECKS", "USING GIN"} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("Expected output not to contain %q, got:\n%s", unexpected, output)
+		}
+	}
+
+	if project.GenerateSQL(DialectMySQL) != output {
+		t.Error("Expected GenerateSQL(DialectMySQL) to use the MySQL generator")
+	}
+}
+
+func TestProject_GenerateMySQLSQL_Cycle(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("a").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("b_id", "int").WithRef(ManyToOne, "public", "b", "id"))).
+		AddTable(NewTable("b").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("a_id", "int").WithRef(ManyToOne, "public", "a", "id")))
+
+	output := project.GenerateMySQLSQL()
+
+	if !strings.HasPrefix(output, "SET FOREIGN_KEY_CHECKS = 0;\n\n") || !strings.HasSuffix(output, "SET FOREIGN_KEY_CHECKS = 1;\n") {
+		t.Errorf("Expected foreign key checks to be disabled around a cycle, got:\n%s", output)
+	}
+}
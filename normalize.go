@@ -0,0 +1,162 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Normalize rewrites the project in place into a canonical form and returns
+// it. Empty schemas become the default schema, inline refs become standalone
+// refs, primary key columns are made not null, enum values are lowercased
+// along with their value notes and the column defaults that use them, and
+// columns and refs are sorted. Normalizing twice yields the same project.
+// Some projects have no canonical form, so Normalize returns an error rather
+// than drop data: when a table or enum with an empty schema would replace
+// one of the same name in the default schema, or lowercasing would make two
+// values of an enum equal. The project is left unchanged in that case.
+func (p *Project) Normalize() (*Project, error) {
+	tableKeys := map[string]string{}
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		normalized := normalizedKey(table.Schema, table.Name)
+		if other, ok := tableKeys[normalized]; ok {
+			return nil, fmt.Errorf("table %s: %w", key, &ValidationError{
+				Field:   "Table.Schema",
+				Message: fmt.Sprintf("table collides with %s when the default schema is applied", other),
+			})
+		}
+		tableKeys[normalized] = key
+	}
+
+	enumKeys := map[string]string{}
+	for _, key := range sortedKeys(p.Enums) {
+		enum := p.Enums[key]
+		normalized := normalizedKey(enum.Schema, enum.Name)
+		if other, ok := enumKeys[normalized]; ok {
+			return nil, fmt.Errorf("enum %s: %w", key, &ValidationError{
+				Field:   "Enum.Schema",
+				Message: fmt.Sprintf("enum collides with %s when the default schema is applied", other),
+			})
+		}
+		enumKeys[normalized] = key
+	}
+
+	for _, key := range sortedKeys(p.Enums) {
+		seen := map[string]string{}
+		for i, value := range p.Enums[key].Values {
+			lower := strings.ToLower(value)
+			if other, ok := seen[lower]; ok {
+				return nil, fmt.Errorf("enum %s: %w", key, &ValidationError{
+					Field:   fmt.Sprintf("Enum.Values[%d]", i),
+					Message: fmt.Sprintf("value %q collides with %q when lowercased", value, other),
+				})
+			}
+			seen[lower] = value
+		}
+	}
+
+	tables := make(map[string]*Table, len(p.Tables))
+	for _, table := range p.Tables {
+		if table.Schema == "" {
+			table.Schema = defaultSchemaName
+		}
+		tables[table.Schema+"."+table.Name] = table
+	}
+	p.Tables = tables
+
+	enums := make(map[string]*Enum, len(p.Enums))
+	for _, enum := range p.Enums {
+		if enum.Schema == "" {
+			enum.Schema = defaultSchemaName
+		}
+		for i, value := range enum.Values {
+			enum.Values[i] = strings.ToLower(value)
+		}
+		if len(enum.ValueNotes) > 0 {
+			notes := make(map[string]string, len(enum.ValueNotes))
+			for value, note := range enum.ValueNotes {
+				notes[strings.ToLower(value)] = note
+			}
+			enum.ValueNotes = notes
+		}
+		enums[enum.Schema+"."+enum.Name] = enum
+	}
+	p.Enums = enums
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		for _, col := range table.Columns {
+			if col.InlineRef != nil {
+				p.Refs = append(p.Refs, col.InlineRef.ToRef(table.Schema, table.Name, col.Name))
+				col.InlineRef = nil
+			}
+			if col.Settings != nil && col.Settings.PrimaryKey {
+				col.Settings.Null = false
+			}
+			if enum := p.columnEnum(col); enum != nil {
+				normalizeEnumDefault(col, enum)
+			}
+		}
+		sort.SliceStable(table.Columns, func(i, j int) bool {
+			return table.Columns[i].Name < table.Columns[j].Name
+		})
+	}
+
+	for _, ref := range p.Refs {
+		for _, endpoint := range []*RefEndpoint{ref.Left, ref.Right} {
+			if endpoint != nil && endpoint.Schema == "" {
+				endpoint.Schema = defaultSchemaName
+			}
+		}
+	}
+	sort.SliceStable(p.Refs, func(i, j int) bool {
+		return refSortKey(p.Refs[i]) < refSortKey(p.Refs[j])
+	})
+
+	for _, group := range p.TableGroups {
+		for i := range group.Tables {
+			if group.Tables[i].Schema == "" {
+				group.Tables[i].Schema = defaultSchemaName
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// normalizedKey returns the project key of schema.name, with an empty schema
+// replaced by the default schema.
+func normalizedKey(schema, name string) string {
+	if schema == "" {
+		schema = defaultSchemaName
+	}
+	return schema + "." + name
+}
+
+// normalizeEnumDefault lowercases a column default naming a value of its
+// enum, keeping the default's quoting.
+func normalizeEnumDefault(c *Column, enum *Enum) {
+	if c.Settings == nil || c.Settings.Default == nil {
+		return
+	}
+	value := *c.Settings.Default
+	quoted := len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")
+	if quoted {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	lower := strings.ToLower(value)
+	if !slices.Contains(enum.Values, lower) {
+		return
+	}
+	if quoted {
+		lower = quoteSQLString(lower)
+	}
+	c.Settings.Default = &lower
+}
+
+func refSortKey(r *Ref) string {
+	return defaultGenerator.formatRefEndpoint(r.Left) + " " + string(r.Type) + " " + defaultGenerator.formatRefEndpoint(r.Right)
+}
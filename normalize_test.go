@@ -0,0 +1,141 @@
+package dbml
+
+import (
+	"errors"
+	"testing"
+)
+
+func newNormalizeTestProject() *Project {
+	project := NewProject("test").
+		AddEnum(&Enum{Name: "status", Values: []string{"Active", "INACTIVE"}}).
+		AddTable(&Table{
+			Name:     "users",
+			Settings: map[string]string{},
+			Columns: []*Column{
+				NewColumn("name", "varchar"),
+				{Name: "id", Type: "int", Settings: &ColumnSettings{PrimaryKey: true, Null: true}},
+			},
+		}).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "", "users", "id")).
+			AddColumn(NewColumn("id", "int")))
+
+	project.AddRef(NewRef(OneToOne).From("public", "users", "id").To("public", "posts", "id"))
+
+	return project
+}
+
+func TestProject_Normalize(t *testing.T) {
+	project, err := newNormalizeTestProject().Normalize()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	users, ok := project.Tables["public.users"]
+	if !ok {
+		t.Fatalf("Expected table re-keyed under default schema, got keys %v", sortedKeys(project.Tables))
+	}
+
+	if users.Columns[0].Name != "id" || users.Columns[1].Name != "name" {
+		t.Errorf("Expected columns sorted by name, got %s, %s", users.Columns[0].Name, users.Columns[1].Name)
+	}
+
+	if users.Columns[0].Settings.Null {
+		t.Error("Expected primary key column to be not null")
+	}
+
+	enum, ok := project.Enums["public.status"]
+	if !ok {
+		t.Fatal("Expected enum re-keyed under default schema")
+	}
+	if enum.Values[0] != "active" || enum.Values[1] != "inactive" {
+		t.Errorf("Expected lowercase enum values, got %v", enum.Values)
+	}
+
+	if project.InlineRefCount() != 0 {
+		t.Error("Expected inline refs to be converted")
+	}
+
+	if len(project.Refs) != 2 {
+		t.Fatalf("Expected 2 standalone refs, got %d", len(project.Refs))
+	}
+
+	converted := project.Refs[0]
+	if converted.Left.Table != "posts" || converted.Right.Schema != "public" || converted.Right.Table != "users" {
+		t.Errorf("Expected converted inline ref posts.user_id > public.users.id first, got %s", refSortKey(converted))
+	}
+}
+
+func TestProject_Normalize_Idempotent(t *testing.T) {
+	once, err := newNormalizeTestProject().Normalize()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	normalized, _ := newNormalizeTestProject().Normalize()
+	twice, err := normalized.Normalize()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !once.Equal(twice) {
+		t.Errorf("Expected normalizing twice to match normalizing once:\n%s\n---\n%s", once.Generate(), twice.Generate())
+	}
+}
+
+func TestProject_Normalize_EnumReferences(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("status", "Active", "Won't Do").SetValueNote("Active", "In use")).
+		AddTable(NewTable("tasks").
+			AddColumn(NewColumn("status", "status").WithDefault("'Active'")).
+			AddColumn(NewColumn("previous", "status").WithDefault("'Won''t Do'")).
+			AddColumn(NewColumn("label", "text").WithDefault("'Active'")))
+
+	normalized, err := project.Normalize()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	enum := normalized.Enums["public.status"]
+	if note, ok := enum.ValueNotes["active"]; !ok || note != "In use" {
+		t.Errorf("Expected value note to follow the lowercased value, got %v", enum.ValueNotes)
+	}
+
+	columns := normalized.Tables["public.tasks"].Columns
+	expected := map[string]string{"label": "'Active'", "previous": "'won''t do'", "status": "'active'"}
+	for _, col := range columns {
+		if got := *col.Settings.Default; got != expected[col.Name] {
+			t.Errorf("Expected %s default %s, got %s", col.Name, expected[col.Name], got)
+		}
+	}
+}
+
+func TestProject_Normalize_EnumCollision(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("status", "Active", "ACTIVE"))
+
+	_, err := project.Normalize()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "Enum.Values[1]" {
+		t.Fatalf("Expected collision validation error, got %v", err)
+	}
+	if project.Enums["public.status"].Values[0] != "Active" {
+		t.Error("Expected project to be left unchanged")
+	}
+}
+
+func TestProject_Normalize_TableCollision(t *testing.T) {
+	unqualified := NewTable("users").AddColumn(NewColumn("id", "int"))
+	unqualified.Schema = ""
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("email", "text")))
+	project.Tables[".users"] = unqualified
+
+	_, err := project.Normalize()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "Table.Schema" {
+		t.Fatalf("Expected table collision validation error, got %v", err)
+	}
+	if len(project.Tables) != 2 || project.Tables["public.users"].Columns[0].Name != "email" {
+		t.Error("Expected project to be left unchanged")
+	}
+}
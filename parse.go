@@ -0,0 +1,1247 @@
+package dbml
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseError reports a syntax error in DBML input. Line and Column are
+// 1-based; Column counts runes.
+type ParseError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// Parse parses DBML into a Project. It accepts the syntax written by
+// Generate and its options, including expression and composite indexes,
+// composite refs, inline refs and both the block and one-line ref forms,
+// as well as // and /* */ comments. Unqualified names belong to the default
+// schema used by Generate. Tables keep the order they appear in through
+// TableOrder. Fields Generate does not write, such as TypeAlias, Tags,
+// SQLComment, ExcludeFromDDL, DatabaseDefault, Deferrable and ParentGroup,
+// are left unset. The first syntax error is returned as a *ParseError.
+func Parse(input string) (*Project, error) {
+	p := &parser{input: []rune(input), line: 1, col: 1, schema: defaultGenerator.DefaultSchema}
+	project := NewProject("")
+	if err := p.parseProject(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// RoundTripCheck generates the project's DBML, parses it back and reports
+// an error if the result is not Equal to p. Fields Generate does not write
+// (see Parse) must be unset for the check to pass.
+func (p *Project) RoundTripCheck() error {
+	parsed, err := Parse(p.Generate())
+	if err != nil {
+		return fmt.Errorf("parse generated DBML: %w", err)
+	}
+	if !parsed.Equal(p) {
+		return errors.New("parsed project differs from the original")
+	}
+	return nil
+}
+
+// parser is a recursive-descent parser reading DBML rune by rune.
+type parser struct {
+	schema string // schema of unqualified names
+	input  []rune
+	pos    int
+	line   int
+	col    int
+}
+
+// settingKind records how a setting value was written.
+type settingKind int
+
+const (
+	settingNone       settingKind = iota // flag without a value, e.g. pk
+	settingRaw                           // bare value, e.g. btree or 42
+	settingString                        // quoted string
+	settingExpression                    // backtick expression
+)
+
+// setting is one entry of a [key: value, ...] settings list. Keys are
+// lowercased with runs of spaces collapsed, e.g. "not null".
+type setting struct {
+	key   string
+	value string
+	kind  settingKind
+	line  int
+	col   int
+}
+
+func (s setting) errorf(format string, args ...any) error {
+	return &ParseError{Line: s.line, Column: s.col, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &ParseError{Line: p.line, Column: p.col, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+// peek returns the rune n positions ahead, or 0 past the end of the input.
+func (p *parser) peek(n int) rune {
+	if p.pos+n >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos+n]
+}
+
+func (p *parser) advance() rune {
+	r := p.input[p.pos]
+	p.pos++
+	if r == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+	return r
+}
+
+// describe names the next rune for error messages.
+func (p *parser) describe() string {
+	switch {
+	case p.eof():
+		return "end of input"
+	case p.peek(0) == '\n':
+		return "end of line"
+	default:
+		return strconv.QuoteRune(p.peek(0))
+	}
+}
+
+// skipSpace skips spaces and comments on the current line.
+func (p *parser) skipSpace() {
+	for !p.eof() {
+		switch r := p.peek(0); {
+		case r == ' ' || r == '\t' || r == '\r':
+			p.advance()
+		case r == '/' && p.peek(1) == '/':
+			for !p.eof() && p.peek(0) != '\n' {
+				p.advance()
+			}
+		case r == '/' && p.peek(1) == '*':
+			p.advance()
+			p.advance()
+			for !p.eof() && (p.peek(0) != '*' || p.peek(1) != '/') {
+				p.advance()
+			}
+			if !p.eof() {
+				p.advance()
+				p.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// skipBlank skips spaces, comments and newlines.
+func (p *parser) skipBlank() {
+	for {
+		p.skipSpace()
+		if p.peek(0) != '\n' {
+			return
+		}
+		p.advance()
+	}
+}
+
+func (p *parser) expect(r rune) error {
+	if p.peek(0) != r {
+		return p.errorf("expected %q, got %s", r, p.describe())
+	}
+	p.advance()
+	return nil
+}
+
+// endLine consumes the rest of the current line, which must be blank. A
+// closing brace or the end of the input also ends the line.
+func (p *parser) endLine() error {
+	p.skipSpace()
+	switch {
+	case p.eof() || p.peek(0) == '}':
+		return nil
+	case p.peek(0) == '\n':
+		p.advance()
+		return nil
+	default:
+		return p.errorf("unexpected %s, expected end of line", p.describe())
+	}
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (p *parser) readIdent() string {
+	start := p.pos
+	for !p.eof() && isIdentRune(p.peek(0)) {
+		p.advance()
+	}
+	return string(p.input[start:p.pos])
+}
+
+// acceptKeyword consumes keyword if it is the next word, ignoring case.
+func (p *parser) acceptKeyword(keyword string) bool {
+	n := len([]rune(keyword))
+	if p.pos+n > len(p.input) || !strings.EqualFold(string(p.input[p.pos:p.pos+n]), keyword) || isIdentRune(p.peek(n)) {
+		return false
+	}
+	for range n {
+		p.advance()
+	}
+	return true
+}
+
+// readName reads an identifier or a double-quoted name.
+func (p *parser) readName(what string) (string, error) {
+	if p.peek(0) == '"' {
+		return p.readString()
+	}
+	name := p.readIdent()
+	if name == "" {
+		return "", p.errorf("expected %s, got %s", what, p.describe())
+	}
+	return name, nil
+}
+
+// readQualifiedName reads name or schema.name.
+func (p *parser) readQualifiedName(what string) (schema, name string, err error) {
+	name, err = p.readName(what)
+	if err != nil {
+		return "", "", err
+	}
+	if p.peek(0) != '.' {
+		return p.schema, name, nil
+	}
+	p.advance()
+	schema = name
+	name, err = p.readName(what)
+	return schema, name, err
+}
+
+// readString reads a single-, triple- or double-quoted string. Quote
+// characters escaped with a backslash, as Generate writes them, are the only
+// escapes in single- and triple-quoted strings; double-quoted strings use Go
+// escapes.
+func (p *parser) readString() (string, error) {
+	line, col := p.line, p.col
+	unterminated := &ParseError{Line: line, Column: col, Message: "unterminated string"}
+
+	switch {
+	case p.peek(0) == '\'' && p.peek(1) == '\'' && p.peek(2) == '\'':
+		p.advance()
+		p.advance()
+		p.advance()
+		var b strings.Builder
+		for {
+			switch {
+			case p.eof():
+				return "", unterminated
+			case p.peek(0) == '\\' && p.peek(1) == '\'':
+				p.advance()
+				b.WriteRune(p.advance())
+			case p.peek(0) == '\'' && p.peek(1) == '\'' && p.peek(2) == '\'':
+				p.advance()
+				p.advance()
+				p.advance()
+				return b.String(), nil
+			default:
+				b.WriteRune(p.advance())
+			}
+		}
+	case p.peek(0) == '\'':
+		p.advance()
+		var b strings.Builder
+		for {
+			switch {
+			case p.eof():
+				return "", unterminated
+			case p.peek(0) == '\\' && p.peek(1) == '\'':
+				p.advance()
+				b.WriteRune(p.advance())
+			case p.peek(0) == '\'':
+				p.advance()
+				return b.String(), nil
+			default:
+				b.WriteRune(p.advance())
+			}
+		}
+	case p.peek(0) == '"':
+		start := p.pos
+		p.advance()
+		for p.peek(0) != '"' {
+			if p.eof() || p.peek(0) == '\n' {
+				return "", unterminated
+			}
+			if p.advance() == '\\' && !p.eof() {
+				p.advance()
+			}
+		}
+		p.advance()
+		s, err := strconv.Unquote(string(p.input[start:p.pos]))
+		if err != nil {
+			return "", &ParseError{Line: line, Column: col, Message: "invalid string: " + err.Error()}
+		}
+		return s, nil
+	default:
+		return "", p.errorf("expected string, got %s", p.describe())
+	}
+}
+
+// readExpression reads a backtick expression.
+func (p *parser) readExpression() (string, error) {
+	line, col := p.line, p.col
+	if err := p.expect('`'); err != nil {
+		return "", err
+	}
+	start := p.pos
+	for p.peek(0) != '`' {
+		if p.eof() {
+			return "", &ParseError{Line: line, Column: col, Message: "unterminated expression"}
+		}
+		p.advance()
+	}
+	expr := string(p.input[start:p.pos])
+	p.advance()
+	return expr, nil
+}
+
+// readNote reads the value of a Note, either `Note: 'text'` or
+// `Note { 'text' }`, with the Note keyword already consumed.
+func (p *parser) readNote() (string, error) {
+	p.skipSpace()
+	if p.peek(0) == '{' {
+		p.advance()
+		p.skipBlank()
+		note, err := p.readString()
+		if err != nil {
+			return "", err
+		}
+		p.skipBlank()
+		return note, p.expect('}')
+	}
+	if err := p.expect(':'); err != nil {
+		return "", err
+	}
+	p.skipSpace()
+	note, err := p.readString()
+	if err != nil {
+		return "", err
+	}
+	return note, p.endLine()
+}
+
+// readSettings reads a [key: value, ...] list.
+func (p *parser) readSettings() ([]setting, error) {
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+
+	settings := []setting{}
+	for {
+		p.skipBlank()
+		s := setting{line: p.line, col: p.col}
+
+		var key strings.Builder
+		for !p.eof() && !strings.ContainsRune(":,]\n", p.peek(0)) {
+			key.WriteRune(p.advance())
+		}
+		s.key = strings.ToLower(strings.Join(strings.Fields(key.String()), " "))
+		if s.key == "" {
+			return nil, p.errorf("expected setting, got %s", p.describe())
+		}
+
+		p.skipBlank()
+		if p.peek(0) == ':' {
+			p.advance()
+			p.skipBlank()
+			var err error
+			switch p.peek(0) {
+			case '\'', '"':
+				s.kind = settingString
+				s.value, err = p.readString()
+			case '`':
+				s.kind = settingExpression
+				s.value, err = p.readExpression()
+			default:
+				s.kind = settingRaw
+				s.value = p.readRawValue()
+			}
+			if err != nil {
+				return nil, err
+			}
+			if s.value == "" && s.kind == settingRaw {
+				return nil, s.errorf("setting %s has no value", s.key)
+			}
+		}
+		settings = append(settings, s)
+
+		p.skipBlank()
+		switch p.peek(0) {
+		case ',':
+			p.advance()
+		case ']':
+			p.advance()
+			return settings, nil
+		default:
+			return nil, p.errorf("expected ',' or ']', got %s", p.describe())
+		}
+	}
+}
+
+// readRawValue reads an unquoted setting value up to a comma or closing
+// bracket outside parentheses.
+func (p *parser) readRawValue() string {
+	start := p.pos
+	depth := 0
+	for !p.eof() && p.peek(0) != '\n' {
+		r := p.peek(0)
+		if depth == 0 && (r == ',' || r == ']') {
+			break
+		}
+		if r == '(' {
+			depth++
+		} else if r == ')' && depth > 0 {
+			depth--
+		}
+		p.advance()
+	}
+	return strings.TrimSpace(string(p.input[start:p.pos]))
+}
+
+func (p *parser) parseProject(project *Project) error {
+	seenProject := false
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		line, col := p.line, p.col
+		keyword := p.readIdent()
+		p.skipSpace()
+
+		var err error
+		switch strings.ToLower(keyword) {
+		case "project":
+			if seenProject {
+				return &ParseError{Line: line, Column: col, Message: "duplicate Project definition"}
+			}
+			seenProject = true
+			err = p.parseProjectDefinition(project)
+		case "schema":
+			err = p.parseSchema(project)
+		case "table":
+			err = p.parseTable(project)
+		case "enum":
+			err = p.parseEnum(project)
+		case "ref":
+			err = p.parseRef(project)
+		case "tablegroup":
+			err = p.parseTableGroup(project)
+		default:
+			return &ParseError{Line: line, Column: col,
+				Message: "expected Project, Schema, Table, Enum, Ref or TableGroup, got " + p.describeWord(keyword)}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// describeWord names a word read at the current position for error
+// messages, falling back to the next rune when the word is empty.
+func (p *parser) describeWord(word string) string {
+	if word == "" {
+		return p.describe()
+	}
+	return strconv.Quote(word)
+}
+
+func (p *parser) parseProjectDefinition(project *Project) error {
+	// Generate writes the project name as-is, so read it up to the brace.
+	if p.peek(0) == '"' {
+		name, err := p.readString()
+		if err != nil {
+			return err
+		}
+		project.Name = name
+	} else {
+		start := p.pos
+		for !p.eof() && p.peek(0) != '{' && p.peek(0) != '\n' {
+			p.advance()
+		}
+		project.Name = strings.TrimSpace(string(p.input[start:p.pos]))
+	}
+	p.skipSpace()
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipBlank()
+		if p.peek(0) == '}' {
+			p.advance()
+			return p.endLine()
+		}
+
+		line, col := p.line, p.col
+		key := p.readIdent()
+		switch strings.ToLower(key) {
+		case "database_type":
+			p.skipSpace()
+			if err := p.expect(':'); err != nil {
+				return err
+			}
+			p.skipSpace()
+			dbType, err := p.readString()
+			if err != nil {
+				return err
+			}
+			project.DatabaseType = &dbType
+			if err := p.endLine(); err != nil {
+				return err
+			}
+		case "note":
+			note, err := p.readNote()
+			if err != nil {
+				return err
+			}
+			project.Note = &note
+		default:
+			return &ParseError{Line: line, Column: col, Message: "unknown project setting " + p.describeWord(key)}
+		}
+	}
+}
+
+func (p *parser) parseSchema(project *Project) error {
+	name, err := p.readName("schema name")
+	if err != nil {
+		return err
+	}
+	schema := NewSchema(name)
+	p.skipSpace()
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipBlank()
+		if p.peek(0) == '}' {
+			p.advance()
+			project.AddSchema(schema)
+			return p.endLine()
+		}
+
+		line, col := p.line, p.col
+		key := p.readIdent()
+		switch strings.ToLower(key) {
+		case "owner":
+			p.skipSpace()
+			if err := p.expect(':'); err != nil {
+				return err
+			}
+			p.skipSpace()
+			owner, err := p.readString()
+			if err != nil {
+				return err
+			}
+			schema.Owner = &owner
+		case "search_path":
+			p.skipSpace()
+			if err := p.expect(':'); err != nil {
+				return err
+			}
+			p.skipSpace()
+			valueLine, valueCol := p.line, p.col
+			value, err := strconv.ParseBool(p.readIdent())
+			if err != nil {
+				return &ParseError{Line: valueLine, Column: valueCol, Message: "search_path must be true or false"}
+			}
+			schema.SearchPath = value
+		case "note":
+			note, err := p.readNote()
+			if err != nil {
+				return err
+			}
+			schema.Comment = &note
+			continue
+		default:
+			return &ParseError{Line: line, Column: col, Message: "unknown schema setting " + p.describeWord(key)}
+		}
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// postgresStorageParams are the table settings parsed into
+// Table.StorageParams rather than Table.Settings, since Generate writes
+// both the same way.
+var postgresStorageParams = map[string]bool{
+	"fillfactor":                  true,
+	"parallel_workers":            true,
+	"toast_tuple_target":          true,
+	"user_catalog_table":          true,
+	"vacuum_index_cleanup":        true,
+	"vacuum_truncate":             true,
+	"log_autovacuum_min_duration": true,
+}
+
+func isStorageParam(key string) bool {
+	return postgresStorageParams[key] || strings.HasPrefix(key, "autovacuum_") || strings.HasPrefix(key, "toast.")
+}
+
+func (p *parser) parseTable(project *Project) error {
+	line, col := p.line, p.col
+	schema, name, err := p.readQualifiedName("table name")
+	if err != nil {
+		return err
+	}
+	table := NewTable(name).WithSchema(schema)
+	key := schema + "." + name
+	if _, ok := project.Tables[key]; ok {
+		return &ParseError{Line: line, Column: col, Message: "duplicate table " + key}
+	}
+
+	p.skipSpace()
+	if p.acceptKeyword("as") {
+		p.skipSpace()
+		alias, err := p.readName("table alias")
+		if err != nil {
+			return err
+		}
+		table.WithAlias(alias)
+		p.skipSpace()
+	}
+
+	if p.peek(0) == '[' {
+		settings, err := p.readSettings()
+		if err != nil {
+			return err
+		}
+		if err := applyTableSettings(table, settings); err != nil {
+			return err
+		}
+		p.skipSpace()
+	}
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipBlank()
+		switch {
+		case p.eof():
+			return p.errorf("expected '}' to close table %s", key)
+		case p.peek(0) == '}':
+			p.advance()
+			project.AddTable(table)
+			project.TableOrder = append(project.TableOrder, key)
+			return p.endLine()
+		}
+
+		start := p.pos
+		startLine, startCol := p.line, p.col
+		word := p.readIdent()
+		p.skipSpace()
+		switch {
+		case strings.EqualFold(word, "indexes") && p.peek(0) == '{':
+			if err := p.parseIndexes(table); err != nil {
+				return err
+			}
+			continue
+		case strings.EqualFold(word, "note") && (p.peek(0) == ':' || p.peek(0) == '{'):
+			note, err := p.readNote()
+			if err != nil {
+				return err
+			}
+			table.Note = &note
+			continue
+		}
+
+		// Not a keyword: rewind and read a column.
+		p.pos, p.line, p.col = start, startLine, startCol
+		column, err := p.parseColumn()
+		if err != nil {
+			return err
+		}
+		table.AddColumn(column)
+	}
+}
+
+func applyTableSettings(table *Table, settings []setting) error {
+	for _, s := range settings {
+		switch {
+		case s.kind == settingNone:
+			return s.errorf("table setting %s has no value", s.key)
+		case s.key == "note":
+			note := s.value
+			table.Note = &note
+		case s.key == "rls":
+			switch strings.ToLower(s.value) {
+			case "enabled":
+				table.WithRowLevelSecurity(true)
+			case "disabled":
+				table.WithRowLevelSecurity(false)
+			default:
+				return s.errorf("rls must be enabled or disabled, got %q", s.value)
+			}
+		case isStorageParam(s.key):
+			table.WithStorageParameter(s.key, s.value)
+		default:
+			table.WithSetting(s.key, s.value)
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseColumn() (*Column, error) {
+	name, err := p.readName("column name")
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+
+	colType, err := p.readColumnType()
+	if err != nil {
+		return nil, err
+	}
+	column := NewColumn(name, colType)
+	column.Settings.Null = true
+
+	p.skipSpace()
+	if p.peek(0) == '[' {
+		settings, err := p.readSettings()
+		if err != nil {
+			return nil, err
+		}
+		if err := applyColumnSettings(column, settings, p.schema); err != nil {
+			return nil, err
+		}
+	}
+
+	return column, p.endLine()
+}
+
+// readColumnType reads a column type, which may contain spaces, arguments
+// and array brackets, e.g. `timestamp with time zone` or `varchar(64)[]`,
+// up to the settings list or the end of the line.
+func (p *parser) readColumnType() (string, error) {
+	if p.peek(0) == '"' {
+		return p.readString()
+	}
+
+	start := p.pos
+	depth := 0
+loop:
+	for !p.eof() {
+		switch r := p.peek(0); {
+		case r == '\n', depth == 0 && r == '}', r == '/' && (p.peek(1) == '/' || p.peek(1) == '*'):
+			break loop
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case r == '[' && depth == 0:
+			if p.peek(1) != ']' {
+				break loop
+			}
+			p.advance()
+		}
+		p.advance()
+	}
+
+	colType := strings.TrimSpace(string(p.input[start:p.pos]))
+	if colType == "" {
+		return "", p.errorf("expected column type, got %s", p.describe())
+	}
+	return colType, nil
+}
+
+func applyColumnSettings(column *Column, settings []setting, defaultSchema string) error {
+	for _, s := range settings {
+		switch s.key {
+		case "pk", "primary key":
+			column.Settings.PrimaryKey = true
+		case "unique":
+			column.Settings.Unique = true
+		case "not null":
+			column.Settings.Null = false
+		case "null":
+			column.Settings.Null = true
+		case "increment":
+			column.Settings.Increment = true
+		case "default":
+			if err := applyColumnDefault(column, s); err != nil {
+				return err
+			}
+		case "check":
+			if s.kind != settingString && s.kind != settingExpression {
+				return s.errorf("check must be a string or expression")
+			}
+			column.WithCheck(s.value)
+		case "computed":
+			if s.kind != settingString && s.kind != settingExpression {
+				return s.errorf("computed must be a string or expression")
+			}
+			column.WithComputedAlias(s.value)
+		case "note":
+			if s.kind != settingString {
+				return s.errorf("note must be a string")
+			}
+			column.WithNote(s.value)
+		case "ref":
+			ref, err := parseInlineRef(s, defaultSchema)
+			if err != nil {
+				return err
+			}
+			column.InlineRef = ref
+		default:
+			return s.errorf("unknown column setting %q", s.key)
+		}
+	}
+	return nil
+}
+
+// applyColumnDefault sets the default with the kind it was written as.
+// Quoted strings are stored SQL-quoted, as WithDefault expects; bare values
+// other than numbers, booleans and null keep an empty kind.
+func applyColumnDefault(column *Column, s setting) error {
+	switch s.kind {
+	case settingExpression:
+		column.WithDefaultKind(s.value, DefaultKindExpression)
+	case settingString:
+		column.WithDefaultKind(quoteSQLString(s.value), DefaultKindString)
+	case settingRaw:
+		kind := detectDefaultKind(s.value)
+		if kind == DefaultKindExpression || kind == DefaultKindString {
+			kind = ""
+		}
+		column.WithDefaultKind(s.value, kind)
+	default:
+		return s.errorf("default has no value")
+	}
+	return nil
+}
+
+// parseInlineRef parses the value of a ref setting, e.g. `> users.id`.
+func parseInlineRef(s setting, defaultSchema string) (*InlineRef, error) {
+	relType, target, ok := splitRelType(s.value)
+	if !ok {
+		return nil, s.errorf("ref must start with <, >, - or <>, got %q", s.value)
+	}
+
+	parts := strings.Split(strings.TrimSpace(target), ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"`)
+	}
+	switch len(parts) {
+	case 2:
+		return &InlineRef{Type: relType, Schema: defaultSchema, Table: parts[0], Column: parts[1]}, nil
+	case 3:
+		return &InlineRef{Type: relType, Schema: parts[0], Table: parts[1], Column: parts[2]}, nil
+	default:
+		return nil, s.errorf("ref target must be table.column or schema.table.column, got %q", target)
+	}
+}
+
+// splitRelType splits a leading relationship operator off value.
+func splitRelType(value string) (RelType, string, bool) {
+	value = strings.TrimSpace(value)
+	for _, relType := range []RelType{ManyToMany, OneToMany, ManyToOne, OneToOne} {
+		if rest, ok := strings.CutPrefix(value, string(relType)); ok {
+			return relType, rest, true
+		}
+	}
+	return "", "", false
+}
+
+func (p *parser) parseIndexes(table *Table) error {
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipBlank()
+		switch {
+		case p.eof():
+			return p.errorf("expected '}' to close indexes")
+		case p.peek(0) == '}':
+			p.advance()
+			return p.endLine()
+		}
+
+		index := &Index{}
+		if p.peek(0) == '(' {
+			p.advance()
+			for {
+				p.skipBlank()
+				column, err := p.readIndexColumn()
+				if err != nil {
+					return err
+				}
+				index.Columns = append(index.Columns, column)
+				p.skipBlank()
+				if p.peek(0) == ')' {
+					p.advance()
+					break
+				}
+				if err := p.expect(','); err != nil {
+					return err
+				}
+			}
+		} else {
+			column, err := p.readIndexColumn()
+			if err != nil {
+				return err
+			}
+			index.Columns = append(index.Columns, column)
+		}
+
+		p.skipSpace()
+		if p.peek(0) == '[' {
+			settings, err := p.readSettings()
+			if err != nil {
+				return err
+			}
+			if err := applyIndexSettings(index, settings); err != nil {
+				return err
+			}
+		}
+		table.AddIndex(index)
+
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) readIndexColumn() (IndexColumn, error) {
+	if p.peek(0) == '`' {
+		expr, err := p.readExpression()
+		return IndexColumn{Expression: &expr}, err
+	}
+	name, err := p.readName("index column")
+	return IndexColumn{Name: &name}, err
+}
+
+func applyIndexSettings(index *Index, settings []setting) error {
+	for _, s := range settings {
+		switch s.key {
+		case "pk", "primary key":
+			index.PrimaryKey = true
+		case "unique":
+			index.Unique = true
+		case "type":
+			if s.kind == settingNone {
+				return s.errorf("type has no value")
+			}
+			index.WithType(s.value)
+		case "name":
+			if s.kind == settingNone {
+				return s.errorf("name has no value")
+			}
+			index.WithName(s.value)
+		case "note":
+			if s.kind != settingString {
+				return s.errorf("note must be a string")
+			}
+			index.WithNote(s.value)
+		default:
+			return s.errorf("unknown index setting %q", s.key)
+		}
+	}
+	return nil
+}
+
+// parseRef parses a ref in block form, `Ref name [settings] { a.id < b.a_id }`,
+// or one-line form, `Ref name: a.id < b.a_id [settings]`.
+func (p *parser) parseRef(project *Project) error {
+	ref := &Ref{}
+	if p.peek(0) != ':' && p.peek(0) != '{' && p.peek(0) != '[' {
+		name, err := p.readName("ref name")
+		if err != nil {
+			return err
+		}
+		ref.Name = &name
+		p.skipSpace()
+	}
+
+	if p.peek(0) == ':' {
+		p.advance()
+		p.skipSpace()
+		if err := p.parseRefLine(ref); err != nil {
+			return err
+		}
+		project.AddRef(ref)
+		return p.endLine()
+	}
+
+	if p.peek(0) == '[' {
+		if err := p.parseRefSettings(ref); err != nil {
+			return err
+		}
+		p.skipSpace()
+	}
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+	p.skipBlank()
+	if err := p.parseRefLine(ref); err != nil {
+		return err
+	}
+	p.skipBlank()
+	if err := p.expect('}'); err != nil {
+		return err
+	}
+	project.AddRef(ref)
+	return p.endLine()
+}
+
+// parseRefLine parses `left op right`, optionally followed by settings.
+func (p *parser) parseRefLine(ref *Ref) error {
+	var err error
+	if ref.Left, err = p.readRefEndpoint(); err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	opLine, opCol := p.line, p.col
+	var op strings.Builder
+	for strings.ContainsRune("<>-", p.peek(0)) && !p.eof() {
+		op.WriteRune(p.advance())
+	}
+	relType, rest, ok := splitRelType(op.String())
+	if !ok || rest != "" {
+		return &ParseError{Line: opLine, Column: opCol, Message: "expected relationship <, >, - or <>, got " + p.describeWord(op.String())}
+	}
+	ref.Type = relType
+
+	p.skipSpace()
+	if ref.Right, err = p.readRefEndpoint(); err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if p.peek(0) == '[' {
+		return p.parseRefSettings(ref)
+	}
+	return nil
+}
+
+// readRefEndpoint reads table.column, schema.table.column or a composite
+// endpoint such as table.(a, b).
+func (p *parser) readRefEndpoint() (*RefEndpoint, error) {
+	line, col := p.line, p.col
+	parts := []string{}
+	var columns []string
+
+	for {
+		if p.peek(0) == '(' {
+			p.advance()
+			for {
+				p.skipSpace()
+				name, err := p.readName("column name")
+				if err != nil {
+					return nil, err
+				}
+				columns = append(columns, name)
+				p.skipSpace()
+				if p.peek(0) == ')' {
+					p.advance()
+					break
+				}
+				if err := p.expect(','); err != nil {
+					return nil, err
+				}
+			}
+			break
+		}
+
+		name, err := p.readName("ref endpoint")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, name)
+		if p.peek(0) != '.' {
+			break
+		}
+		p.advance()
+	}
+
+	if columns == nil && len(parts) > 0 {
+		columns = []string{parts[len(parts)-1]}
+		parts = parts[:len(parts)-1]
+	}
+	switch len(parts) {
+	case 1:
+		return &RefEndpoint{Schema: p.schema, Table: parts[0], Columns: columns}, nil
+	case 2:
+		return &RefEndpoint{Schema: parts[0], Table: parts[1], Columns: columns}, nil
+	default:
+		return nil, &ParseError{Line: line, Column: col, Message: "ref endpoint must be table.column or schema.table.column"}
+	}
+}
+
+func (p *parser) parseRefSettings(ref *Ref) error {
+	settings, err := p.readSettings()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range settings {
+		switch s.key {
+		case "delete", "update":
+			action, err := parseRefAction(s)
+			if err != nil {
+				return err
+			}
+			if s.key == "delete" {
+				ref.OnDelete = &action
+			} else {
+				ref.OnUpdate = &action
+			}
+		case "color":
+			if s.kind == settingNone {
+				return s.errorf("color has no value")
+			}
+			ref.WithColor(s.value)
+		default:
+			return s.errorf("unknown ref setting %q", s.key)
+		}
+	}
+	return nil
+}
+
+func parseRefAction(s setting) (RefAction, error) {
+	value := strings.ToLower(strings.Join(strings.Fields(s.value), " "))
+	for _, action := range []RefAction{Cascade, Restrict, SetNull, SetDefault, NoAction} {
+		if value == string(action) {
+			return action, nil
+		}
+	}
+	return "", s.errorf("unknown referential action %q", s.value)
+}
+
+func (p *parser) parseEnum(project *Project) error {
+	line, col := p.line, p.col
+	schema, name, err := p.readQualifiedName("enum name")
+	if err != nil {
+		return err
+	}
+	enum := NewEnum(name).WithSchema(schema)
+	key := schema + "." + name
+	if _, ok := project.Enums[key]; ok {
+		return &ParseError{Line: line, Column: col, Message: "duplicate enum " + key}
+	}
+
+	p.skipSpace()
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipBlank()
+		switch {
+		case p.eof():
+			return p.errorf("expected '}' to close enum %s", key)
+		case p.peek(0) == '}':
+			p.advance()
+			project.AddEnum(enum)
+			return p.endLine()
+		}
+
+		var value string
+		if p.peek(0) == '"' {
+			if value, err = p.readString(); err != nil {
+				return err
+			}
+		} else {
+			start := p.pos
+			for !p.eof() && !unicode.IsSpace(p.peek(0)) && !strings.ContainsRune("[]{}:,", p.peek(0)) {
+				p.advance()
+			}
+			value = string(p.input[start:p.pos])
+			p.skipSpace()
+			if strings.EqualFold(value, "note") && (p.peek(0) == ':' || p.peek(0) == '{') {
+				note, err := p.readNote()
+				if err != nil {
+					return err
+				}
+				enum.WithNote(note)
+				continue
+			}
+			if value == "" {
+				return p.errorf("expected enum value, got %s", p.describe())
+			}
+		}
+		enum.Values = append(enum.Values, value)
+
+		p.skipSpace()
+		if p.peek(0) == '[' {
+			settings, err := p.readSettings()
+			if err != nil {
+				return err
+			}
+			for _, s := range settings {
+				if s.key != "note" || s.kind != settingString {
+					return s.errorf("enum values only support a note string, got %q", s.key)
+				}
+				enum.SetValueNote(value, s.value)
+			}
+		}
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) parseTableGroup(project *Project) error {
+	name, err := p.readName("table group name")
+	if err != nil {
+		return err
+	}
+	group := NewTableGroup(name)
+	p.skipSpace()
+	if err := p.expect('{'); err != nil {
+		return err
+	}
+
+	for {
+		p.skipBlank()
+		switch {
+		case p.eof():
+			return p.errorf("expected '}' to close table group %s", name)
+		case p.peek(0) == '}':
+			p.advance()
+			project.AddTableGroup(group)
+			return p.endLine()
+		}
+
+		schema, table, err := p.readQualifiedName("table name")
+		if err != nil {
+			return err
+		}
+		group.AddTable(schema, table)
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package dbml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func parseTestProject() *Project {
+	return NewProject("shop").
+		WithDatabaseType("PostgreSQL").
+		WithNote("Online shop").
+		DefineSchema("sales", WithOwner("sales_admin"), WithComment("Sales data"), WithSearchPath(true)).
+		AddEnum(NewEnum("order_status", "pending", "in transit", "delivered").
+			WithSchema("sales").
+			WithNote("Order lifecycle").
+			SetValueNote("pending", "Awaiting payment")).
+		AddTable(NewTable("users").
+			WithAlias("U").
+			WithHeaderColor("#3498db").
+			WithStorageParameter("fillfactor", "70").
+			WithRowLevelSecurity(true).
+			WithNote("Registered users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique().WithNote("Login, can't change")).
+			AddColumn(NewColumn("status", "varchar").WithDefault("'active'")).
+			AddColumn(NewColumn("created_at", "timestamp with time zone").WithDefault("now()")).
+			AddColumn(NewColumn("score", "decimal(10, 2)").WithNull().WithDefault("0").WithCheck("score >= 0")).
+			AddColumn(NewColumn("tags", "text[]").WithNull()).
+			AddColumn(NewColumn("email_lower", "text").WithComputedAlias("lower(email)")).
+			AddIndex(NewIndex("email").WithUnique().WithName("idx_users_email")).
+			AddIndex(NewExpressionIndex("lower(email)").WithType("btree").WithNote("Case-insensitive lookup"))).
+		AddTable(NewTable("orders").WithSchema("sales").
+			AddColumn(NewColumn("id", "bigint")).
+			AddColumn(NewColumn("line", "int")).
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("status", "sales.order_status").WithDefault("'pending'")).
+			AddIndex(NewIndex("id", "line").WithPrimaryKey())).
+		AddTable(NewTable("shipments").WithSchema("sales").
+			AddColumn(NewColumn("order_id", "bigint")).
+			AddColumn(NewColumn("order_line", "int"))).
+		AddRef(NewRef(ManyToOne).WithName("fk_shipments_orders").
+			From("sales", "shipments", "order_id", "order_line").
+			To("sales", "orders", "id", "line").
+			WithOnDelete(Cascade).WithOnUpdate(SetNull).WithColor("#aabbcc")).
+		AddRef(NewRef(OneToOne).From("public", "users", "id").To("sales", "orders", "user_id")).
+		AddTableGroup(NewTableGroup("sales").AddTable("sales", "orders").AddTable("sales", "shipments"))
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	project := parseTestProject()
+
+	tests := map[string]GenerateOptions{
+		"default":   {},
+		"v2":        {DBMLVersion: DBMLv2},
+		"shorthand": {UseShorthandRefs: true},
+		"compact":   {CompactSingleColumnIndexes: true, InlineTableNotes: true},
+	}
+
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			output := project.GenerateWithOptions(opts)
+			parsed, err := Parse(output)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v\n%s", err, output)
+			}
+			if !parsed.Equal(project) {
+				t.Errorf("Expected round trip to reproduce the project, got:\n%s\nfrom:\n%s", parsed.Generate(), output)
+			}
+		})
+	}
+}
+
+func TestParse_MultiLineNoteV2(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			WithNote("First line\nSecond 'quoted' line").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	parsed, err := Parse(project.GenerateDBMLv2())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if note := parsed.Tables["public.users"].Note; note == nil || *note != "First line\nSecond 'quoted' line" {
+		t.Errorf("Expected multi-line note to survive, got %v", note)
+	}
+}
+
+func TestParse_HandWritten(t *testing.T) {
+	input := `// Blog schema
+Table posts {
+  id int [pk] /* surrogate key */
+  author_id int [not null, ref: > authors.id]
+  "title text" varchar
+  body text [note: 'Markdown']
+
+  indexes {
+    (author_id, id) [unique]
+  }
+}
+
+Table authors {
+  id int [primary key]
+}
+
+Ref: posts.id - authors.id [delete: no action]
+`
+
+	project, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	posts := project.Tables["public.posts"]
+	if posts == nil || len(posts.Columns) != 4 {
+		t.Fatalf("Expected posts with 4 columns, got %+v", posts)
+	}
+	if !posts.Columns[0].Settings.PrimaryKey || !posts.Columns[0].Settings.Null {
+		t.Errorf("Expected nullable pk id, got %+v", posts.Columns[0].Settings)
+	}
+	if ref := posts.Columns[1].InlineRef; ref == nil || ref.Type != ManyToOne || ref.Schema != "public" || ref.Table != "authors" {
+		t.Errorf("Expected inline ref to public.authors, got %+v", ref)
+	}
+	if posts.Columns[2].Name != "title text" {
+		t.Errorf("Expected quoted column name, got %s", posts.Columns[2].Name)
+	}
+	if !posts.Indexes[0].Unique || len(posts.Indexes[0].Columns) != 2 {
+		t.Errorf("Expected composite unique index, got %+v", posts.Indexes[0])
+	}
+	if len(project.Refs) != 1 || project.Refs[0].OnDelete == nil || *project.Refs[0].OnDelete != NoAction {
+		t.Errorf("Expected ref with delete: no action, got %+v", project.Refs)
+	}
+	if strings.Join(project.TableOrder, ",") != "public.posts,public.authors" {
+		t.Errorf("Expected table order from the input, got %v", project.TableOrder)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		line   int
+		column int
+	}{
+		"unknown keyword":      {"Tabel users {\n}\n", 1, 1},
+		"unknown setting":      {"Table users {\n  id int [primary]\n}\n", 2, 11},
+		"missing type":         {"Table users {\n  id\n}\n", 2, 5},
+		"unterminated string":  {"Table users {\n  id int [note: 'oops]\n}\n", 2, 17},
+		"bad relationship":     {"Ref: a.id = b.id\n", 1, 11},
+		"bad action":           {"Ref: a.id > b.id [delete: explode]\n", 1, 19},
+		"unclosed table":       {"Table users {\n  id int\n", 3, 1},
+		"duplicate table":      {"Table users {\n}\nTable users {\n}\n", 3, 7},
+		"trailing text":        {"Enum status {\n  a b\n}\n", 2, 5},
+		"bad schema qualifier": {"Ref: a.b.c.d > e.f\n", 1, 6},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected ParseError, got %v", err)
+			}
+			if parseErr.Line != tt.line || parseErr.Column != tt.column {
+				t.Errorf("Expected error at %d:%d, got %v", tt.line, tt.column, err)
+			}
+		})
+	}
+}
+
+func TestProject_RoundTripCheck(t *testing.T) {
+	if err := parseTestProject().RoundTripCheck(); err != nil {
+		t.Errorf("Expected round trip to pass, got %v", err)
+	}
+
+	quoted := NewProject("test").
+		AddTable(NewTable("notes").
+			AddColumn(NewColumn("body", "text").WithDefault("'it''s'")))
+	if err := quoted.RoundTripCheck(); err != nil {
+		t.Errorf("Expected a quote inside a string default to round trip, got %v", err)
+	}
+
+	parsed, err := Parse("Table notes {\n  body text [default: 'it\\'s']\n}\n")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sql := parsed.GeneratePostgresSQL(); !strings.Contains(sql, `DEFAULT 'it''s'`) {
+		t.Errorf("Expected parsed default to be SQL-quoted, got:\n%s", sql)
+	}
+
+	names := NewProject("test").
+		AddEnum(NewEnum("ratio", "a:b", "x,y", "in transit")).
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("posts").AddColumn(NewColumn("user_id", "int"))).
+		AddRef(NewRef(ManyToOne).WithName("my fk").From("public", "posts", "user_id").To("public", "users", "id"))
+	if err := names.RoundTripCheck(); err != nil {
+		t.Errorf("Expected enum values and ref names that are not identifiers to round trip, got %v\n%s", err, names.Generate())
+	}
+
+	project := parseTestProject()
+	project.Tables["public.users"].Columns[0].WithTypeAlias("int8")
+	if err := project.RoundTripCheck(); err == nil {
+		t.Error("Expected round trip to fail for a field DBML does not carry")
+	}
+}
@@ -0,0 +1,72 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PostgRESTOptions controls the grants emitted by GeneratePostgRESTWithOptions.
+type PostgRESTOptions struct {
+	// ReadOnlyTables are granted SELECT only. Entries are table names or
+	// schema-qualified names such as "audit.events".
+	ReadOnlyTables []string
+}
+
+// GeneratePostgREST generates the GRANT statements PostgREST needs to expose
+// every table to apiRole.
+func (p *Project) GeneratePostgREST(apiRole string) string {
+	return p.GeneratePostgRESTWithOptions(apiRole, PostgRESTOptions{})
+}
+
+// GeneratePostgRESTWithOptions generates PostgREST grants for apiRole: schema
+// usage, table privileges, and usage of the identity sequence PostgreSQL
+// creates for each increment column of a writable table, named
+// <table>_<column>_seq. Tables excluded from DDL are skipped.
+func (p *Project) GeneratePostgRESTWithOptions(apiRole string, opts PostgRESTOptions) string {
+	var b strings.Builder
+
+	role := pgQuoteIdent(apiRole)
+	tables := p.GetDDLTables()
+
+	schemas := map[string]bool{}
+	for _, table := range tables {
+		schemas[table.Schema] = true
+	}
+	for _, schema := range sortedKeys(schemas) {
+		b.WriteString(fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s;\n", pgQuoteIdent(schema), role))
+	}
+	if len(schemas) > 0 {
+		b.WriteString("\n")
+	}
+
+	sequences := []string{}
+	for _, table := range tables {
+		key := table.Schema + "." + table.Name
+		readOnly := slices.Contains(opts.ReadOnlyTables, key) || slices.Contains(opts.ReadOnlyTables, table.Name)
+
+		privileges := "SELECT, INSERT, UPDATE, DELETE"
+		if readOnly {
+			privileges = "SELECT"
+		}
+		b.WriteString(fmt.Sprintf("GRANT %s ON TABLE %s TO %s;\n", privileges, pgQualifiedName(table.Schema, table.Name), role))
+
+		if readOnly {
+			continue
+		}
+		for _, col := range table.Columns {
+			if col.Settings != nil && col.Settings.Increment {
+				sequences = append(sequences, pgQualifiedName(table.Schema, table.Name+"_"+col.Name+"_seq"))
+			}
+		}
+	}
+
+	if len(sequences) > 0 {
+		b.WriteString("\n")
+	}
+	for _, sequence := range sequences {
+		b.WriteString(fmt.Sprintf("GRANT USAGE ON SEQUENCE %s TO %s;\n", sequence, role))
+	}
+
+	return b.String()
+}
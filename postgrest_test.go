@@ -0,0 +1,48 @@
+package dbml
+
+import "testing"
+
+func TestProject_GeneratePostgREST(t *testing.T) {
+	project := NewProject("api").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement())).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement())).
+		AddTable(NewTable("flyway_schema_history").WithSchema("migrations").WithExcludeFromDDL().
+			AddColumn(NewColumn("installed_rank", "int").WithPrimaryKey().WithIncrement()))
+
+	output := project.GeneratePostgREST("web_anon")
+
+	expected := `GRANT USAGE ON SCHEMA "audit" TO "web_anon";
+GRANT USAGE ON SCHEMA "public" TO "web_anon";
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE "audit"."events" TO "web_anon";
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE "public"."users" TO "web_anon";
+
+GRANT USAGE ON SEQUENCE "audit"."events_id_seq" TO "web_anon";
+GRANT USAGE ON SEQUENCE "public"."users_id_seq" TO "web_anon";
+`
+	if output != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+	}
+}
+
+func TestProject_GeneratePostgRESTWithOptions_ReadOnly(t *testing.T) {
+	project := NewProject("api").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement())).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()))
+
+	output := project.GeneratePostgRESTWithOptions("api_user", PostgRESTOptions{ReadOnlyTables: []string{"audit.events", "users"}})
+
+	expected := `GRANT USAGE ON SCHEMA "audit" TO "api_user";
+GRANT USAGE ON SCHEMA "public" TO "api_user";
+
+GRANT SELECT ON TABLE "audit"."events" TO "api_user";
+GRANT SELECT ON TABLE "public"."users" TO "api_user";
+`
+	if output != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+	}
+}
@@ -0,0 +1,827 @@
+package dbml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// prismaProviders maps Project.DatabaseType values to Prisma datasource providers.
+var prismaProviders = map[string]string{
+	"postgres":   "postgresql",
+	"postgresql": "postgresql",
+	"mysql":      "mysql",
+	"sqlite":     "sqlite",
+	"sql server": "sqlserver",
+	"sqlserver":  "sqlserver",
+}
+
+// prismaField is one line of a Prisma model body.
+type prismaField struct {
+	name  string
+	typ   string
+	attrs []string
+}
+
+// GeneratePrismaSchema generates a Prisma schema file from a Project.
+// Each table becomes a model named after its singular table name and mapped
+// to the table with @@map; foreign keys become @relation fields on both sides.
+// Many-to-many refs have no foreign key and are not emitted.
+func (p *Project) GeneratePrismaSchema() string {
+	var b strings.Builder
+
+	provider := "postgresql"
+	if p.DatabaseType != nil {
+		if mapped, ok := prismaProviders[strings.ToLower(*p.DatabaseType)]; ok {
+			provider = mapped
+		}
+	}
+
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString("datasource db {\n")
+	b.WriteString(fmt.Sprintf("  provider = %q\n", provider))
+	b.WriteString("  url      = env(\"DATABASE_URL\")\n")
+	b.WriteString("}\n\n")
+	b.WriteString("generator client {\n")
+	b.WriteString("  provider = \"prisma-client-js\"\n")
+	b.WriteString("}\n")
+
+	for _, key := range sortedKeys(p.Enums) {
+		b.WriteString("\n")
+		b.WriteString(p.Enums[key].prismaEnum())
+	}
+
+	relations := p.prismaRelationFields()
+	for _, key := range sortedKeys(p.Tables) {
+		b.WriteString("\n")
+		b.WriteString(p.prismaModel(p.Tables[key], relations[key]))
+	}
+
+	return b.String()
+}
+
+func (e *Enum) prismaEnum() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("enum %s {\n", e.Name))
+	for _, value := range e.Values {
+		name := prismaIdentifier(value)
+		if name != value {
+			b.WriteString(fmt.Sprintf("  %s @map(%q)\n", name, value))
+		} else {
+			b.WriteString(fmt.Sprintf("  %s\n", name))
+		}
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (p *Project) prismaModel(t *Table, relations []prismaField) string {
+	var b strings.Builder
+
+	modelName := goStructName(t.Name)
+	pkColumns := t.primaryKeyColumns()
+
+	fields := make([]prismaField, 0, len(t.Columns)+len(relations))
+	for _, col := range t.Columns {
+		fields = append(fields, p.prismaColumnField(col, len(pkColumns) == 1 && pkColumns[0] == col.Name))
+	}
+	fields = append(fields, relations...)
+
+	nameWidth, typeWidth := 0, 0
+	for _, field := range fields {
+		nameWidth = max(nameWidth, len(field.name))
+		typeWidth = max(typeWidth, len(field.typ))
+	}
+
+	b.WriteString(fmt.Sprintf("model %s {\n", modelName))
+	for _, field := range fields {
+		if len(field.attrs) == 0 {
+			b.WriteString(fmt.Sprintf("  %-*s %s\n", nameWidth, field.name, field.typ))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %-*s %-*s %s\n", nameWidth, field.name, typeWidth, field.typ, strings.Join(field.attrs, " ")))
+	}
+
+	blockAttrs := []string{}
+	if len(pkColumns) > 1 {
+		blockAttrs = append(blockAttrs, fmt.Sprintf("@@id([%s])", strings.Join(pkColumns, ", ")))
+	}
+	for _, idx := range t.Indexes {
+		if idx.PrimaryKey {
+			continue
+		}
+		columns := []string{}
+		for _, col := range idx.Columns {
+			if col.Name != nil {
+				columns = append(columns, *col.Name)
+			}
+		}
+		if len(columns) != len(idx.Columns) {
+			continue // expression indexes have no Prisma equivalent
+		}
+		attr := "@@index"
+		if idx.Unique {
+			attr = "@@unique"
+		}
+		blockAttrs = append(blockAttrs, fmt.Sprintf("%s([%s])", attr, strings.Join(columns, ", ")))
+	}
+	if modelName != t.Name {
+		blockAttrs = append(blockAttrs, fmt.Sprintf("@@map(%q)", t.Name))
+	}
+	if len(blockAttrs) > 0 {
+		b.WriteString("\n")
+		for _, attr := range blockAttrs {
+			b.WriteString("  " + attr + "\n")
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (p *Project) prismaColumnField(c *Column, isID bool) prismaField {
+	field := prismaField{name: c.Name}
+
+	base, isArray := baseSQLType(c.Type)
+	enumName := ""
+	if enum := p.columnEnum(c); enum != nil {
+		enumName = enum.Name
+	}
+
+	switch {
+	case enumName != "":
+		field.typ = enumName
+	default:
+		field.typ = prismaScalarType(base)
+	}
+
+	switch {
+	case isArray:
+		field.typ += "[]"
+	case c.Settings != nil && c.Settings.Null:
+		field.typ += "?"
+	}
+
+	if isID {
+		field.attrs = append(field.attrs, "@id")
+	}
+	if c.Settings != nil {
+		if c.Settings.Unique && !isID {
+			field.attrs = append(field.attrs, "@unique")
+		}
+		if c.Settings.Increment {
+			field.attrs = append(field.attrs, "@default(autoincrement())")
+		} else if c.Settings.Default != nil {
+			field.attrs = append(field.attrs, "@default("+prismaDefault(sqlDefault(*c.Settings.Default, c.Settings.DefaultKind), enumName != "", c.Settings.DatabaseDefault)+")")
+		}
+	}
+	if base == "uuid" {
+		field.attrs = append(field.attrs, "@db.Uuid")
+	}
+
+	return field
+}
+
+// prismaRelationFields returns the relation fields each model gains from
+// foreign keys, keyed by table key.
+func (p *Project) prismaRelationFields() map[string][]prismaField {
+	fields := map[string][]prismaField{}
+	for _, rel := range p.ormRelations() {
+		childModel := goStructName(rel.child.Table)
+		parentModel := goStructName(rel.parent.Table)
+
+		// Prisma requires a relation name when two models are related more than once
+		relationName := ""
+		if rel.ambiguous {
+			relationName = rel.ref.foreignKeyName(rel.child)
+		}
+
+		args := []string{}
+		if relationName != "" {
+			args = append(args, strconv.Quote(relationName))
+		}
+		args = append(args,
+			fmt.Sprintf("fields: [%s]", strings.Join(rel.child.Columns, ", ")),
+			fmt.Sprintf("references: [%s]", strings.Join(rel.parent.Columns, ", ")))
+		if rel.ref.OnDelete != nil {
+			args = append(args, "onDelete: "+goExportedName(string(*rel.ref.OnDelete)))
+		}
+		if rel.ref.OnUpdate != nil {
+			args = append(args, "onUpdate: "+goExportedName(string(*rel.ref.OnUpdate)))
+		}
+
+		childType := parentModel
+		if rel.nullable {
+			childType += "?"
+		}
+		fields[rel.childKey] = append(fields[rel.childKey], prismaField{
+			name:  rel.field,
+			typ:   childType,
+			attrs: []string{fmt.Sprintf("@relation(%s)", strings.Join(args, ", "))},
+		})
+
+		backType := childModel + "[]"
+		if rel.ref.Type == OneToOne {
+			backType = childModel + "?"
+		}
+		back := prismaField{name: rel.backField, typ: backType}
+		if relationName != "" {
+			back.attrs = []string{fmt.Sprintf("@relation(%q)", relationName)}
+		}
+		fields[rel.parentKey] = append(fields[rel.parentKey], back)
+	}
+
+	return fields
+}
+
+func prismaScalarType(base string) string {
+	switch base {
+	case "bigint", "int8", "bigserial", "serial8":
+		return "BigInt"
+	case "int", "integer", "int4", "serial", "serial4", "smallint", "int2", "smallserial", "serial2",
+		"mediumint", "tinyint":
+		return "Int"
+	case "boolean", "bool":
+		return "Boolean"
+	case "real", "float4", "float", "double", "double precision", "float8":
+		return "Float"
+	case "numeric", "decimal", "money":
+		return "Decimal"
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone",
+		"date", "datetime", "time", "timetz":
+		return "DateTime"
+	case "json", "jsonb":
+		return "Json"
+	case "bytea", "blob", "binary", "varbinary":
+		return "Bytes"
+	default:
+		return "String"
+	}
+}
+
+// prismaDefault converts a DBML default value to a Prisma @default argument.
+func prismaDefault(value string, isEnum, databaseDefault bool) string {
+	lower := strings.ToLower(value)
+	switch {
+	case databaseDefault:
+		return fmt.Sprintf("dbgenerated(%q)", value)
+	case lower == "now()" || lower == "current_timestamp":
+		return "now()"
+	case value == "true" || value == "false" || isNumeric(value):
+		return value
+	case len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"):
+		unquoted := strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		if isEnum {
+			return prismaIdentifier(unquoted)
+		}
+		return strconv.Quote(unquoted)
+	default:
+		return fmt.Sprintf("dbgenerated(%q)", value)
+	}
+}
+
+// prismaIdentifier replaces characters that are not valid in Prisma
+// identifiers with underscores.
+func prismaIdentifier(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r)):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// prismaActions maps Prisma referential actions to RefActions.
+var prismaActions = map[string]RefAction{
+	"Cascade":    Cascade,
+	"Restrict":   Restrict,
+	"SetNull":    SetNull,
+	"SetDefault": SetDefault,
+	"NoAction":   NoAction,
+}
+
+// prismaBlock is a model or enum block read from a Prisma schema.
+type prismaBlock struct {
+	kind  string
+	name  string
+	lines []string
+}
+
+// prismaModelInfo records how a Prisma model maps onto a table.
+type prismaModelInfo struct {
+	table   *Table
+	columns map[string]string // field name -> column name
+}
+
+// prismaPendingRelation is a @relation field resolved once all models are known.
+type prismaPendingRelation struct {
+	args   map[string]string
+	child  string
+	parent string
+	unique bool
+}
+
+// FromPrismaSchema populates a Project from a Prisma schema. Models become
+// tables, enums become enums and @relation fields with fields/references
+// become refs. Prisma features without a DBML equivalent (composite types
+// and other blocks, @updatedAt, client-side defaults such as uuid() or
+// cuid()) are skipped and described in the returned warnings. Datasource
+// and generator blocks only configure Prisma and are skipped silently.
+func (p *Project) FromPrismaSchema(schema string) ([]string, error) {
+	blocks, err := parsePrismaBlocks(schema)
+	if err != nil {
+		return nil, err
+	}
+	if p.Tables == nil {
+		p.Tables = make(map[string]*Table)
+	}
+	if p.Enums == nil {
+		p.Enums = make(map[string]*Enum)
+	}
+
+	// Enums and model names must be known before field types can be resolved
+	enums := map[string]*Enum{}
+	models := map[string]*prismaModelInfo{}
+	warnings := []string{}
+	for _, block := range blocks {
+		switch block.kind {
+		case "enum":
+			enums[block.name] = prismaEnumFromBlock(block)
+		case "model":
+			models[block.name] = &prismaModelInfo{table: NewTable(block.name), columns: map[string]string{}}
+		case "datasource", "generator":
+		default:
+			warnings = append(warnings, fmt.Sprintf("prisma %s %s: skipped", block.kind, block.name))
+		}
+	}
+
+	var pending []prismaPendingRelation
+	for _, block := range blocks {
+		if block.kind != "model" {
+			continue
+		}
+		relations, skipped, err := p.prismaTableFromBlock(block, models, enums)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, relations...)
+		warnings = append(warnings, skipped...)
+	}
+
+	for _, enum := range enums {
+		p.AddEnum(enum)
+	}
+	for _, model := range models {
+		p.AddTable(model.table)
+	}
+
+	for _, rel := range pending {
+		ref, err := prismaRef(rel, models)
+		if err != nil {
+			return nil, err
+		}
+		p.AddRef(ref)
+	}
+
+	return warnings, nil
+}
+
+// parsePrismaBlocks splits a Prisma schema into blocks, dropping comments.
+// Block headers may omit
+// the space before the brace, and a block may be written on one line, as in
+// enum Role { ADMIN MEMBER }.
+func parsePrismaBlocks(schema string) ([]prismaBlock, error) {
+	var blocks []prismaBlock
+	var current *prismaBlock
+	start := 0
+
+	for i, line := range strings.Split(schema, "\n") {
+		line = strings.TrimSpace(stripPrismaComment(line))
+		if line == "" {
+			continue
+		}
+
+		if current == nil {
+			header, body, found := strings.Cut(line, "{")
+			fields := strings.Fields(header)
+			if !found || len(fields) != 2 {
+				return nil, fmt.Errorf("prisma line %d: expected block declaration, got %q", i+1, line)
+			}
+			current = &prismaBlock{kind: fields[0], name: fields[1]}
+			start = i + 1
+
+			body = strings.TrimSpace(body)
+			closed := strings.HasSuffix(body, "}")
+			body = strings.TrimSpace(strings.TrimSuffix(body, "}"))
+			if body != "" {
+				current.lines = append(current.lines, prismaInlineBlockLines(current.kind, body)...)
+			}
+			if !closed {
+				continue
+			}
+			line = "}"
+		}
+
+		if line == "}" {
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("prisma line %d: unterminated %s %s", start, current.kind, current.name)
+	}
+	return blocks, nil
+}
+
+// stripPrismaComment removes a // comment from a line, ignoring slashes
+// inside double-quoted strings such as URL defaults.
+func stripPrismaComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case inString && line[i] == '\\':
+			i++
+		case line[i] == '"':
+			inString = !inString
+		case !inString && strings.HasPrefix(line[i:], "//"):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// prismaInlineBlockLines splits the body of a one-line block into lines.
+// Enum values are separated by whitespace, with any attributes kept with
+// the value before them; other bodies are a single line.
+func prismaInlineBlockLines(kind, body string) []string {
+	if kind != "enum" {
+		return []string{body}
+	}
+	var lines []string
+	for _, field := range strings.Fields(body) {
+		if strings.HasPrefix(field, "@") && !strings.HasPrefix(field, "@@") && len(lines) > 0 {
+			lines[len(lines)-1] += " " + field
+			continue
+		}
+		lines = append(lines, field)
+	}
+	return lines
+}
+
+func prismaEnumFromBlock(block prismaBlock) *Enum {
+	enum := NewEnum(block.name)
+	for _, line := range block.lines {
+		if strings.HasPrefix(line, "@@") {
+			if name, ok := prismaStringArg(line, "@@map"); ok {
+				enum.Name = name
+			}
+			if schema, ok := prismaStringArg(line, "@@schema"); ok {
+				enum.Schema = schema
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		value := fields[0]
+		if mapped, ok := prismaStringArg(line, "@map"); ok {
+			value = mapped
+		}
+		enum.Values = append(enum.Values, value)
+	}
+	return enum
+}
+
+// prismaTableFromBlock fills in the table of a model block. It returns the
+// model's relations and warnings for the attributes it skipped.
+func (p *Project) prismaTableFromBlock(block prismaBlock, models map[string]*prismaModelInfo, enums map[string]*Enum) ([]prismaPendingRelation, []string, error) {
+	model := models[block.name]
+	table := model.table
+
+	var relations []prismaPendingRelation
+	var warnings []string
+	var blockAttrs []string
+	for _, line := range block.lines {
+		if strings.HasPrefix(line, "@@") {
+			blockAttrs = append(blockAttrs, line)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, nil, fmt.Errorf("prisma model %s: invalid field %q", block.name, line)
+		}
+		name, typ := fields[0], fields[1]
+		attrs := strings.TrimSpace(strings.TrimPrefix(line, name))
+		attrs = strings.TrimSpace(strings.TrimPrefix(attrs, typ))
+
+		optional, list := strings.HasSuffix(typ, "?"), strings.HasSuffix(typ, "[]")
+		typ = strings.TrimSuffix(strings.TrimSuffix(typ, "?"), "[]")
+
+		if _, ok := models[typ]; ok {
+			args, ok := prismaRelationArgs(attrs)
+			if !ok || args["fields"] == "" {
+				continue // back-relation; the ref comes from the other side
+			}
+			relations = append(relations, prismaPendingRelation{args: args, child: block.name, parent: typ})
+			continue
+		}
+
+		column := NewColumn(name, prismaSQLType(typ, attrs, enums))
+		if mapped, ok := prismaStringArg(attrs, "@map"); ok {
+			column.Name = mapped
+		}
+		model.columns[name] = column.Name
+		if list {
+			column.Type += "[]"
+		}
+		if optional {
+			column.WithNull()
+		}
+		if prismaHasAttr(attrs, "@id") {
+			column.WithPrimaryKey()
+		}
+		if prismaHasAttr(attrs, "@unique") {
+			column.WithUnique()
+		}
+		if value, ok := prismaCallArg(attrs, "@default"); ok && !prismaApplyDefault(column, value, enums[typ] != nil) {
+			warnings = append(warnings, fmt.Sprintf("prisma model %s: field %s: @default(%s) skipped", block.name, name, value))
+		}
+		if prismaHasAttr(attrs, "@updatedAt") {
+			warnings = append(warnings, fmt.Sprintf("prisma model %s: field %s: @updatedAt skipped", block.name, name))
+		}
+		table.AddColumn(column)
+	}
+
+	for _, attr := range blockAttrs {
+		switch {
+		case strings.HasPrefix(attr, "@@map("):
+			table.Name, _ = prismaStringArg(attr, "@@map")
+		case strings.HasPrefix(attr, "@@schema("):
+			table.Schema, _ = prismaStringArg(attr, "@@schema")
+		case strings.HasPrefix(attr, "@@id("), strings.HasPrefix(attr, "@@unique("), strings.HasPrefix(attr, "@@index("):
+			columns := model.columnNames(prismaListArg(attr))
+			if len(columns) == 0 {
+				continue
+			}
+			index := NewIndex(columns...)
+			switch {
+			case strings.HasPrefix(attr, "@@id("):
+				index.WithPrimaryKey()
+			case strings.HasPrefix(attr, "@@unique("):
+				index.WithUnique()
+			}
+			table.AddIndex(index)
+		}
+	}
+
+	// A relation over a single unique column is one-to-one
+	for i, rel := range relations {
+		columns := prismaSplitList(rel.args["fields"])
+		if len(columns) != 1 {
+			continue
+		}
+		if idx := table.GetColumnIndex(model.columns[columns[0]]); idx >= 0 {
+			settings := table.Columns[idx].Settings
+			relations[i].unique = settings.Unique || settings.PrimaryKey
+		}
+	}
+
+	return relations, warnings, nil
+}
+
+// columnNames maps Prisma field names to column names.
+func (m *prismaModelInfo) columnNames(fields []string) []string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field
+		if mapped, ok := m.columns[field]; ok {
+			columns[i] = mapped
+		}
+	}
+	return columns
+}
+
+func prismaRef(rel prismaPendingRelation, models map[string]*prismaModelInfo) (*Ref, error) {
+	child, parent := models[rel.child], models[rel.parent]
+
+	childColumns := child.columnNames(prismaSplitList(rel.args["fields"]))
+	parentColumns := parent.columnNames(prismaSplitList(rel.args["references"]))
+	if len(childColumns) != len(parentColumns) {
+		return nil, fmt.Errorf("prisma model %s: relation to %s has %d fields but %d references",
+			rel.child, rel.parent, len(childColumns), len(parentColumns))
+	}
+
+	relType := ManyToOne
+	if rel.unique {
+		relType = OneToOne
+	}
+	ref := NewRef(relType).
+		From(child.table.Schema, child.table.Name, childColumns...).
+		To(parent.table.Schema, parent.table.Name, parentColumns...)
+	if action, ok := prismaActions[rel.args["onDelete"]]; ok {
+		ref.WithOnDelete(action)
+	}
+	if action, ok := prismaActions[rel.args["onUpdate"]]; ok {
+		ref.WithOnUpdate(action)
+	}
+	return ref, nil
+}
+
+// prismaSQLType maps a Prisma field type to a SQL type, preferring the
+// native type given by a @db attribute.
+func prismaSQLType(typ, attrs string, enums map[string]*Enum) string {
+	if enum, ok := enums[typ]; ok {
+		return enum.Name
+	}
+	if strings.HasPrefix(typ, "Unsupported(") {
+		if native, ok := prismaStringArg(typ, "Unsupported"); ok {
+			return native
+		}
+	}
+	if idx := strings.Index(attrs, "@db."); idx >= 0 {
+		native := attrs[idx+len("@db."):]
+		end := strings.IndexFunc(native, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if end < 0 {
+			return strings.ToLower(native)
+		}
+		name := native[:end]
+		if args, ok := prismaCallArg(native, name); ok {
+			return strings.ToLower(name) + "(" + strings.ReplaceAll(args, " ", "") + ")"
+		}
+		return strings.ToLower(name)
+	}
+
+	switch typ {
+	case "String":
+		return "text"
+	case "Int":
+		return "integer"
+	case "BigInt":
+		return "bigint"
+	case "Boolean":
+		return "boolean"
+	case "Float":
+		return "double precision"
+	case "Decimal":
+		return "numeric"
+	case "DateTime":
+		return "timestamp"
+	case "Json":
+		return "jsonb"
+	case "Bytes":
+		return "bytea"
+	default:
+		return typ
+	}
+}
+
+// prismaApplyDefault converts a Prisma @default argument to a column
+// default. It reports false if the default has no SQL equivalent.
+func prismaApplyDefault(c *Column, value string, isEnum bool) bool {
+	switch {
+	case value == "autoincrement()":
+		c.WithIncrement()
+	case value == "now()":
+		c.WithDefault("now()")
+	case strings.HasPrefix(value, "dbgenerated("):
+		expr, ok := prismaStringArg(value, "dbgenerated")
+		if !ok {
+			return false
+		}
+		c.WithDefault(expr).WithDatabaseDefault()
+	case strings.HasSuffix(value, ")"):
+		// uuid(), cuid() and friends are generated by the Prisma client
+		return false
+	case value == "true" || value == "false" || isNumeric(value):
+		c.WithDefault(value)
+	case isEnum:
+		c.WithDefault("'" + value + "'")
+	default:
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return false
+		}
+		c.WithDefault("'" + strings.ReplaceAll(unquoted, "'", "''") + "'")
+	}
+	return true
+}
+
+// prismaHasAttr reports whether attrs contains the attribute name as a whole word.
+func prismaHasAttr(attrs, name string) bool {
+	for _, field := range strings.Fields(attrs) {
+		if field == name || strings.HasPrefix(field, name+"(") {
+			return true
+		}
+	}
+	return false
+}
+
+// prismaCallArg returns the raw argument of the first name(...) call in attrs.
+func prismaCallArg(attrs, name string) (string, bool) {
+	idx := strings.Index(attrs, name+"(")
+	if idx < 0 {
+		return "", false
+	}
+	rest := attrs[idx+len(name)+1:]
+
+	depth, inString := 1, false
+	for i, r := range rest {
+		switch {
+		case r == '"' && (i == 0 || rest[i-1] != '\\'):
+			inString = !inString
+		case inString:
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(rest[:i]), true
+			}
+		}
+	}
+	return "", false
+}
+
+// prismaStringArg returns the unquoted string argument of name("...").
+func prismaStringArg(attrs, name string) (string, bool) {
+	arg, ok := prismaCallArg(attrs, name)
+	if !ok {
+		return "", false
+	}
+	if idx := strings.Index(arg, ","); idx >= 0 && !strings.HasPrefix(arg, "name:") {
+		arg = arg[:idx]
+	}
+	arg = strings.TrimSpace(strings.TrimPrefix(arg, "name:"))
+	value, err := strconv.Unquote(arg)
+	return value, err == nil
+}
+
+// prismaListArg returns the field names of a block attribute like @@index([a, b]).
+func prismaListArg(attr string) []string {
+	start, end := strings.Index(attr, "["), strings.Index(attr, "]")
+	if start < 0 || end < start {
+		return nil
+	}
+	return prismaSplitList(attr[start+1 : end])
+}
+
+// prismaSplitList splits a comma-separated field list, dropping sort and
+// length arguments such as title(sort: Desc).
+func prismaSplitList(list string) []string {
+	var names []string
+	for _, item := range strings.Split(strings.Trim(list, "[]"), ",") {
+		item = strings.TrimSpace(item)
+		if idx := strings.Index(item, "("); idx >= 0 {
+			item = item[:idx]
+		}
+		if item != "" {
+			names = append(names, item)
+		}
+	}
+	return names
+}
+
+// prismaRelationArgs parses the named arguments of a @relation attribute.
+func prismaRelationArgs(attrs string) (map[string]string, bool) {
+	raw, ok := prismaCallArg(attrs, "@relation")
+	if !ok {
+		return nil, false
+	}
+
+	args := map[string]string{}
+	var parts []string
+	depth, last := 0, 0
+	for i, r := range raw {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, raw[last:i])
+				last = i + 1
+			}
+		}
+	}
+	parts = append(parts, raw[last:])
+
+	for _, part := range parts {
+		key, value, found := strings.Cut(part, ":")
+		if !found {
+			continue // positional relation name
+		}
+		args[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return args, true
+}
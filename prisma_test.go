@@ -0,0 +1,350 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GeneratePrismaSchema(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published", "in review")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique()).
+			AddColumn(NewColumn("active", "boolean").WithDefault("true")).
+			AddColumn(NewColumn("created_at", "timestamp").WithDefault("now()"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "uuid").WithPrimaryKey().WithDefault("gen_random_uuid()").WithDatabaseDefault()).
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("status", "post_status").WithDefault("'draft'")).
+			AddColumn(NewColumn("title", "text").WithDefault("'Untitled'")).
+			AddColumn(NewColumn("body", "text").WithNull()).
+			AddIndex(NewIndex("user_id", "status")))
+
+	output := project.GeneratePrismaSchema()
+
+	expected := []string{
+		"datasource db {\n  provider = \"postgresql\"",
+		"enum post_status {\n  draft\n  published\n  in_review @map(\"in review\")\n}",
+		"model User {",
+		"  id         BigInt   @id @default(autoincrement())",
+		"  email      String   @unique",
+		"  active     Boolean  @default(true)",
+		"  created_at DateTime @default(now())",
+		"  posts      Post[]",
+		"  @@map(\"users\")",
+		"model Post {",
+		"@id @default(dbgenerated(\"gen_random_uuid()\")) @db.Uuid",
+		"status  post_status @default(draft)",
+		"@default(\"Untitled\")",
+		"body    String?",
+		"user    User        @relation(fields: [user_id], references: [id])",
+		"  @@index([user_id, status])",
+		"  @@map(\"posts\")",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestProject_GeneratePrismaSchema_Relations(t *testing.T) {
+	project := NewProject("org").
+		WithDatabaseType("MySQL").
+		AddTable(NewTable("employees").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("manager_id", "int").WithNull())).
+		AddTable(NewTable("memberships").
+			AddColumn(NewColumn("employee_id", "int")).
+			AddColumn(NewColumn("team_id", "int")).
+			AddIndex(NewIndex("employee_id", "team_id").WithPrimaryKey())).
+		AddRef(NewRef(ManyToOne).From("public", "employees", "manager_id").To("public", "employees", "id").WithOnDelete(SetNull)).
+		AddRef(NewRef(ManyToOne).From("public", "memberships", "employee_id").To("public", "employees", "id").WithOnDelete(Cascade))
+
+	output := project.GeneratePrismaSchema()
+
+	expected := []string{
+		`provider = "mysql"`,
+		`manager           Employee?    @relation("fk_employees_manager_id", fields: [manager_id], references: [id], onDelete: SetNull)`,
+		`employees_manager Employee[]   @relation("fk_employees_manager_id")`,
+		`memberships       Membership[]`,
+		`employee    Employee @relation(fields: [employee_id], references: [id], onDelete: Cascade)`,
+		`@@id([employee_id, team_id])`,
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestProject_FromPrismaSchema(t *testing.T) {
+	schema := `// Blog schema
+datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+}
+
+enum Role {
+  ADMIN
+  MEMBER @map("member")
+
+  @@map("user_role")
+}
+
+model User {
+  id        Int      @id @default(autoincrement())
+  email     String   @unique @db.VarChar(255)
+  role      Role     @default(MEMBER)
+  balance   Decimal  @default(0) @db.Decimal(10, 2)
+  createdAt DateTime @default(now()) @map("created_at")
+  updatedAt DateTime @updatedAt @map("updated_at")
+  posts     Post[]
+  profile   Profile?
+
+  @@map("users")
+}
+
+model Post {
+  id       String  @id @default(dbgenerated("gen_random_uuid()")) @db.Uuid
+  title    String  @default("Untitled")
+  authorId Int     @map("author_id")
+  author   User    @relation(fields: [authorId], references: [id], onDelete: Cascade)
+  tags     String[]
+
+  @@index([authorId, title(sort: Desc)])
+  @@map("posts")
+}
+
+model Profile {
+  userId Int  @unique @map("user_id")
+  bio    String?
+  user   User @relation(fields: [userId], references: [id])
+
+  @@id([userId])
+}
+`
+
+	project := NewProject("blog")
+	warnings, err := project.FromPrismaSchema(schema)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "prisma model User: field updatedAt: @updatedAt skipped" {
+		t.Errorf("Expected a warning for @updatedAt, got %v", warnings)
+	}
+
+	if len(project.Tables) != 3 {
+		t.Fatalf("Expected 3 tables, got %d", len(project.Tables))
+	}
+
+	enum := project.Enums["public.user_role"]
+	if enum == nil {
+		t.Fatal("Expected enum public.user_role")
+	}
+	if len(enum.Values) != 2 || enum.Values[0] != "ADMIN" || enum.Values[1] != "member" {
+		t.Errorf("Expected enum values [ADMIN member], got %v", enum.Values)
+	}
+
+	users := project.Tables["public.users"]
+	if users == nil {
+		t.Fatal("Expected table public.users")
+	}
+	if len(users.Columns) != 6 {
+		t.Fatalf("Expected 6 columns on users, got %d", len(users.Columns))
+	}
+	id := users.Columns[0]
+	if !id.Settings.PrimaryKey || !id.Settings.Increment || id.Type != "integer" {
+		t.Errorf("Expected id integer pk increment, got %s %+v", id.Type, id.Settings)
+	}
+	email := users.Columns[1]
+	if email.Type != "varchar(255)" || !email.Settings.Unique {
+		t.Errorf("Expected email varchar(255) unique, got %s %+v", email.Type, email.Settings)
+	}
+	role := users.Columns[2]
+	if role.Type != "user_role" || role.Settings.Default == nil || *role.Settings.Default != "'MEMBER'" {
+		t.Errorf("Expected role user_role default 'MEMBER', got %s %v", role.Type, role.Settings.Default)
+	}
+	if users.Columns[3].Type != "decimal(10,2)" {
+		t.Errorf("Expected balance decimal(10,2), got %s", users.Columns[3].Type)
+	}
+	if users.Columns[4].Name != "created_at" || *users.Columns[4].Settings.Default != "now()" {
+		t.Errorf("Expected created_at default now(), got %s", users.Columns[4].Name)
+	}
+
+	posts := project.Tables["public.posts"]
+	if posts == nil {
+		t.Fatal("Expected table public.posts")
+	}
+	postID := posts.Columns[0]
+	if postID.Type != "uuid" || !postID.Settings.DatabaseDefault || *postID.Settings.Default != "gen_random_uuid()" {
+		t.Errorf("Expected uuid id with database default, got %s %+v", postID.Type, postID.Settings)
+	}
+	if *posts.Columns[1].Settings.Default != "'Untitled'" {
+		t.Errorf("Expected title default 'Untitled', got %s", *posts.Columns[1].Settings.Default)
+	}
+	if posts.Columns[3].Type != "text[]" {
+		t.Errorf("Expected tags text[], got %s", posts.Columns[3].Type)
+	}
+	if len(posts.Indexes) != 1 || *posts.Indexes[0].Columns[0].Name != "author_id" || *posts.Indexes[0].Columns[1].Name != "title" {
+		t.Errorf("Expected index on (author_id, title), got %+v", posts.Indexes)
+	}
+
+	profiles := project.Tables["public.Profile"]
+	if profiles == nil || len(profiles.Indexes) != 1 || !profiles.Indexes[0].PrimaryKey {
+		t.Errorf("Expected Profile table with composite primary key index")
+	}
+	if profiles != nil && !profiles.Columns[1].Settings.Null {
+		t.Error("Expected bio to be nullable")
+	}
+
+	if len(project.Refs) != 2 {
+		t.Fatalf("Expected 2 refs, got %d", len(project.Refs))
+	}
+	counts := project.CountRefsByType()
+	if counts[ManyToOne] != 1 || counts[OneToOne] != 1 {
+		t.Errorf("Expected one many-to-one and one one-to-one ref, got %v", counts)
+	}
+	for _, ref := range project.Refs {
+		if ref.Left.Table != "posts" {
+			continue
+		}
+		if ref.Left.Columns[0] != "author_id" || ref.Right.Table != "users" || ref.Right.Columns[0] != "id" {
+			t.Errorf("Expected posts.author_id > users.id, got %s", ref)
+		}
+		if ref.OnDelete == nil || *ref.OnDelete != Cascade {
+			t.Errorf("Expected on delete cascade, got %v", ref.OnDelete)
+		}
+	}
+}
+
+func TestProject_FromPrismaSchema_CompactSyntax(t *testing.T) {
+	schema := `enum Role { ADMIN MEMBER @map("member") }
+
+model Site{
+  id   Int    @id // primary key
+  url  String @default("https://example.com") // homepage
+  role Role   @default(ADMIN)
+}
+`
+
+	project := NewProject("sites")
+	if _, err := project.FromPrismaSchema(schema); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	enum := project.Enums["public.Role"]
+	if enum == nil || len(enum.Values) != 2 || enum.Values[0] != "ADMIN" || enum.Values[1] != "member" {
+		t.Fatalf("Expected one-line enum with values [ADMIN member], got %+v", enum)
+	}
+
+	site := project.Tables["public.Site"]
+	if site == nil || len(site.Columns) != 3 {
+		t.Fatalf("Expected Site table with 3 columns, got %+v", site)
+	}
+	if def := site.Columns[1].Settings.Default; def == nil || *def != "'https://example.com'" {
+		t.Errorf("Expected url default to survive comment stripping, got %v", def)
+	}
+}
+
+func TestProject_FromPrismaSchema_Warnings(t *testing.T) {
+	schema := `generator client {
+  provider = "prisma-client-js"
+}
+
+type Address {
+  street String
+}
+
+model Session {
+  id        String   @id @default(uuid())
+  token     String   @default(cuid())
+  touchedAt DateTime @updatedAt
+}
+`
+
+	project := NewProject("auth")
+	warnings, err := project.FromPrismaSchema(schema)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"prisma type Address: skipped",
+		"prisma model Session: field id: @default(uuid()) skipped",
+		"prisma model Session: field token: @default(cuid()) skipped",
+		"prisma model Session: field touchedAt: @updatedAt skipped",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected warnings %v, got %v", expected, warnings)
+	}
+	if project.Tables["public.Session"] == nil {
+		t.Error("Expected Session table to be imported")
+	}
+}
+
+func TestStripPrismaComment(t *testing.T) {
+	tests := map[string]string{
+		`id Int // comment`:               `id Int `,
+		`url String @default("a//b")`:     `url String @default("a//b")`,
+		`s String @default("q\"//") // c`: `s String @default("q\"//") `,
+		`// only a comment`:               ``,
+	}
+
+	for line, want := range tests {
+		if got := stripPrismaComment(line); got != want {
+			t.Errorf("Expected %q for %q, got %q", want, line, got)
+		}
+	}
+}
+
+func TestProject_FromPrismaSchema_RoundTrip(t *testing.T) {
+	original := NewProject("blog").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("name", "text").WithNull())).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id").WithOnDelete(Cascade))
+
+	imported := NewProject("blog")
+	if _, err := imported.FromPrismaSchema(original.GeneratePrismaSchema()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, key := range []string{"public.users", "public.posts"} {
+		if imported.Tables[key] == nil {
+			t.Errorf("Expected table %s after round trip", key)
+		}
+	}
+	if len(imported.Refs) != 1 || imported.Refs[0].Left.Columns[0] != "user_id" {
+		t.Errorf("Expected posts.user_id ref after round trip, got %v", imported.Refs)
+	}
+}
+
+func TestProject_FromPrismaSchema_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unterminated block": "model User {\n  id Int @id\n",
+		"stray field":        "id Int @id\n",
+		"mismatched relation": `model User {
+  id Int @id
+}
+model Post {
+  id     Int  @id
+  userId Int
+  user   User @relation(fields: [userId], references: [id, id])
+}`,
+	}
+
+	for name, schema := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewProject("test").FromPrismaSchema(schema); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,605 @@
+package dbml
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ColumnRef identifies a column by schema, table, and column name.
+type ColumnRef struct {
+	Schema string
+	Table  string
+	Column string
+}
+
+// GetAllEnumUsages returns the columns using each enum, keyed by schema.enumName.
+// Every enum in the project has an entry, even when no column uses it.
+func (p *Project) GetAllEnumUsages() map[string][]ColumnRef {
+	usages := make(map[string][]ColumnRef, len(p.Enums))
+	for key := range p.Enums {
+		usages[key] = []ColumnRef{}
+	}
+
+	for _, tableKey := range sortedKeys(p.Tables) {
+		table := p.Tables[tableKey]
+		for _, col := range table.Columns {
+			for key, enum := range p.Enums {
+				if enumTypeMatches(col.Type, enum.Schema, enum.Name) {
+					usages[key] = append(usages[key], ColumnRef{
+						Schema: table.Schema,
+						Table:  table.Name,
+						Column: col.Name,
+					})
+				}
+			}
+		}
+	}
+
+	return usages
+}
+
+// UsesEnum reports whether the column type refers to the named enum.
+// The enum name may be schema-qualified; unqualified names resolve to the default schema.
+func (c *Column) UsesEnum(enumName string) bool {
+	schema, name := defaultSchemaName, enumName
+	if i := strings.LastIndex(enumName, "."); i >= 0 {
+		schema, name = enumName[:i], enumName[i+1:]
+	}
+	return enumTypeMatches(c.Type, schema, name)
+}
+
+// enumTypeMatches reports whether a column type refers to the enum schema.name.
+// Array types such as `status[]` count as usages of the element enum.
+func enumTypeMatches(colType, schema, name string) bool {
+	colType = strings.TrimSuffix(strings.TrimSpace(colType), "[]")
+	if colType == schema+"."+name {
+		return true
+	}
+	return schema == defaultSchemaName && colType == name
+}
+
+// columnEnum returns the project enum the column's type refers to, or nil.
+func (p *Project) columnEnum(c *Column) *Enum {
+	for _, key := range sortedKeys(p.Enums) {
+		enum := p.Enums[key]
+		if enumTypeMatches(c.Type, enum.Schema, enum.Name) {
+			return enum
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// allRefs returns the standalone refs followed by refs synthesized from
+// inline column refs, in table key order.
+func (p *Project) allRefs() []*Ref {
+	refs := make([]*Ref, 0, len(p.Refs))
+	refs = append(refs, p.Refs...)
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		for _, col := range table.Columns {
+			if col.InlineRef == nil {
+				continue
+			}
+			refs = append(refs, col.InlineRef.ToRef(table.Schema, table.Name, col.Name))
+		}
+	}
+
+	return refs
+}
+
+// ormRelation is a foreign key between two tables of the project, with the
+// property names ORM generators give each side.
+type ormRelation struct {
+	ref       *Ref
+	child     *RefEndpoint
+	parent    *RefEndpoint
+	childKey  string
+	parentKey string
+	field     string // property on the child pointing at the parent
+	backField string // inverse property on the parent
+	ambiguous bool   // the tables are related more than once, or to themselves
+	nullable  bool   // any child column is nullable
+}
+
+// ormRelations returns the project's foreign keys whose tables both exist.
+// The child property is the FK column without its _id suffix, or the parent
+// model name; the inverse property is the child table name, suffixed with
+// the child property when the relation is ambiguous.
+func (p *Project) ormRelations() []ormRelation {
+	relations := []ormRelation{}
+	pairs := map[string]int{}
+	for _, ref := range p.allRefs() {
+		child, parent, ok := ref.foreignKey()
+		if !ok {
+			continue
+		}
+		rel := ormRelation{
+			ref:       ref,
+			child:     child,
+			parent:    parent,
+			childKey:  child.Schema + "." + child.Table,
+			parentKey: parent.Schema + "." + parent.Table,
+		}
+		if _, ok := p.Tables[rel.childKey]; !ok {
+			continue
+		}
+		if _, ok := p.Tables[rel.parentKey]; !ok {
+			continue
+		}
+		relations = append(relations, rel)
+		pairs[rel.childKey+" "+rel.parentKey]++
+	}
+
+	for i := range relations {
+		rel := &relations[i]
+		rel.ambiguous = pairs[rel.childKey+" "+rel.parentKey] > 1 || rel.childKey == rel.parentKey
+		rel.nullable = p.Tables[rel.childKey].anyNullable(rel.child.Columns)
+
+		rel.field = goParamName(goStructName(rel.parent.Table))
+		if len(rel.child.Columns) == 1 && strings.HasSuffix(rel.child.Columns[0], "_id") {
+			rel.field = strings.TrimSuffix(rel.child.Columns[0], "_id")
+		}
+		rel.backField = rel.child.Table
+		if rel.ambiguous {
+			rel.backField += "_" + rel.field
+		}
+	}
+
+	return relations
+}
+
+// anyNullable reports whether any of the named columns is nullable.
+func (t *Table) anyNullable(names []string) bool {
+	for _, col := range t.columnsByName(names) {
+		if col.Settings != nil && col.Settings.Null {
+			return true
+		}
+	}
+	return false
+}
+
+// foreignKey returns the referencing (child) and referenced (parent) endpoints
+// of a ref. Many-to-many refs have no single foreign key and report false.
+func (r *Ref) foreignKey() (child, parent *RefEndpoint, ok bool) {
+	if r.Left == nil || r.Right == nil {
+		return nil, nil, false
+	}
+
+	switch r.Type {
+	case ManyToOne, OneToOne:
+		return r.Left, r.Right, true
+	case OneToMany:
+		return r.Right, r.Left, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// foreignKeyName returns the ref name, or a conventional fk_<table>_<columns> name.
+func (r *Ref) foreignKeyName(child *RefEndpoint) string {
+	if r.Name != nil {
+		return *r.Name
+	}
+	return "fk_" + child.Table + "_" + strings.Join(child.Columns, "_")
+}
+
+// indexName returns the index name, or a conventional idx_<table>_<columns> name.
+func (i *Index) indexName(table *Table) string {
+	if i.Name != nil {
+		return *i.Name
+	}
+	parts := []string{"idx", table.Name}
+	for n, col := range i.Columns {
+		if col.Name != nil {
+			parts = append(parts, *col.Name)
+		} else {
+			parts = append(parts, "expr"+strconv.Itoa(n))
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// sequenceName returns the conventional sequence name for an increment column.
+func sequenceName(table *Table, col *Column) string {
+	return table.Schema + "_" + table.Name + "_" + col.Name + "_seq"
+}
+
+// SchemaNode is a schema and the schemas it depends on.
+type SchemaNode struct {
+	Schema    string
+	DependsOn []string
+}
+
+// GetSchemaGraph returns the cross-schema dependency graph, sorted by schema name.
+// A schema depends on another when one of its tables holds a foreign key to,
+// or uses an enum from, the other schema.
+func (p *Project) GetSchemaGraph() []*SchemaNode {
+	deps := map[string]map[string]bool{}
+	addSchema := func(schema string) {
+		if deps[schema] == nil {
+			deps[schema] = map[string]bool{}
+		}
+	}
+
+	for _, table := range p.Tables {
+		addSchema(table.Schema)
+	}
+	for _, enum := range p.Enums {
+		addSchema(enum.Schema)
+	}
+
+	for _, ref := range p.allRefs() {
+		child, parent, ok := ref.foreignKey()
+		if !ok {
+			continue
+		}
+		addSchema(child.Schema)
+		addSchema(parent.Schema)
+		if child.Schema != parent.Schema {
+			deps[child.Schema][parent.Schema] = true
+		}
+	}
+
+	for _, table := range p.Tables {
+		for _, col := range table.Columns {
+			for _, enum := range p.Enums {
+				if enum.Schema != table.Schema && enumTypeMatches(col.Type, enum.Schema, enum.Name) {
+					deps[table.Schema][enum.Schema] = true
+				}
+			}
+		}
+	}
+
+	nodes := make([]*SchemaNode, 0, len(deps))
+	for _, schema := range sortedKeys(deps) {
+		nodes = append(nodes, &SchemaNode{
+			Schema:    schema,
+			DependsOn: sortedKeys(deps[schema]),
+		})
+	}
+
+	return nodes
+}
+
+// ForEachTable calls fn for every table in sorted key order, stopping at the
+// first error, which is returned.
+func (p *Project) ForEachTable(fn func(*Table) error) error {
+	for _, key := range sortedKeys(p.Tables) {
+		if err := fn(p.Tables[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachColumn calls fn for every column of every table, with tables in
+// sorted key order and columns in declaration order. It stops at the first
+// error, which is returned.
+func (p *Project) ForEachColumn(fn func(*Table, *Column) error) error {
+	return p.ForEachTable(func(table *Table) error {
+		for _, col := range table.Columns {
+			if err := fn(table, col); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ContainsColumn reports whether the index includes the named column.
+func (i *Index) ContainsColumn(name string) bool {
+	for _, col := range i.Columns {
+		if col.Name != nil && *col.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsExpression reports whether the index includes the given expression.
+func (i *Index) ContainsExpression(expr string) bool {
+	for _, col := range i.Columns {
+		if col.Expression != nil && *col.Expression == expr {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIndex reports whether any index on the table includes the named column.
+func (t *Table) HasIndex(columnName string) bool {
+	for _, idx := range t.Indexes {
+		if idx.ContainsColumn(columnName) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetColumnIndex returns the position of the named column in t.Columns, or -1
+// if the table has no such column. Names are compared case-sensitively.
+func (t *Table) GetColumnIndex(name string) int {
+	for i, col := range t.Columns {
+		if col.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetColumnIndexCaseInsensitive is like GetColumnIndex but ignores case.
+func (t *Table) GetColumnIndexCaseInsensitive(name string) int {
+	for i, col := range t.Columns {
+		if strings.EqualFold(col.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetColumnNames returns the names of the table's columns in order.
+func (t *Table) GetColumnNames() []string {
+	names := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// GetColumnTypes returns the types of the table's columns in the same order
+// as GetColumnNames.
+func (t *Table) GetColumnTypes() []string {
+	types := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		types[i] = col.Type
+	}
+	return types
+}
+
+// GetDDLTables returns the tables included in SQL DDL, sorted by schema and
+// name. Tables marked with WithExcludeFromDDL are left out.
+func (p *Project) GetDDLTables() []*Table {
+	tables := []*Table{}
+	for _, key := range sortedKeys(p.Tables) {
+		if table := p.Tables[key]; !table.ExcludeFromDDL {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// ddlTablesInDependencyOrder returns the DDL tables with every table after
+// the tables its foreign keys reference, breaking ties by schema and name.
+// Tables in a foreign key cycle cannot be ordered; they follow the others and
+// are reported in cyclic. Self-references are ignored.
+func (p *Project) ddlTablesInDependencyOrder() (ordered []*Table, cyclic map[string]bool) {
+	tables := p.GetDDLTables()
+	deps := make(map[string]map[string]bool, len(tables))
+	for _, table := range tables {
+		deps[table.Schema+"."+table.Name] = map[string]bool{}
+	}
+	for _, ref := range p.allRefs() {
+		child, parent, ok := ref.foreignKey()
+		if !ok {
+			continue
+		}
+		childKey, parentKey := child.Schema+"."+child.Table, parent.Schema+"."+parent.Table
+		if _, ok := deps[parentKey]; !ok || childKey == parentKey || deps[childKey] == nil {
+			continue
+		}
+		deps[childKey][parentKey] = true
+	}
+
+	placed := make(map[string]bool, len(tables))
+	for len(ordered) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			key := table.Schema + "." + table.Name
+			if placed[key] {
+				continue
+			}
+			ready := true
+			for dep := range deps[key] {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, table)
+				placed[key] = true
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+
+	cyclic = map[string]bool{}
+	for _, table := range tables {
+		if key := table.Schema + "." + table.Name; !placed[key] {
+			ordered = append(ordered, table)
+			cyclic[key] = true
+		}
+	}
+
+	return ordered, cyclic
+}
+
+// FindTableByAlias returns the table with the given alias. If several tables
+// share the alias, the first by schema and name is returned.
+func (p *Project) FindTableByAlias(alias string) (*Table, bool) {
+	for _, key := range sortedKeys(p.Tables) {
+		if table := p.Tables[key]; table.Alias != nil && *table.Alias == alias {
+			return table, true
+		}
+	}
+	return nil, false
+}
+
+// InlineRefCount returns the number of inline refs across all table columns.
+func (p *Project) InlineRefCount() int {
+	count := 0
+	for _, table := range p.Tables {
+		for _, col := range table.Columns {
+			if col.InlineRef != nil {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// CountRefsByType counts standalone and inline refs by relationship type.
+func (p *Project) CountRefsByType() map[RelType]int {
+	counts := map[RelType]int{}
+	for _, ref := range p.allRefs() {
+		counts[ref.Type]++
+	}
+	return counts
+}
+
+// IsSymmetric reports whether the relationship reads the same in both
+// directions: one-to-one and many-to-many.
+func (r RelType) IsSymmetric() bool {
+	return r == OneToOne || r == ManyToMany
+}
+
+// Invert returns the relationship type seen from the other side:
+// one-to-many becomes many-to-one and vice versa. Symmetric types are
+// returned unchanged.
+func (r RelType) Invert() RelType {
+	switch r {
+	case OneToMany:
+		return ManyToOne
+	case ManyToOne:
+		return OneToMany
+	default:
+		return r
+	}
+}
+
+// Reverse returns a copy of the ref with its endpoints swapped and its type
+// inverted, describing the same relationship from the other side.
+func (r *Ref) Reverse() *Ref {
+	reversed := r.Clone()
+	reversed.Left, reversed.Right = reversed.Right, reversed.Left
+	reversed.Type = r.Type.Invert()
+	return reversed
+}
+
+// RefWithContext is a ref bound to the project it belongs to, for
+// operations that need to look up the tables it connects.
+type RefWithContext struct {
+	*Ref
+	project *Project
+}
+
+// WithSchemaContext binds the ref to a project.
+func (r *Ref) WithSchemaContext(p *Project) RefWithContext {
+	return RefWithContext{Ref: r, project: p}
+}
+
+// ResolveLeftTable returns the project table of the left endpoint.
+func (r RefWithContext) ResolveLeftTable() (*Table, bool) {
+	return r.resolveTable(r.Left)
+}
+
+// ResolveRightTable returns the project table of the right endpoint.
+func (r RefWithContext) ResolveRightTable() (*Table, bool) {
+	return r.resolveTable(r.Right)
+}
+
+// resolveTable looks the endpoint up by schema and name, with an empty
+// schema meaning the default one, and falls back to table aliases.
+func (r RefWithContext) resolveTable(endpoint *RefEndpoint) (*Table, bool) {
+	if r.project == nil || endpoint == nil {
+		return nil, false
+	}
+	schema := endpoint.Schema
+	if schema == "" {
+		schema = defaultSchemaName
+	}
+	if table, ok := r.project.Tables[schema+"."+endpoint.Table]; ok {
+		return table, true
+	}
+	return r.project.FindTableByAlias(endpoint.Table)
+}
+
+// FindDuplicateRefs returns groups of standalone and inline refs that
+// describe the same relationship. One-to-many refs are compared as their
+// many-to-one reverse, and symmetric refs match in either direction.
+// Groups are in the order their first ref appears.
+func (p *Project) FindDuplicateRefs() [][]*Ref {
+	groups := map[string][]*Ref{}
+	var order []string
+	for _, ref := range p.allRefs() {
+		if ref.Left == nil || ref.Right == nil {
+			continue
+		}
+		key := refDuplicateKey(ref)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], ref)
+	}
+
+	var duplicates [][]*Ref
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+	return duplicates
+}
+
+func refDuplicateKey(r *Ref) string {
+	if r.Type == OneToMany {
+		r = r.Reverse()
+	}
+	left := defaultGenerator.formatRefEndpoint(r.Left)
+	right := defaultGenerator.formatRefEndpoint(r.Right)
+	if r.Type.IsSymmetric() && right < left {
+		left, right = right, left
+	}
+	return left + " " + string(r.Type) + " " + right
+}
+
+// TotalRefCount returns the number of standalone and inline refs.
+func (p *Project) TotalRefCount() int {
+	return len(p.Refs) + p.InlineRefCount()
+}
+
+// GroupTablesByPrefix groups tables by the part of their name before the
+// first separator: auth_users and auth_roles group under "auth". Tables whose
+// names do not contain the separator are left out. Each group is in sorted
+// table key order.
+func (p *Project) GroupTablesByPrefix(separator string) map[string][]*Table {
+	groups := map[string][]*Table{}
+	if separator == "" {
+		return groups
+	}
+
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		prefix, _, found := strings.Cut(table.Name, separator)
+		if !found || prefix == "" {
+			continue
+		}
+		groups[prefix] = append(groups[prefix], table)
+	}
+
+	return groups
+}
@@ -0,0 +1,481 @@
+package dbml
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestProject_GetAllEnumUsages(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("status", "active", "inactive")).
+		AddEnum(NewEnum("role", "admin", "member").WithSchema("auth")).
+		AddEnum(NewEnum("unused", "a")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int")).
+			AddColumn(NewColumn("status", "status")).
+			AddColumn(NewColumn("role", "auth.role"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("status", "public.status")))
+
+	usages := project.GetAllEnumUsages()
+
+	if len(usages["public.status"]) != 2 {
+		t.Fatalf("Expected 2 usages of public.status, got %d", len(usages["public.status"]))
+	}
+
+	if usages["public.status"][0].Table != "posts" {
+		t.Errorf("Expected usages sorted by table key, got %+v", usages["public.status"])
+	}
+
+	if len(usages["auth.role"]) != 1 || usages["auth.role"][0].Column != "role" {
+		t.Errorf("Expected auth.role used by users.role, got %+v", usages["auth.role"])
+	}
+
+	if got, ok := usages["public.unused"]; !ok || len(got) != 0 {
+		t.Errorf("Expected empty entry for unused enum, got %+v", got)
+	}
+}
+
+func TestColumn_UsesEnum(t *testing.T) {
+	tests := []struct {
+		colType  string
+		enumName string
+		want     bool
+	}{
+		{"status", "status", true},
+		{"status", "public.status", true},
+		{"public.status", "status", true},
+		{"status[]", "status", true},
+		{"auth.status", "status", false},
+		{"auth.status", "auth.status", true},
+		{"status", "auth.status", false},
+		{"varchar", "status", false},
+	}
+
+	for _, tt := range tests {
+		col := NewColumn("c", tt.colType)
+		if got := col.UsesEnum(tt.enumName); got != tt.want {
+			t.Errorf("UsesEnum(%q) on type %q = %v, want %v", tt.enumName, tt.colType, got, tt.want)
+		}
+	}
+}
+
+func TestProject_GetSchemaGraph(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("role", "admin").WithSchema("auth")).
+		AddTable(NewTable("users").WithSchema("auth").
+			AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int")).
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "auth", "users", "id"))).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("role", "auth.role")).
+			AddColumn(NewColumn("post_id", "int")))
+
+	project.AddRef(NewRef(OneToMany).From("public", "posts", "id").To("audit", "events", "post_id"))
+
+	nodes := project.GetSchemaGraph()
+
+	if len(nodes) != 3 {
+		t.Fatalf("Expected 3 schema nodes, got %d", len(nodes))
+	}
+
+	expected := map[string][]string{
+		"audit":  {"auth", "public"},
+		"auth":   {},
+		"public": {"auth"},
+	}
+
+	for _, node := range nodes {
+		want := expected[node.Schema]
+		if len(node.DependsOn) != len(want) {
+			t.Errorf("Schema %s: expected dependencies %v, got %v", node.Schema, want, node.DependsOn)
+			continue
+		}
+		for i := range want {
+			if node.DependsOn[i] != want[i] {
+				t.Errorf("Schema %s: expected dependencies %v, got %v", node.Schema, want, node.DependsOn)
+			}
+		}
+	}
+}
+
+func TestProject_ForEachTable(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("accounts").WithSchema("billing").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("posts").AddColumn(NewColumn("id", "int")))
+
+	t.Run("sorted order", func(t *testing.T) {
+		var names []string
+		err := project.ForEachTable(func(table *Table) error {
+			names = append(names, table.Name)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		want := []string{"accounts", "posts", "users"}
+		if len(names) != len(want) {
+			t.Fatalf("Expected %v, got %v", want, names)
+		}
+		for i := range want {
+			if names[i] != want[i] {
+				t.Errorf("Expected %v, got %v", want, names)
+			}
+		}
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := project.ForEachTable(func(*Table) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("Expected stop error, got: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 call, got %d", calls)
+		}
+	})
+}
+
+func TestProject_ForEachColumn(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int")).
+			AddColumn(NewColumn("email", "varchar"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int")))
+
+	var visited []string
+	err := project.ForEachColumn(func(table *Table, col *Column) error {
+		visited = append(visited, table.Name+"."+col.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := []string{"posts.id", "users.id", "users.email"}
+	if len(visited) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, visited)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, visited)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = project.ForEachColumn(func(*Table, *Column) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || calls != 2 {
+		t.Errorf("Expected iteration to stop after 2 calls with stop error, got %d calls and %v", calls, err)
+	}
+}
+
+func TestIndex_Contains(t *testing.T) {
+	idx := NewIndex("user_id", "created_at")
+	expr := NewExpressionIndex("lower(email)")
+
+	if !idx.ContainsColumn("created_at") {
+		t.Error("Expected index to contain column 'created_at'")
+	}
+	if idx.ContainsColumn("email") {
+		t.Error("Expected index not to contain column 'email'")
+	}
+	if idx.ContainsExpression("user_id") {
+		t.Error("Expected column names not to match as expressions")
+	}
+	if !expr.ContainsExpression("lower(email)") {
+		t.Error("Expected index to contain expression 'lower(email)'")
+	}
+	if expr.ContainsColumn("lower(email)") {
+		t.Error("Expected expressions not to match as column names")
+	}
+}
+
+func TestTable_HasIndex(t *testing.T) {
+	table := NewTable("posts").
+		AddColumn(NewColumn("user_id", "int")).
+		AddColumn(NewColumn("title", "varchar")).
+		AddIndex(NewIndex("user_id", "created_at"))
+
+	if !table.HasIndex("user_id") {
+		t.Error("Expected table to have an index on 'user_id'")
+	}
+	if table.HasIndex("title") {
+		t.Error("Expected table not to have an index on 'title'")
+	}
+}
+
+func TestTable_GetColumnIndex(t *testing.T) {
+	table := NewTable("users").
+		AddColumn(NewColumn("id", "bigint")).
+		AddColumn(NewColumn("Email", "varchar"))
+
+	if got := table.GetColumnIndex("id"); got != 0 {
+		t.Errorf("Expected index 0 for id, got %d", got)
+	}
+	if got := table.GetColumnIndex("Email"); got != 1 {
+		t.Errorf("Expected index 1 for Email, got %d", got)
+	}
+	if got := table.GetColumnIndex("email"); got != -1 {
+		t.Errorf("Expected -1 for case-mismatched name, got %d", got)
+	}
+	if got := table.GetColumnIndexCaseInsensitive("EMAIL"); got != 1 {
+		t.Errorf("Expected index 1 for case-insensitive lookup, got %d", got)
+	}
+	if got := table.GetColumnIndexCaseInsensitive("missing"); got != -1 {
+		t.Errorf("Expected -1 for missing column, got %d", got)
+	}
+}
+
+func TestProject_RefCounts(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("editor_id", "int").WithRef(ManyToOne, "public", "users", "id")))
+
+	project.AddRef(NewRef(OneToOne).From("public", "users", "id").To("public", "posts", "user_id"))
+
+	if got := project.InlineRefCount(); got != 2 {
+		t.Errorf("Expected 2 inline refs, got %d", got)
+	}
+
+	if got := project.TotalRefCount(); got != 3 {
+		t.Errorf("Expected 3 refs in total, got %d", got)
+	}
+}
+
+func TestProject_GroupTablesByPrefix(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("auth_users").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("auth_roles").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("billing_invoices").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("settings").AddColumn(NewColumn("id", "int")))
+
+	groups := project.GroupTablesByPrefix("_")
+
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d: %v", len(groups), groups)
+	}
+
+	auth := groups["auth"]
+	if len(auth) != 2 || auth[0].Name != "auth_roles" || auth[1].Name != "auth_users" {
+		t.Errorf("Expected auth group [auth_roles auth_users], got %v", auth)
+	}
+
+	billing := groups["billing"]
+	if len(billing) != 1 || billing[0].Name != "billing_invoices" {
+		t.Errorf("Expected billing group [billing_invoices], got %v", billing)
+	}
+
+	if len(project.GroupTablesByPrefix("")) != 0 {
+		t.Error("Expected no groups for an empty separator")
+	}
+}
+
+func TestProject_CountRefsByType(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("editor_id", "int").WithRef(ManyToOne, "public", "users", "id"))).
+		AddRef(NewRef(OneToOne).From("public", "profiles", "user_id").To("public", "users", "id")).
+		AddRef(NewRef(ManyToMany).From("public", "posts", "id").To("public", "tags", "id")).
+		AddRef(NewRef(ManyToOne).From("public", "comments", "post_id").To("public", "posts", "id"))
+
+	counts := project.CountRefsByType()
+
+	expected := map[RelType]int{ManyToOne: 3, OneToOne: 1, ManyToMany: 1}
+	if len(counts) != len(expected) {
+		t.Errorf("Expected %d relationship types, got %v", len(expected), counts)
+	}
+	for relType, want := range expected {
+		if counts[relType] != want {
+			t.Errorf("Expected %d refs of type %s, got %d", want, relType, counts[relType])
+		}
+	}
+	if counts[OneToMany] != 0 {
+		t.Errorf("Expected no one-to-many refs, got %d", counts[OneToMany])
+	}
+}
+
+func TestTable_GetColumnNamesAndTypes(t *testing.T) {
+	table := NewTable("users").
+		AddColumn(NewColumn("id", "int")).
+		AddColumn(NewColumn("email", "varchar(255)")).
+		AddColumn(NewColumn("created_at", "timestamp"))
+
+	names := table.GetColumnNames()
+	if !slices.Equal(names, []string{"id", "email", "created_at"}) {
+		t.Errorf("Expected [id email created_at], got %v", names)
+	}
+
+	types := table.GetColumnTypes()
+	if !slices.Equal(types, []string{"int", "varchar(255)", "timestamp"}) {
+		t.Errorf("Expected [int varchar(255) timestamp], got %v", types)
+	}
+
+	if empty := NewTable("empty").GetColumnNames(); len(empty) != 0 {
+		t.Errorf("Expected no column names, got %v", empty)
+	}
+}
+
+func TestProject_FindTableByAlias(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").WithAlias("U")).
+		AddTable(NewTable("posts"))
+
+	table, ok := project.FindTableByAlias("U")
+	if !ok || table.Name != "users" {
+		t.Errorf("Expected to find users by alias U, got %v, %v", table, ok)
+	}
+
+	if _, ok := project.FindTableByAlias("P"); ok {
+		t.Error("Expected no table for unknown alias P")
+	}
+}
+
+func TestRelType_IsSymmetricAndInvert(t *testing.T) {
+	tests := []struct {
+		relType   RelType
+		inverted  RelType
+		symmetric bool
+	}{
+		{OneToMany, ManyToOne, false},
+		{ManyToOne, OneToMany, false},
+		{OneToOne, OneToOne, true},
+		{ManyToMany, ManyToMany, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.relType.IsSymmetric(); got != tt.symmetric {
+			t.Errorf("Expected %s symmetric=%t, got %t", tt.relType, tt.symmetric, got)
+		}
+		if got := tt.relType.Invert(); got != tt.inverted {
+			t.Errorf("Expected %s inverted to be %s, got %s", tt.relType, tt.inverted, got)
+		}
+	}
+}
+
+func TestRef_Reverse(t *testing.T) {
+	ref := NewRef(ManyToOne).WithName("fk_posts_user").
+		From("public", "posts", "user_id").To("public", "users", "id")
+
+	reversed := ref.Reverse()
+
+	if reversed.Type != OneToMany {
+		t.Errorf("Expected type %s, got %s", OneToMany, reversed.Type)
+	}
+	if reversed.Left.Table != "users" || reversed.Right.Table != "posts" {
+		t.Errorf("Expected users < posts, got %s", reversed)
+	}
+	if reversed.Name == nil || *reversed.Name != "fk_posts_user" {
+		t.Error("Expected name to be kept")
+	}
+	if ref.Left.Table != "posts" || ref.Type != ManyToOne {
+		t.Error("Expected original ref to be unchanged")
+	}
+}
+
+func TestRef_WithSchemaContext(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").WithAlias("U").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey())).
+		AddTable(NewTable("posts").WithSchema("blog").
+			AddColumn(NewColumn("user_id", "bigint")))
+
+	ref := NewRef(ManyToOne).From("blog", "posts", "user_id").To("", "U", "id").WithSchemaContext(project)
+
+	left, ok := ref.ResolveLeftTable()
+	if !ok || left != project.Tables["blog.posts"] {
+		t.Errorf("Expected left table blog.posts, got %v", left)
+	}
+	right, ok := ref.ResolveRightTable()
+	if !ok || right != project.Tables["public.users"] {
+		t.Errorf("Expected right table resolved by alias, got %v", right)
+	}
+	if ref.Type != ManyToOne {
+		t.Errorf("Expected embedded ref fields, got type %s", ref.Type)
+	}
+
+	missing := NewRef(ManyToOne).From("public", "comments", "post_id").To("blog", "posts", "id").WithSchemaContext(project)
+	if _, ok := missing.ResolveLeftTable(); ok {
+		t.Error("Expected unknown table not to resolve")
+	}
+	if _, ok := missing.WithSchemaContext(nil).ResolveRightTable(); ok {
+		t.Error("Expected no resolution without a project")
+	}
+}
+
+func TestProject_FindDuplicateRefs(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  *Ref
+		match bool
+	}{
+		{
+			name:  "one-to-many matches many-to-one reverse",
+			a:     NewRef(OneToMany).From("public", "users", "id").To("public", "posts", "user_id"),
+			b:     NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id"),
+			match: true,
+		},
+		{
+			name:  "many-to-one does not match swapped many-to-one",
+			a:     NewRef(ManyToOne).From("public", "users", "id").To("public", "posts", "user_id"),
+			b:     NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id"),
+			match: false,
+		},
+		{
+			name:  "one-to-one matches in either direction",
+			a:     NewRef(OneToOne).From("public", "profiles", "user_id").To("public", "users", "id"),
+			b:     NewRef(OneToOne).From("public", "users", "id").To("public", "profiles", "user_id"),
+			match: true,
+		},
+		{
+			name:  "many-to-many matches in either direction",
+			a:     NewRef(ManyToMany).From("public", "posts", "id").To("public", "tags", "id"),
+			b:     NewRef(ManyToMany).From("public", "tags", "id").To("public", "posts", "id"),
+			match: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := NewProject("test").AddRef(tt.a).AddRef(tt.b)
+
+			duplicates := project.FindDuplicateRefs()
+
+			if tt.match && (len(duplicates) != 1 || len(duplicates[0]) != 2) {
+				t.Errorf("Expected one group of two refs, got %v", duplicates)
+			}
+			if !tt.match && len(duplicates) != 0 {
+				t.Errorf("Expected no duplicates, got %v", duplicates)
+			}
+		})
+	}
+}
+
+func TestProject_FindDuplicateRefs_Inline(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "public", "users", "id"))).
+		AddRef(NewRef(OneToMany).From("public", "users", "id").To("public", "posts", "user_id"))
+
+	if duplicates := project.FindDuplicateRefs(); len(duplicates) != 1 {
+		t.Errorf("Expected inline ref to duplicate standalone ref, got %v", duplicates)
+	}
+}
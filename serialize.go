@@ -25,3 +25,77 @@ func (p *Project) ToYAML() ([]byte, error) {
 func (p *Project) FromYAML(data []byte) error {
 	return yaml.Unmarshal(data, p)
 }
+
+// columnSettingsJSON is the JSON form of ColumnSettings. Default and Check
+// are decoded as raw values so that absent, null and "" can be told apart.
+type columnSettingsJSON struct {
+	Default         json.RawMessage `json:",omitempty"`
+	Check           json.RawMessage `json:",omitempty"`
+	DefaultKind     DefaultKind     `json:",omitempty"`
+	PrimaryKey      bool
+	Null            bool
+	Unique          bool
+	Increment       bool
+	DatabaseDefault bool
+}
+
+// MarshalJSON encodes the settings, omitting Default and Check when they are
+// nil. A pointer to an empty string is written as "".
+func (s ColumnSettings) MarshalJSON() ([]byte, error) {
+	out := columnSettingsJSON{
+		PrimaryKey:      s.PrimaryKey,
+		Null:            s.Null,
+		Unique:          s.Unique,
+		Increment:       s.Increment,
+		DatabaseDefault: s.DatabaseDefault,
+		DefaultKind:     s.DefaultKind,
+	}
+	for _, field := range []struct {
+		value *string
+		raw   *json.RawMessage
+	}{{s.Default, &out.Default}, {s.Check, &out.Check}} {
+		if field.value == nil {
+			continue
+		}
+		data, err := json.Marshal(*field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.raw = data
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the settings. An absent or null Default or Check
+// becomes nil; any string, including "", becomes a non-nil pointer.
+func (s *ColumnSettings) UnmarshalJSON(data []byte) error {
+	var in columnSettingsJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	settings := ColumnSettings{
+		PrimaryKey:      in.PrimaryKey,
+		Null:            in.Null,
+		Unique:          in.Unique,
+		Increment:       in.Increment,
+		DatabaseDefault: in.DatabaseDefault,
+		DefaultKind:     in.DefaultKind,
+	}
+	for _, field := range []struct {
+		raw   json.RawMessage
+		value **string
+	}{{in.Default, &settings.Default}, {in.Check, &settings.Check}} {
+		if len(field.raw) == 0 || string(field.raw) == "null" {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(field.raw, &value); err != nil {
+			return err
+		}
+		*field.value = &value
+	}
+
+	*s = settings
+	return nil
+}
@@ -303,3 +303,99 @@ func TestProject_FromYAML_InvalidData(t *testing.T) {
 		t.Error("Expected error for invalid YAML, got nil")
 	}
 }
+
+func TestProject_EnumValueNotesRoundTrip(t *testing.T) {
+	project := NewProject("test_db").
+		AddEnum(NewEnum("status", "active", "banned").SetValueNote("banned", "Blocked by a moderator"))
+
+	jsonData, err := project.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	fromJSON := &Project{}
+	if err := fromJSON.FromJSON(jsonData); err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if got := fromJSON.Enums["public.status"].ValueNotes["banned"]; got != "Blocked by a moderator" {
+		t.Errorf("Expected value note to round-trip through JSON, got '%s'", got)
+	}
+
+	yamlData, err := project.ToYAML()
+	if err != nil {
+		t.Fatalf("ToYAML failed: %v", err)
+	}
+	fromYAML := &Project{}
+	if err := fromYAML.FromYAML(yamlData); err != nil {
+		t.Fatalf("FromYAML failed: %v", err)
+	}
+	if got := fromYAML.Enums["public.status"].ValueNotes["banned"]; got != "Blocked by a moderator" {
+		t.Errorf("Expected value note to round-trip through YAML, got '%s'", got)
+	}
+}
+
+func TestColumnSettings_JSONPointerSemantics(t *testing.T) {
+	empty := ""
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("nickname", "text").WithDefaultKind("", DefaultKindString)).
+			AddColumn(NewColumn("bio", "text")).
+			AddColumn(NewColumn("age", "int").WithCheck("age >= 0").WithDefault("0")))
+
+	data, err := project.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	var roundTripped Project
+	if err := roundTripped.FromJSON(data); err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if !roundTripped.Equal(project) {
+		t.Errorf("Expected round trip to preserve the project, got:\n%s", data)
+	}
+
+	columns := roundTripped.Tables["public.users"].Columns
+	if columns[0].Settings.Default == nil || *columns[0].Settings.Default != empty {
+		t.Errorf("Expected empty default to stay non-nil, got %v", columns[0].Settings.Default)
+	}
+	if columns[1].Settings.Default != nil || columns[1].Settings.Check != nil {
+		t.Errorf("Expected unset default and check to stay nil, got %v, %v", columns[1].Settings.Default, columns[1].Settings.Check)
+	}
+
+	var settings map[string]any
+	raw, _ := json.Marshal(columns[1].Settings)
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if _, ok := settings["Default"]; ok {
+		t.Errorf("Expected nil Default to be omitted, got %s", raw)
+	}
+}
+
+func TestColumnSettings_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		input       string
+		wantDefault *string
+	}{
+		"absent": {input: `{"PrimaryKey": true}`},
+		"null":   {input: `{"Default": null}`},
+		"empty":  {input: `{"Default": ""}`, wantDefault: new(string)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings := ColumnSettings{Default: &name}
+			if err := json.Unmarshal([]byte(tt.input), &settings); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !ptrEqual(settings.Default, tt.wantDefault) {
+				t.Errorf("Expected Default %v, got %v", tt.wantDefault, settings.Default)
+			}
+		})
+	}
+
+	var settings ColumnSettings
+	if err := json.Unmarshal([]byte(`{"Default": 5}`), &settings); err == nil {
+		t.Error("Expected error for a non-string default")
+	}
+}
@@ -0,0 +1,69 @@
+package dbml
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// slackBlock is a Slack Block Kit block. Only the fields used by
+// GenerateSlackMessage are modelled.
+type slackBlock struct {
+	Text     *slackText  `json:"text,omitempty"`
+	Type     string      `json:"type"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// GenerateSlackMessage generates a Slack Block Kit JSON payload announcing
+// the schema: a header with the project name and database type, a section
+// with table, column and ref counts, and a context footer with changeDesc
+// and the project note. changeDesc is passed through as Slack mrkdwn; names
+// and notes taken from the schema are escaped.
+func (p *Project) GenerateSlackMessage(changeDesc string) string {
+	header := p.Name
+	if p.DatabaseType != nil {
+		header += " (" + *p.DatabaseType + ")"
+	}
+
+	columns := 0
+	for _, table := range p.Tables {
+		columns += len(table.Columns)
+	}
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: header}},
+		{Type: "section", Fields: []slackText{
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Tables*\n%d", len(p.Tables))},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Columns*\n%d", columns)},
+			{Type: "mrkdwn", Text: fmt.Sprintf("*Refs*\n%d", p.TotalRefCount())},
+		}},
+	}
+
+	footer := []slackText{}
+	if changeDesc != "" {
+		footer = append(footer, slackText{Type: "mrkdwn", Text: changeDesc})
+	}
+	if p.Note != nil {
+		footer = append(footer, slackText{Type: "mrkdwn", Text: slackEscape(*p.Note)})
+	}
+	if len(footer) > 0 {
+		blocks = append(blocks, slackBlock{Type: "divider"}, slackBlock{Type: "context", Elements: footer})
+	}
+
+	// The payload holds only strings, which always marshal
+	data, _ := json.MarshalIndent(struct {
+		Blocks []slackBlock `json:"blocks"`
+	}{blocks}, "", "  ")
+	return string(data)
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,74 @@
+package dbml
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProject_GenerateSlackMessage(t *testing.T) {
+	project := NewProject("shop").
+		WithDatabaseType("PostgreSQL").
+		WithNote("Owned by <payments> & billing").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar"))).
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("total", "numeric"))).
+		AddRef(NewRef(OneToOne).From("public", "orders", "id").To("public", "users", "id"))
+
+	output := project.GenerateSlackMessage("Added *orders.total*")
+
+	var payload struct {
+		Blocks []slackBlock `json:"blocks"`
+	}
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	types := []string{}
+	for _, block := range payload.Blocks {
+		types = append(types, block.Type)
+	}
+	if len(types) != 4 || types[0] != "header" || types[1] != "section" || types[2] != "divider" || types[3] != "context" {
+		t.Fatalf("Expected header, section, divider and context blocks, got %v", types)
+	}
+
+	if text := payload.Blocks[0].Text; text == nil || text.Type != "plain_text" || text.Text != "shop (PostgreSQL)" {
+		t.Errorf("Expected plain text header shop (PostgreSQL), got %+v", text)
+	}
+
+	expectedFields := []string{"*Tables*\n2", "*Columns*\n5", "*Refs*\n2"}
+	fields := payload.Blocks[1].Fields
+	if len(fields) != len(expectedFields) {
+		t.Fatalf("Expected %d fields, got %+v", len(expectedFields), fields)
+	}
+	for i, want := range expectedFields {
+		if fields[i].Text != want {
+			t.Errorf("Expected field %q, got %q", want, fields[i].Text)
+		}
+	}
+
+	footer := payload.Blocks[3].Elements
+	if len(footer) != 2 || footer[0].Text != "Added *orders.total*" || footer[1].Text != "Owned by &lt;payments&gt; &amp; billing" {
+		t.Errorf("Expected change description and escaped note in footer, got %+v", footer)
+	}
+}
+
+func TestProject_GenerateSlackMessage_NoFooter(t *testing.T) {
+	output := NewProject("empty").GenerateSlackMessage("")
+
+	var payload struct {
+		Blocks []slackBlock `json:"blocks"`
+	}
+	if err := json.Unmarshal([]byte(output), &payload); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if len(payload.Blocks) != 2 {
+		t.Errorf("Expected header and counts only, got %d blocks", len(payload.Blocks))
+	}
+	if payload.Blocks[0].Text.Text != "empty" {
+		t.Errorf("Expected header without database type, got %q", payload.Blocks[0].Text.Text)
+	}
+}
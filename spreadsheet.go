@@ -0,0 +1,106 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// spreadsheetHeader is the header row of each GenerateSpreadsheetML worksheet.
+var spreadsheetHeader = []string{"table", "column", "type", "pk", "fk", "nullable", "note"}
+
+// spreadsheetSheetNameReplacer strips the characters Excel forbids in
+// worksheet names.
+var spreadsheetSheetNameReplacer = strings.NewReplacer(
+	"[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", `\`, "_",
+)
+
+// GenerateSpreadsheetML generates the schema as an XML Spreadsheet 2003
+// (SpreadsheetML) workbook, which Excel and LibreOffice Calc open directly.
+// Each schema gets a worksheet with a bold header row and one row per
+// column, in the order of ExportColumnCatalog. PK, FK and nullable are
+// boolean cells; FK is set for columns on the referencing side of a ref.
+// A project without tables yields a single worksheet for the default schema.
+func (p *Project) GenerateSpreadsheetML() string {
+	var b strings.Builder
+
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<?mso-application progid="Excel.Sheet"?>` + "\n")
+	b.WriteString(`<Workbook xmlns="urn:schemas-microsoft-com:office:spreadsheet"` + "\n")
+	b.WriteString(`    xmlns:ss="urn:schemas-microsoft-com:office:spreadsheet">` + "\n")
+	b.WriteString("  <Styles>\n")
+	b.WriteString(`    <Style ss:ID="header"><Font ss:Bold="1"/></Style>` + "\n")
+	b.WriteString("  </Styles>\n")
+
+	foreignKeys := map[string]bool{}
+	for _, ref := range p.allRefs() {
+		child, _, ok := ref.foreignKey()
+		if !ok {
+			continue
+		}
+		for _, col := range child.Columns {
+			foreignKeys[child.Schema+"."+child.Table+"."+col] = true
+		}
+	}
+
+	rows := map[string][]ColumnCatalogEntry{}
+	schemas := []string{}
+	for _, entry := range p.ExportColumnCatalog() {
+		if _, ok := rows[entry.Schema]; !ok {
+			schemas = append(schemas, entry.Schema)
+		}
+		rows[entry.Schema] = append(rows[entry.Schema], entry)
+	}
+	if len(schemas) == 0 {
+		schemas = append(schemas, defaultSchemaName)
+	}
+
+	for _, schema := range schemas {
+		b.WriteString(fmt.Sprintf("  <Worksheet ss:Name=\"%s\">\n", xmlEscaper.Replace(spreadsheetSheetName(schema))))
+		b.WriteString("    <Table>\n")
+		b.WriteString(`      <Row ss:StyleID="header">`)
+		for _, title := range spreadsheetHeader {
+			writeSpreadsheetCell(&b, "String", title)
+		}
+		b.WriteString("</Row>\n")
+
+		for _, entry := range rows[schema] {
+			b.WriteString("      <Row>")
+			writeSpreadsheetCell(&b, "String", entry.Table)
+			writeSpreadsheetCell(&b, "String", entry.Column)
+			writeSpreadsheetCell(&b, "String", entry.Type)
+			writeSpreadsheetCell(&b, "Boolean", spreadsheetBool(entry.PrimaryKey))
+			writeSpreadsheetCell(&b, "Boolean", spreadsheetBool(foreignKeys[entry.Schema+"."+entry.Table+"."+entry.Column]))
+			writeSpreadsheetCell(&b, "Boolean", spreadsheetBool(entry.Nullable))
+			writeSpreadsheetCell(&b, "String", entry.Note)
+			b.WriteString("</Row>\n")
+		}
+
+		b.WriteString("    </Table>\n")
+		b.WriteString("  </Worksheet>\n")
+	}
+
+	b.WriteString("</Workbook>\n")
+
+	return b.String()
+}
+
+func writeSpreadsheetCell(b *strings.Builder, kind, value string) {
+	b.WriteString(fmt.Sprintf(`<Cell><Data ss:Type="%s">%s</Data></Cell>`, kind, xmlEscaper.Replace(value)))
+}
+
+func spreadsheetBool(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// spreadsheetSheetName makes a schema name a valid worksheet name: at most
+// 31 characters, without the characters Excel forbids.
+func spreadsheetSheetName(schema string) string {
+	name := spreadsheetSheetNameReplacer.Replace(schema)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
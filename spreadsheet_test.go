@@ -0,0 +1,73 @@
+package dbml
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateSpreadsheetML(t *testing.T) {
+	project := NewProject("shop").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("bio", "text").WithNull().WithNote("Shown on <profile> & cards"))).
+		AddTable(NewTable("invoices").
+			WithSchema("billing").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint"))).
+		AddRef(NewRef(ManyToOne).From("billing", "invoices", "user_id").To("public", "users", "id"))
+
+	output := project.GenerateSpreadsheetML()
+
+	expected := []string{
+		"<?mso-application progid=\"Excel.Sheet\"?>\n",
+		"<Workbook xmlns=\"urn:schemas-microsoft-com:office:spreadsheet\"",
+		"  <Worksheet ss:Name=\"billing\">\n",
+		"  <Worksheet ss:Name=\"public\">\n",
+		"<Row ss:StyleID=\"header\"><Cell><Data ss:Type=\"String\">table</Data></Cell><Cell><Data ss:Type=\"String\">column</Data></Cell>",
+		"<Cell><Data ss:Type=\"String\">invoices</Data></Cell><Cell><Data ss:Type=\"String\">user_id</Data></Cell><Cell><Data ss:Type=\"String\">bigint</Data></Cell>" +
+			"<Cell><Data ss:Type=\"Boolean\">0</Data></Cell><Cell><Data ss:Type=\"Boolean\">1</Data></Cell><Cell><Data ss:Type=\"Boolean\">0</Data></Cell>",
+		"<Cell><Data ss:Type=\"String\">users</Data></Cell><Cell><Data ss:Type=\"String\">id</Data></Cell><Cell><Data ss:Type=\"String\">bigint</Data></Cell>" +
+			"<Cell><Data ss:Type=\"Boolean\">1</Data></Cell><Cell><Data ss:Type=\"Boolean\">0</Data></Cell>",
+		"<Cell><Data ss:Type=\"Boolean\">1</Data></Cell><Cell><Data ss:Type=\"String\">Shown on &lt;profile&gt; &amp; cards</Data></Cell>",
+		"</Workbook>\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+		}
+	}
+	if strings.Index(output, "ss:Name=\"billing\"") > strings.Index(output, "ss:Name=\"public\"") {
+		t.Error("Expected worksheets in schema order")
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(output))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Errorf("Expected well-formed XML, got %v", err)
+			}
+			break
+		}
+	}
+}
+
+func TestProject_GenerateSpreadsheetML_Empty(t *testing.T) {
+	output := NewProject("empty").GenerateSpreadsheetML()
+
+	if strings.Count(output, "<Worksheet ") != 1 || !strings.Contains(output, "ss:Name=\"public\"") {
+		t.Errorf("Expected a single default worksheet, got:\n%s", output)
+	}
+}
+
+func TestSpreadsheetSheetName(t *testing.T) {
+	if got := spreadsheetSheetName("a/b:c"); got != "a_b_c" {
+		t.Errorf("Expected a_b_c, got %s", got)
+	}
+	if got := spreadsheetSheetName(strings.Repeat("x", 40)); len(got) != 31 {
+		t.Errorf("Expected 31 characters, got %d", len(got))
+	}
+}
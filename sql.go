@@ -0,0 +1,394 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SQLDialect identifies the SQL dialect targeted by DDL generation.
+type SQLDialect string
+
+const (
+	DialectPostgres SQLDialect = "postgres"
+	DialectMySQL    SQLDialect = "mysql"
+)
+
+// GenerateSQL generates SQL DDL from a Project for the given dialect.
+// Dialects without a dedicated generator fall back to PostgreSQL.
+func (p *Project) GenerateSQL(dialect SQLDialect) string {
+	switch dialect {
+	case DialectPostgres:
+		return p.GeneratePostgresSQL()
+	case DialectMySQL:
+		return p.GenerateMySQLSQL()
+	default:
+		return p.GeneratePostgresSQL()
+	}
+}
+
+// GenerateDDLWithTransaction generates SQL DDL for the given dialect wrapped
+// in a transaction. PostgreSQL scripts also set a lock timeout so that a
+// blocked migration fails instead of queueing behind long-running queries.
+// Like GenerateSQL, dialects without a dedicated generator fall back to
+// PostgreSQL, lock timeout included.
+func (p *Project) GenerateDDLWithTransaction(dialect SQLDialect) string {
+	var b strings.Builder
+
+	switch dialect {
+	case DialectMySQL:
+		b.WriteString("START TRANSACTION;\n\n")
+	default:
+		b.WriteString("BEGIN;\n\n")
+		b.WriteString("SET LOCAL lock_timeout = '10s';\n\n")
+	}
+	b.WriteString(strings.TrimRight(p.GenerateSQL(dialect), "\n"))
+	b.WriteString("\n\nCOMMIT;\n")
+
+	return b.String()
+}
+
+// GeneratePostgresSQL generates PostgreSQL DDL from a Project.
+// Enum types are created first, then tables and their indexes, and finally
+// foreign key constraints so that table order does not matter. Tables
+// excluded from DDL are skipped along with their foreign keys.
+func (p *Project) GeneratePostgresSQL() string {
+	var b strings.Builder
+
+	// Schemas
+	searchPath := []string{}
+	for _, name := range sortedKeys(p.Schemas) {
+		schema := p.Schemas[name]
+		b.WriteString(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pgQuoteIdent(name)))
+		if schema.Owner != nil {
+			b.WriteString(" AUTHORIZATION " + pgQuoteIdent(*schema.Owner))
+		}
+		b.WriteString(";\n")
+		if schema.Comment != nil {
+			b.WriteString(fmt.Sprintf("COMMENT ON SCHEMA %s IS %s;\n", pgQuoteIdent(name), quoteSQLString(*schema.Comment)))
+		}
+		if schema.SearchPath {
+			searchPath = append(searchPath, name)
+		}
+	}
+	if len(searchPath) > 0 {
+		if !slices.Contains(searchPath, defaultSchema) {
+			searchPath = append(searchPath, defaultSchema)
+		}
+		b.WriteString(fmt.Sprintf("SET search_path TO %s;\n", pgQuoteIdentList(searchPath)))
+	}
+	if len(p.Schemas) > 0 {
+		b.WriteString("\n")
+	}
+
+	// Enums
+	for _, key := range sortedKeys(p.Enums) {
+		enum := p.Enums[key]
+		values := make([]string, len(enum.Values))
+		for i, value := range enum.Values {
+			values[i] = quoteSQLString(value)
+		}
+		b.WriteString(fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);\n\n",
+			pgQualifiedName(enum.Schema, enum.Name), strings.Join(values, ", ")))
+	}
+
+	// Tables
+	for _, table := range p.GetDDLTables() {
+		b.WriteString(table.postgresCreateTable())
+		b.WriteString("\n")
+	}
+
+	// Foreign keys; migration history and excluded tables are never constrained
+	for _, ref := range p.allRefs() {
+		if p.refTouchesMigrationTable(ref) || p.refTouchesExcludedTable(ref) {
+			continue
+		}
+		if stmt := ref.postgresForeignKey(); stmt != "" {
+			b.WriteString(stmt)
+		}
+	}
+
+	return b.String()
+}
+
+// SQL returns the action as a SQL keyword, e.g. "SET NULL". The supported
+// dialects spell referential actions the same way, but MySQL's InnoDB engine
+// rejects SET DEFAULT; ValidateWithOptions reports it for DialectMySQL.
+func (a RefAction) SQL(_ SQLDialect) string {
+	return strings.ToUpper(string(a))
+}
+
+func (p *Project) refTouchesMigrationTable(r *Ref) bool {
+	return p.refTouchesTable(r, func(t *Table) bool { return t.Settings[migrationTableSetting] == "true" })
+}
+
+func (p *Project) refTouchesExcludedTable(r *Ref) bool {
+	return p.refTouchesTable(r, func(t *Table) bool { return t.ExcludeFromDDL })
+}
+
+// refTouchesTable reports whether either end of r is a table matching match.
+func (p *Project) refTouchesTable(r *Ref, match func(*Table) bool) bool {
+	for _, endpoint := range []*RefEndpoint{r.Left, r.Right} {
+		if endpoint == nil {
+			continue
+		}
+		if table, ok := p.Tables[endpoint.Schema+"."+endpoint.Table]; ok && match(table) {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateSequences generates explicit sequence DDL for increment columns,
+// for databases that back integer keys with named sequences instead of
+// identity or serial columns. Each sequence is named
+// schema_table_column_seq and attached to its column as the default.
+// GeneratePostgresSQL declares increment columns as identity columns, which
+// cannot also have a default, so the identity is dropped first; the output
+// can be run after the table DDL. MySQL has no sequences, so the result is
+// empty for DialectMySQL.
+func (p *Project) GenerateSequences(dialect SQLDialect) string {
+	if dialect == DialectMySQL {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, table := range p.GetDDLTables() {
+		for _, col := range table.Columns {
+			if col.Settings == nil || !col.Settings.Increment {
+				continue
+			}
+			sequence := pgQualifiedName(table.Schema, sequenceName(table, col))
+			b.WriteString(fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START 1;\n", sequence))
+			b.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP IDENTITY IF EXISTS;\n",
+				pgQualifiedName(table.Schema, table.Name), pgQuoteIdent(col.Name)))
+			b.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT nextval(%s);\n\n",
+				pgQualifiedName(table.Schema, table.Name), pgQuoteIdent(col.Name), quoteSQLString(sequence)))
+		}
+	}
+
+	return b.String()
+}
+
+func (t *Table) postgresCreateTable() string {
+	var b strings.Builder
+
+	pkColumns := t.primaryKeyColumns()
+	inlinePK := len(pkColumns) == 1 && !t.hasPrimaryKeyIndex()
+
+	lines := []string{}
+	for _, col := range t.Columns {
+		lines = append(lines, "  "+col.postgresDefinition(inlinePK))
+	}
+	if !inlinePK && len(pkColumns) > 0 {
+		lines = append(lines, fmt.Sprintf("  PRIMARY KEY (%s)", pgQuoteIdentList(pkColumns)))
+	}
+
+	b.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", pgQualifiedName(t.Schema, t.Name)))
+	b.WriteString(strings.Join(lines, ",\n"))
+	b.WriteString("\n)")
+	if len(t.StorageParams) > 0 {
+		params := []string{}
+		for _, key := range sortedKeys(t.StorageParams) {
+			params = append(params, key+" = "+t.StorageParams[key])
+		}
+		b.WriteString(" WITH (" + strings.Join(params, ", ") + ")")
+	}
+	b.WriteString(";\n")
+
+	if t.RowLevelSecurity != nil && *t.RowLevelSecurity {
+		b.WriteString(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY;\n", pgQualifiedName(t.Schema, t.Name)))
+	}
+
+	if t.SQLComment != nil {
+		b.WriteString(fmt.Sprintf("COMMENT ON TABLE %s IS %s;\n", pgQualifiedName(t.Schema, t.Name), quoteSQLString(*t.SQLComment)))
+	}
+
+	for _, idx := range t.Indexes {
+		if idx.PrimaryKey {
+			continue
+		}
+		b.WriteString(idx.postgresCreateIndex(t))
+	}
+
+	return b.String()
+}
+
+func (c *Column) postgresDefinition(inlinePK bool) string {
+	parts := []string{pgQuoteIdent(c.Name), c.sqlType()}
+
+	// PostgreSQL only supports stored generated columns
+	if c.ComputedAlias != nil {
+		parts = append(parts, fmt.Sprintf("GENERATED ALWAYS AS (%s) STORED", *c.ComputedAlias))
+	}
+
+	if c.Settings != nil {
+		if c.Settings.Increment {
+			parts = append(parts, "GENERATED BY DEFAULT AS IDENTITY")
+		}
+		if c.Settings.PrimaryKey && inlinePK {
+			parts = append(parts, "PRIMARY KEY")
+		} else if !c.Settings.Null && !c.Settings.PrimaryKey {
+			parts = append(parts, "NOT NULL")
+		}
+		if c.Settings.Unique {
+			parts = append(parts, "UNIQUE")
+		}
+		if c.Settings.Default != nil && !c.Settings.Increment && !c.Settings.DatabaseDefault && c.ComputedAlias == nil {
+			parts = append(parts, "DEFAULT "+sqlDefault(*c.Settings.Default, c.Settings.DefaultKind))
+		}
+		if c.Settings.Check != nil {
+			parts = append(parts, fmt.Sprintf("CHECK (%s)", *c.Settings.Check))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (i *Index) postgresCreateIndex(table *Table) string {
+	columns := []string{}
+	for _, col := range i.Columns {
+		if col.Name != nil {
+			columns = append(columns, pgQuoteIdent(*col.Name))
+		} else if col.Expression != nil {
+			columns = append(columns, "("+*col.Expression+")")
+		}
+	}
+
+	create := "CREATE INDEX"
+	if i.Unique {
+		create = "CREATE UNIQUE INDEX"
+	}
+
+	using := ""
+	if i.Type != nil {
+		using = " USING " + *i.Type
+	}
+
+	return fmt.Sprintf("%s %s ON %s%s (%s);\n", create, pgQuoteIdent(i.indexName(table)),
+		pgQualifiedName(table.Schema, table.Name), using, strings.Join(columns, ", "))
+}
+
+func (r *Ref) postgresForeignKey() string {
+	child, parent, ok := r.foreignKey()
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		pgQualifiedName(child.Schema, child.Table),
+		pgQuoteIdent(r.foreignKeyName(child)),
+		pgQuoteIdentList(child.Columns),
+		pgQualifiedName(parent.Schema, parent.Table),
+		pgQuoteIdentList(parent.Columns),
+	))
+	if r.OnDelete != nil {
+		b.WriteString(" ON DELETE " + r.OnDelete.SQL(DialectPostgres))
+	}
+	if r.OnUpdate != nil {
+		b.WriteString(" ON UPDATE " + r.OnUpdate.SQL(DialectPostgres))
+	}
+	if r.Deferrable != nil {
+		b.WriteString(" DEFERRABLE " + strings.ToUpper(string(*r.Deferrable)))
+	}
+	b.WriteString(";\n")
+
+	return b.String()
+}
+
+// sqlType returns the type used in SQL DDL: the type alias if set, else Type.
+func (c *Column) sqlType() string {
+	if c.TypeAlias != nil {
+		return *c.TypeAlias
+	}
+	return c.Type
+}
+
+// primaryKeyColumns returns the primary key column names, preferring a pk index
+// over column-level pk settings.
+func (t *Table) primaryKeyColumns() []string {
+	for _, idx := range t.Indexes {
+		if !idx.PrimaryKey {
+			continue
+		}
+		columns := []string{}
+		for _, col := range idx.Columns {
+			if col.Name != nil {
+				columns = append(columns, *col.Name)
+			}
+		}
+		return columns
+	}
+
+	columns := []string{}
+	for _, col := range t.Columns {
+		if col.Settings != nil && col.Settings.PrimaryKey {
+			columns = append(columns, col.Name)
+		}
+	}
+	return columns
+}
+
+func (t *Table) hasPrimaryKeyIndex() bool {
+	for _, idx := range t.Indexes {
+		if idx.PrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlDialectsByDatabaseType maps lowercased DBML database_type values to dialects.
+var sqlDialectsByDatabaseType = map[string]SQLDialect{
+	"postgres":   DialectPostgres,
+	"postgresql": DialectPostgres,
+	"mysql":      DialectMySQL,
+}
+
+// sqlDialect maps the project's database type to a SQL dialect, defaulting to PostgreSQL.
+func (p *Project) sqlDialect() SQLDialect {
+	if p.DatabaseType != nil {
+		if dialect, ok := sqlDialectsByDatabaseType[strings.ToLower(*p.DatabaseType)]; ok {
+			return dialect
+		}
+	}
+	return DialectPostgres
+}
+
+func pgQuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func pgQuoteIdentList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = pgQuoteIdent(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func pgQualifiedName(schema, name string) string {
+	return pgQuoteIdent(schema) + "." + pgQuoteIdent(name)
+}
+
+func quoteSQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sqlDefault returns a column default as SQL. String defaults are quoted
+// unless they already are, and expressions lose their DBML backticks.
+func sqlDefault(value string, kind DefaultKind) string {
+	switch kind {
+	case DefaultKindString:
+		if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			return value
+		}
+		return quoteSQLString(value)
+	case DefaultKindExpression:
+		if len(value) >= 2 && strings.HasPrefix(value, "`") && strings.HasSuffix(value, "`") {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
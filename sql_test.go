@@ -0,0 +1,348 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GeneratePostgresSQL(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("status", "active", "it's off")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique()).
+			AddColumn(NewColumn("status", "status").WithDefault("'active'")).
+			AddColumn(NewColumn("age", "int").WithNull().WithCheck("age >= 18")).
+			AddIndex(NewIndex("email").WithType("btree")).
+			AddIndex(NewExpressionIndex("lower(email)").WithUnique().WithName("idx_lower_email"))).
+		AddTable(NewTable("memberships").
+			AddColumn(NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")).
+			AddColumn(NewColumn("group_id", "bigint")).
+			AddIndex(NewIndex("user_id", "group_id").WithPrimaryKey()))
+
+	project.AddRef(NewRef(ManyToMany).
+		From("public", "users", "id").
+		To("public", "memberships", "group_id"))
+
+	output := project.GeneratePostgresSQL()
+
+	expected := []string{
+		`CREATE TYPE "public"."status" AS ENUM ('active', 'it''s off');`,
+		`CREATE TABLE "public"."users" (`,
+		`  "id" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,`,
+		`  "email" varchar(255) NOT NULL UNIQUE,`,
+		`  "status" status NOT NULL DEFAULT 'active',`,
+		`  "age" int CHECK (age >= 18)`,
+		`CREATE INDEX "idx_users_email" ON "public"."users" USING btree ("email");`,
+		`CREATE UNIQUE INDEX "idx_lower_email" ON "public"."users" ((lower(email)));`,
+		`  PRIMARY KEY ("user_id", "group_id")`,
+		`ALTER TABLE "public"."memberships" ADD CONSTRAINT "fk_memberships_user_id" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id");`,
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Count(output, "FOREIGN KEY") != 1 {
+		t.Errorf("Expected many-to-many ref to produce no foreign key, got:\n%s", output)
+	}
+}
+
+func TestProject_GenerateSQL_RefActions(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("posts").AddColumn(NewColumn("user_id", "int")))
+
+	project.AddRef(NewRef(OneToMany).
+		WithName("posts_user").
+		From("public", "users", "id").
+		To("public", "posts", "user_id").
+		WithOnDelete(SetNull).
+		WithOnUpdate(Cascade))
+
+	output := project.GenerateSQL(DialectPostgres)
+
+	want := `ALTER TABLE "public"."posts" ADD CONSTRAINT "posts_user" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON DELETE SET NULL ON UPDATE CASCADE;`
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_DatabaseDefault(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("number", "bigint").WithDefault("next_order_number()").WithDatabaseDefault()).
+			AddColumn(NewColumn("created_at", "timestamp").WithDefault("now()")))
+
+	output := project.GeneratePostgresSQL()
+
+	if strings.Contains(output, "next_order_number()") {
+		t.Errorf("Expected database-generated default to be omitted, got:\n%s", output)
+	}
+
+	if !strings.Contains(output, `"created_at" timestamp NOT NULL DEFAULT now()`) {
+		t.Errorf("Expected explicit default to be kept, got:\n%s", output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_RowLevelSecurity(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("documents").WithRowLevelSecurity(true).AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("logs").WithRowLevelSecurity(false).AddColumn(NewColumn("id", "int")))
+
+	output := project.GeneratePostgresSQL()
+
+	if !strings.Contains(output, `ALTER TABLE "public"."documents" ENABLE ROW LEVEL SECURITY;`) {
+		t.Errorf("Expected RLS to be enabled on documents, got:\n%s", output)
+	}
+
+	if strings.Count(output, "ROW LEVEL SECURITY") != 1 {
+		t.Errorf("Expected RLS statement only for documents, got:\n%s", output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_Schemas(t *testing.T) {
+	project := NewProject("test").
+		AddSchema(NewSchema("auth")).
+		AddTable(NewTable("users").WithSchema("auth").AddColumn(NewColumn("id", "int")))
+
+	output := project.GeneratePostgresSQL()
+
+	if !strings.HasPrefix(output, `CREATE SCHEMA IF NOT EXISTS "auth";`) {
+		t.Errorf("Expected schemas to be created first, got:\n%s", output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_MigrationTable(t *testing.T) {
+	project := NewProject("app").
+		AddMigrationTable("schema_history").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "bigint").WithPrimaryKey())).
+		AddRef(NewRef(ManyToOne).From("public", "schema_history", "installed_by").To("public", "users", "id"))
+
+	output := project.GeneratePostgresSQL()
+
+	if !strings.Contains(output, `CREATE TABLE "public"."schema_history"`) {
+		t.Errorf("Expected migration table to be created, got:\n%s", output)
+	}
+	if strings.Contains(output, "FOREIGN KEY") {
+		t.Errorf("Expected no foreign keys on the migration table, got:\n%s", output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_Deferrable(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("posts").AddColumn(NewColumn("user_id", "int"))).
+		AddRef(NewRef(ManyToOne).
+			From("public", "posts", "user_id").
+			To("public", "users", "id").
+			WithOnDelete(Cascade).
+			WithDeferrable(DeferrableInitiallyDeferred))
+
+	output := project.GeneratePostgresSQL()
+
+	want := `REFERENCES "public"."users" ("id") ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;`
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_ComputedColumn(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("items").
+			AddColumn(NewColumn("price", "numeric")).
+			AddColumn(NewColumn("qty", "int")).
+			AddColumn(NewColumn("total", "numeric").WithComputedAlias("price * qty").WithDefault("0")))
+
+	output := project.GeneratePostgresSQL()
+
+	want := `"total" numeric GENERATED ALWAYS AS (price * qty) STORED NOT NULL`
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+	}
+	if strings.Contains(output, "DEFAULT 0") {
+		t.Errorf("Expected no default on a computed column, got:\n%s", output)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_SchemaOptions(t *testing.T) {
+	project := NewProject("test").
+		DefineSchema("billing", WithOwner("finance"), WithComment("Invoices and payments"), WithSearchPath(true)).
+		DefineSchema("auth")
+
+	output := project.GeneratePostgresSQL()
+
+	expected := []string{
+		"CREATE SCHEMA IF NOT EXISTS \"auth\";\n",
+		"CREATE SCHEMA IF NOT EXISTS \"billing\" AUTHORIZATION \"finance\";\n",
+		"COMMENT ON SCHEMA \"billing\" IS 'Invoices and payments';\n",
+		"SET search_path TO \"billing\", \"public\";\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestProject_GeneratePostgresSQL_StorageParams(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("events").
+			WithStorageParameter("fillfactor", "70").
+			WithStorageParameter("autovacuum_enabled", "false").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()))
+
+	output := project.GeneratePostgresSQL()
+
+	want := "\n) WITH (autovacuum_enabled = false, fillfactor = 70);\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+	}
+}
+
+func TestProject_GenerateDDLWithTransaction(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()))
+
+	output := project.GenerateDDLWithTransaction(DialectPostgres)
+
+	if !strings.HasPrefix(output, "BEGIN;\n\nSET LOCAL lock_timeout = '10s';\n\n") {
+		t.Errorf("Expected output to start with BEGIN and lock timeout, got:\n%s", output)
+	}
+	if !strings.Contains(output, project.GeneratePostgresSQL()) {
+		t.Errorf("Expected output to contain the generated DDL, got:\n%s", output)
+	}
+	if !strings.HasSuffix(output, "\nCOMMIT;\n") {
+		t.Errorf("Expected output to end with COMMIT, got:\n%s", output)
+	}
+
+	mysql := project.GenerateDDLWithTransaction(DialectMySQL)
+	if !strings.HasPrefix(mysql, "START TRANSACTION;\n\n") {
+		t.Errorf("Expected MySQL output to start with START TRANSACTION, got:\n%s", mysql)
+	}
+	if strings.Contains(mysql, "lock_timeout") {
+		t.Errorf("Expected no lock timeout for MySQL, got:\n%s", mysql)
+	}
+
+	if fallback := project.GenerateDDLWithTransaction("sqlite"); fallback != output {
+		t.Errorf("Expected unknown dialects to fall back to PostgreSQL, got:\n%s", fallback)
+	}
+}
+
+func TestProject_GenerateSequences(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("name", "text"))).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("seq", "bigint").WithIncrement())).
+		AddTable(NewTable("tags").
+			AddColumn(NewColumn("name", "text").WithPrimaryKey()))
+
+	output := project.GenerateSequences(DialectPostgres)
+
+	expected := `CREATE SEQUENCE IF NOT EXISTS "audit"."audit_events_seq_seq" START 1;
+ALTER TABLE "audit"."events" ALTER COLUMN "seq" DROP IDENTITY IF EXISTS;
+ALTER TABLE "audit"."events" ALTER COLUMN "seq" SET DEFAULT nextval('"audit"."audit_events_seq_seq"');
+
+CREATE SEQUENCE IF NOT EXISTS "public"."public_users_id_seq" START 1;
+ALTER TABLE "public"."users" ALTER COLUMN "id" DROP IDENTITY IF EXISTS;
+ALTER TABLE "public"."users" ALTER COLUMN "id" SET DEFAULT nextval('"public"."public_users_id_seq"');
+
+`
+	if output != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+	}
+
+	if mysql := project.GenerateSequences(DialectMySQL); mysql != "" {
+		t.Errorf("Expected no sequences for MySQL, got:\n%s", mysql)
+	}
+}
+
+func TestProject_GenerateSequences_WithPostgresSQL(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()))
+
+	script := project.GeneratePostgresSQL() + project.GenerateSequences(DialectPostgres)
+
+	create := strings.Index(script, `"id" bigint GENERATED BY DEFAULT AS IDENTITY`)
+	drop := strings.Index(script, `ALTER TABLE "public"."users" ALTER COLUMN "id" DROP IDENTITY IF EXISTS;`)
+	setDefault := strings.Index(script, `ALTER TABLE "public"."users" ALTER COLUMN "id" SET DEFAULT nextval(`)
+	if create < 0 || drop < create || setDefault < drop {
+		t.Errorf("Expected the identity to be dropped before the sequence default is set, got:\n%s", script)
+	}
+}
+
+func TestProject_GeneratePostgresSQL_TableComment(t *testing.T) {
+	table := NewTable("users").
+		WithUnifiedComment("Registered users").
+		AddColumn(NewColumn("id", "int"))
+	project := NewProject("test").AddTable(table)
+
+	if table.Note == nil || *table.Note != "Registered users" {
+		t.Errorf("Expected note 'Registered users', got %v", table.Note)
+	}
+
+	output := project.GeneratePostgresSQL()
+	want := "COMMENT ON TABLE \"public\".\"users\" IS 'Registered users';\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+	}
+	if !strings.Contains(project.Generate(), "Note: 'Registered users'") {
+		t.Errorf("Expected DBML output to contain the note, got:\n%s", project.Generate())
+	}
+
+	if strings.Contains(NewProject("test").AddTable(NewTable("t").WithNote("Only DBML")).GeneratePostgresSQL(), "COMMENT ON TABLE") {
+		t.Error("Expected no COMMENT ON TABLE for a note without SQL comment")
+	}
+}
+
+func TestProject_GenerateSQL_ExcludeFromDDL(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey().WithIncrement())).
+		AddTable(NewTable("flyway_schema_history").WithExcludeFromDDL().
+			AddColumn(NewColumn("installed_rank", "int").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "public", "users", "id")))
+
+	if tables := project.GetDDLTables(); len(tables) != 1 || tables[0].Name != "users" {
+		t.Errorf("Expected only users to be DDL-eligible, got %v", tables)
+	}
+
+	for name, output := range map[string]string{
+		"sql":       project.GenerateSQL(DialectPostgres),
+		"sequences": project.GenerateSequences(DialectPostgres),
+	} {
+		if !strings.Contains(output, `"public"."users"`) {
+			t.Errorf("Expected %s output to include users, got:\n%s", name, output)
+		}
+		if strings.Contains(output, "flyway_schema_history") {
+			t.Errorf("Expected %s output to skip the excluded table, got:\n%s", name, output)
+		}
+	}
+
+	if !strings.Contains(project.Generate(), "Table flyway_schema_history {") {
+		t.Error("Expected excluded table to still be generated as DBML")
+	}
+}
+
+func TestProject_GenerateSQL_TypeAlias(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("payments").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("amount", "money_amount").WithTypeAlias("decimal(19,4)")))
+
+	if sql := project.GenerateSQL(DialectPostgres); !strings.Contains(sql, `"amount" decimal(19,4) NOT NULL`) {
+		t.Errorf("Expected SQL to use the type alias, got:\n%s", sql)
+	}
+	if dbml := project.Generate(); !strings.Contains(dbml, "amount money_amount [not null]") {
+		t.Errorf("Expected DBML to keep the domain type, got:\n%s", dbml)
+	}
+	if clone := project.Clone(); !clone.Equal(project) || *clone.Tables["public.payments"].Columns[1].TypeAlias != "decimal(19,4)" {
+		t.Error("Expected clone to keep the type alias")
+	}
+}
@@ -0,0 +1,263 @@
+package dbml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// typeArgsPattern extracts the arguments of types like varchar(255) or numeric(10, 2).
+var typeArgsPattern = regexp.MustCompile(`\(([^)]*)\)`)
+
+// GenerateSQLAlchemyModels generates Python SQLAlchemy declarative models from
+// a Project. Foreign keys become ForeignKey column arguments (or a
+// ForeignKeyConstraint for composite keys) plus relationship() pairs on both
+// models. Defaults are emitted verbatim as server defaults.
+func (p *Project) GenerateSQLAlchemyModels() string {
+	imports := map[string]bool{"Column": true}
+
+	relations := map[string][]ormRelation{}
+	inverse := map[string][]ormRelation{}
+	for _, rel := range p.ormRelations() {
+		relations[rel.childKey] = append(relations[rel.childKey], rel)
+		inverse[rel.parentKey] = append(inverse[rel.parentKey], rel)
+	}
+
+	var body strings.Builder
+	for _, key := range sortedKeys(p.Tables) {
+		body.WriteString("\n\n")
+		body.WriteString(p.sqlalchemyModel(p.Tables[key], relations[key], inverse[key], imports))
+	}
+
+	var b strings.Builder
+	b.WriteString("# Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("from sqlalchemy import %s\n", strings.Join(sortedKeys(imports), ", ")))
+	b.WriteString("from sqlalchemy.orm import declarative_base, relationship\n\n")
+	b.WriteString("Base = declarative_base()\n")
+	b.WriteString(body.String())
+
+	return b.String()
+}
+
+func (p *Project) sqlalchemyModel(t *Table, relations, inverse []ormRelation, imports map[string]bool) string {
+	var b strings.Builder
+
+	className := goStructName(t.Name)
+	pkColumns := t.primaryKeyColumns()
+
+	// Single-column foreign keys are declared on the column itself
+	columnFKs := map[string]string{}
+	tableArgs := []string{}
+	for _, rel := range relations {
+		if len(rel.child.Columns) == 1 {
+			columnFKs[rel.child.Columns[0]] = sqlalchemyForeignKeyTarget(rel.parent, rel.parent.Columns[0], rel.ref, p.sqlDialect())
+			continue
+		}
+		imports["ForeignKeyConstraint"] = true
+		targets := make([]string, len(rel.parent.Columns))
+		for i, col := range rel.parent.Columns {
+			targets[i] = strconv.Quote(sqlalchemyQualifiedColumn(rel.parent, col))
+		}
+		tableArgs = append(tableArgs, fmt.Sprintf("ForeignKeyConstraint([%s], [%s]%s)",
+			sqlalchemyQuoteList(rel.child.Columns), strings.Join(targets, ", "), sqlalchemyActions(rel.ref, p.sqlDialect())))
+	}
+	if t.Schema != defaultSchemaName {
+		tableArgs = append(tableArgs, fmt.Sprintf("{%q: %q}", "schema", t.Schema))
+	}
+
+	b.WriteString(fmt.Sprintf("class %s(Base):\n", className))
+	b.WriteString(fmt.Sprintf("    __tablename__ = %q\n", t.Name))
+	switch {
+	case len(tableArgs) == 1 && t.Schema != defaultSchemaName:
+		b.WriteString(fmt.Sprintf("    __table_args__ = %s\n", tableArgs[0]))
+	case len(tableArgs) > 0:
+		b.WriteString(fmt.Sprintf("    __table_args__ = (%s,)\n", strings.Join(tableArgs, ", ")))
+	}
+	b.WriteString("\n")
+
+	for _, col := range t.Columns {
+		isPK := false
+		for _, name := range pkColumns {
+			if name == col.Name {
+				isPK = true
+			}
+		}
+		b.WriteString(fmt.Sprintf("    %s = %s\n", col.Name, p.sqlalchemyColumn(col, isPK, columnFKs[col.Name], imports)))
+	}
+
+	if len(relations) > 0 || len(inverse) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, rel := range relations {
+		args := []string{strconv.Quote(goStructName(rel.parent.Table)), fmt.Sprintf("back_populates=%q", rel.backField)}
+		if rel.ambiguous {
+			args = append(args, fmt.Sprintf("foreign_keys=[%s]", strings.Join(rel.child.Columns, ", ")))
+		}
+		if rel.childKey == rel.parentKey {
+			args = append(args, fmt.Sprintf("remote_side=[%s]", strings.Join(rel.parent.Columns, ", ")))
+		}
+		b.WriteString(fmt.Sprintf("    %s = relationship(%s)\n", rel.field, strings.Join(args, ", ")))
+	}
+
+	for _, rel := range inverse {
+		childClass := goStructName(rel.child.Table)
+		args := []string{strconv.Quote(childClass), fmt.Sprintf("back_populates=%q", rel.field)}
+		if rel.ambiguous {
+			qualified := make([]string, len(rel.child.Columns))
+			for i, col := range rel.child.Columns {
+				qualified[i] = childClass + "." + col
+			}
+			args = append(args, fmt.Sprintf("foreign_keys=%q", "["+strings.Join(qualified, ", ")+"]"))
+		}
+		if rel.ref.Type == OneToOne {
+			args = append(args, "uselist=False")
+		}
+		b.WriteString(fmt.Sprintf("    %s = relationship(%s)\n", rel.backField, strings.Join(args, ", ")))
+	}
+
+	return b.String()
+}
+
+func (p *Project) sqlalchemyColumn(c *Column, isPK bool, foreignKey string, imports map[string]bool) string {
+	args := []string{p.sqlalchemyType(c, imports)}
+
+	if foreignKey != "" {
+		imports["ForeignKey"] = true
+		args = append(args, foreignKey)
+	}
+	if isPK {
+		args = append(args, "primary_key=True")
+	}
+	if c.Settings != nil {
+		if c.Settings.Increment {
+			args = append(args, "autoincrement=True")
+		}
+		if !c.Settings.Null && !isPK {
+			args = append(args, "nullable=False")
+		}
+		if c.Settings.Unique && !isPK {
+			args = append(args, "unique=True")
+		}
+		if c.Settings.Default != nil && !c.Settings.Increment {
+			imports["text"] = true
+			args = append(args, fmt.Sprintf("server_default=text(%q)", sqlDefault(*c.Settings.Default, c.Settings.DefaultKind)))
+		}
+	}
+	if c.Note != nil {
+		args = append(args, fmt.Sprintf("comment=%q", *c.Note))
+	}
+
+	return "Column(" + strings.Join(args, ", ") + ")"
+}
+
+func (p *Project) sqlalchemyType(c *Column, imports map[string]bool) string {
+	typ := p.sqlalchemyColumnType(c)
+
+	imports[typ.name] = true
+	call := typ.name
+	if typ.args != "" {
+		call += "(" + typ.args + ")"
+	}
+
+	if typ.array {
+		imports["ARRAY"] = true
+		return "ARRAY(" + call + ")"
+	}
+	return call
+}
+
+// sqlalchemyColumnType is a SQLAlchemy type name with its constructor
+// arguments, shared by the model and Alembic generators.
+type sqlalchemyColumnType struct {
+	name  string
+	args  string
+	array bool
+}
+
+func (p *Project) sqlalchemyColumnType(c *Column) sqlalchemyColumnType {
+	base, isArray := baseSQLType(c.Type)
+
+	if enum := p.columnEnum(c); enum != nil {
+		return sqlalchemyColumnType{
+			name:  "Enum",
+			args:  fmt.Sprintf("%s, name=%q", sqlalchemyQuoteList(enum.Values), enum.Name),
+			array: isArray,
+		}
+	}
+
+	name, args := sqlalchemyTypeName(base), ""
+	if match := typeArgsPattern.FindStringSubmatch(c.Type); match != nil && (name == "String" || name == "Numeric") {
+		args = strings.Join(strings.Fields(strings.ReplaceAll(match[1], ",", ", ")), " ")
+	}
+	if base == "timestamptz" || base == "timestamp with time zone" {
+		args = "timezone=True"
+	}
+	return sqlalchemyColumnType{name: name, args: args, array: isArray}
+}
+
+func sqlalchemyTypeName(base string) string {
+	switch base {
+	case "bigint", "int8", "bigserial", "serial8":
+		return "BigInteger"
+	case "int", "integer", "int4", "serial", "serial4", "mediumint":
+		return "Integer"
+	case "smallint", "int2", "smallserial", "serial2", "tinyint":
+		return "SmallInteger"
+	case "boolean", "bool":
+		return "Boolean"
+	case "real", "float4", "float", "double", "double precision", "float8":
+		return "Float"
+	case "numeric", "decimal", "money":
+		return "Numeric"
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone", "datetime":
+		return "DateTime"
+	case "date":
+		return "Date"
+	case "time", "timetz":
+		return "Time"
+	case "text", "longtext", "mediumtext", "tinytext":
+		return "Text"
+	case "json", "jsonb":
+		return "JSON"
+	case "uuid":
+		return "Uuid"
+	case "bytea", "blob", "binary", "varbinary":
+		return "LargeBinary"
+	default:
+		return "String"
+	}
+}
+
+func sqlalchemyForeignKeyTarget(parent *RefEndpoint, column string, ref *Ref, dialect SQLDialect) string {
+	return fmt.Sprintf("ForeignKey(%q%s)", sqlalchemyQualifiedColumn(parent, column), sqlalchemyActions(ref, dialect))
+}
+
+// sqlalchemyQualifiedColumn returns table.column, prefixed with the schema
+// outside the default schema.
+func sqlalchemyQualifiedColumn(endpoint *RefEndpoint, column string) string {
+	if endpoint.Schema != defaultSchemaName {
+		return endpoint.Schema + "." + endpoint.Table + "." + column
+	}
+	return endpoint.Table + "." + column
+}
+
+func sqlalchemyActions(ref *Ref, dialect SQLDialect) string {
+	var b strings.Builder
+	if ref.OnDelete != nil {
+		b.WriteString(fmt.Sprintf(", ondelete=%q", ref.OnDelete.SQL(dialect)))
+	}
+	if ref.OnUpdate != nil {
+		b.WriteString(fmt.Sprintf(", onupdate=%q", ref.OnUpdate.SQL(dialect)))
+	}
+	return b.String()
+}
+
+func sqlalchemyQuoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,82 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateSQLAlchemyModels(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique().WithNote("Login name")).
+			AddColumn(NewColumn("created_at", "timestamptz").WithDefault("now()"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "post_status").WithDefault("'draft'")).
+			AddColumn(NewColumn("price", "numeric(10,2)").WithNull()).
+			AddColumn(NewColumn("tags", "text[]").WithNull())).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id").WithOnDelete(Cascade))
+
+	output := project.GenerateSQLAlchemyModels()
+
+	expected := []string{
+		"from sqlalchemy import ARRAY, BigInteger, Column, DateTime, Enum, ForeignKey, Integer, Numeric, String, Text, text\n",
+		"from sqlalchemy.orm import declarative_base, relationship\n",
+		"Base = declarative_base()\n\n\nclass Post(Base):\n    __tablename__ = \"posts\"\n",
+		"    id = Column(Integer, primary_key=True)\n",
+		"    user_id = Column(BigInteger, ForeignKey(\"users.id\", ondelete=\"CASCADE\"), nullable=False)\n",
+		"    status = Column(Enum(\"draft\", \"published\", name=\"post_status\"), nullable=False, server_default=text(\"'draft'\"))\n",
+		"    price = Column(Numeric(10, 2))\n",
+		"    tags = Column(ARRAY(Text))\n",
+		"    user = relationship(\"User\", back_populates=\"posts\")\n",
+		"class User(Base):\n    __tablename__ = \"users\"\n",
+		"    id = Column(BigInteger, primary_key=True, autoincrement=True)\n",
+		"    email = Column(String(255), nullable=False, unique=True, comment=\"Login name\")\n",
+		"    created_at = Column(DateTime(timezone=True), nullable=False, server_default=text(\"now()\"))\n",
+		"    posts = relationship(\"Post\", back_populates=\"user\")\n",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestProject_GenerateSQLAlchemyModels_SchemasAndCompositeKeys(t *testing.T) {
+	project := NewProject("org").
+		AddTable(NewTable("employees").WithSchema("hr").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("manager_id", "int").WithNull())).
+		AddTable(NewTable("regions").
+			AddColumn(NewColumn("country", "char(2)")).
+			AddColumn(NewColumn("code", "varchar")).
+			AddIndex(NewIndex("country", "code").WithPrimaryKey())).
+		AddTable(NewTable("offices").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("country", "char(2)")).
+			AddColumn(NewColumn("region_code", "varchar"))).
+		AddRef(NewRef(ManyToOne).From("hr", "employees", "manager_id").To("hr", "employees", "id")).
+		AddRef(NewRef(ManyToOne).From("public", "offices", "country", "region_code").To("public", "regions", "country", "code"))
+
+	output := project.GenerateSQLAlchemyModels()
+
+	expected := []string{
+		"    __table_args__ = {\"schema\": \"hr\"}\n",
+		"    manager_id = Column(Integer, ForeignKey(\"hr.employees.id\"))\n",
+		"    manager = relationship(\"Employee\", back_populates=\"employees_manager\", foreign_keys=[manager_id], remote_side=[id])\n",
+		"    employees_manager = relationship(\"Employee\", back_populates=\"manager\", foreign_keys=\"[Employee.manager_id]\")\n",
+		"    __table_args__ = (ForeignKeyConstraint([\"country\", \"region_code\"], [\"regions.country\", \"regions.code\"]),)\n",
+		"    country = Column(String(2), primary_key=True)\n",
+		"    region = relationship(\"Region\", back_populates=\"offices\")\n",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package dbml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GenerateSQLCQueries generates a sqlc-compatible queries.sql file with
+// Get, List, Create, Update and Delete queries for every table.
+// Tables without a primary key only get List and Create queries.
+func (p *Project) GenerateSQLCQueries() string {
+	var b strings.Builder
+
+	for i, key := range sortedKeys(p.Tables) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(p.Tables[key].sqlcQueries())
+	}
+
+	return b.String()
+}
+
+func (t *Table) sqlcQueries() string {
+	var b strings.Builder
+
+	tableName := t.Name
+	if t.Schema != defaultSchema {
+		tableName = t.Schema + "." + t.Name
+	}
+	singular := goStructName(t.Name)
+	plural := goExportedName(t.Name)
+	pkColumns := t.primaryKeyColumns()
+
+	where := make([]string, len(pkColumns))
+	for i, col := range pkColumns {
+		where[i] = fmt.Sprintf("%s = $%d", col, i+1)
+	}
+
+	if len(pkColumns) > 0 {
+		b.WriteString(fmt.Sprintf("-- name: Get%sBy%s :one\n", singular, sqlcKeyName(pkColumns)))
+		b.WriteString(fmt.Sprintf("SELECT * FROM %s\nWHERE %s LIMIT 1;\n\n", tableName, strings.Join(where, " AND ")))
+	}
+
+	b.WriteString(fmt.Sprintf("-- name: List%s :many\n", plural))
+	b.WriteString(fmt.Sprintf("SELECT * FROM %s", tableName))
+	if len(pkColumns) > 0 {
+		b.WriteString("\nORDER BY " + strings.Join(pkColumns, ", "))
+	}
+	b.WriteString(";\n\n")
+
+	insertColumns := []string{}
+	placeholders := []string{}
+	for _, col := range t.Columns {
+		if col.Settings != nil && (col.Settings.Increment || col.Settings.DatabaseDefault) {
+			continue
+		}
+		insertColumns = append(insertColumns, col.Name)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(placeholders)+1))
+	}
+	b.WriteString(fmt.Sprintf("-- name: Create%s :exec\n", singular))
+	b.WriteString(fmt.Sprintf("INSERT INTO %s (%s)\nVALUES (%s);\n", tableName,
+		strings.Join(insertColumns, ", "), strings.Join(placeholders, ", ")))
+
+	if len(pkColumns) == 0 {
+		return b.String()
+	}
+
+	assignments := []string{}
+	for _, col := range t.Columns {
+		if slices.Contains(pkColumns, col.Name) {
+			continue
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", col.Name, len(pkColumns)+len(assignments)+1))
+	}
+	if len(assignments) > 0 {
+		b.WriteString(fmt.Sprintf("\n-- name: Update%s :exec\n", singular))
+		b.WriteString(fmt.Sprintf("UPDATE %s\nSET %s\nWHERE %s;\n", tableName,
+			strings.Join(assignments, ", "), strings.Join(where, " AND ")))
+	}
+
+	b.WriteString(fmt.Sprintf("\n-- name: Delete%s :exec\n", singular))
+	b.WriteString(fmt.Sprintf("DELETE FROM %s\nWHERE %s;\n", tableName, strings.Join(where, " AND ")))
+
+	return b.String()
+}
+
+func sqlcKeyName(columns []string) string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = goExportedName(col)
+	}
+	return strings.Join(names, "And")
+}
@@ -0,0 +1,38 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateSQLCQueries(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)")).
+			AddColumn(NewColumn("name", "varchar"))).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("payload", "jsonb")))
+
+	output := project.GenerateSQLCQueries()
+
+	expected := []string{
+		"-- name: GetUserByID :one\nSELECT * FROM users\nWHERE id = $1 LIMIT 1;",
+		"-- name: ListUsers :many\nSELECT * FROM users\nORDER BY id;",
+		"-- name: CreateUser :exec\nINSERT INTO users (email, name)\nVALUES ($1, $2);",
+		"-- name: UpdateUser :exec\nUPDATE users\nSET email = $2, name = $3\nWHERE id = $1;",
+		"-- name: DeleteUser :exec\nDELETE FROM users\nWHERE id = $1;",
+		"-- name: ListEvents :many\nSELECT * FROM audit.events;",
+		"-- name: CreateEvent :exec\nINSERT INTO audit.events (payload)\nVALUES ($1);",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "GetEvent") || strings.Contains(output, "DeleteEvent") {
+		t.Errorf("Expected no key-based queries for table without primary key, got:\n%s", output)
+	}
+}
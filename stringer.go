@@ -0,0 +1,62 @@
+package dbml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns a one-line summary such as `Table public.users [4 columns, 2 indexes]`.
+func (t *Table) String() string {
+	return fmt.Sprintf("Table %s.%s [%s, %s]", t.Schema, t.Name,
+		pluralize(len(t.Columns), "column"), pluralize(len(t.Indexes), "index"))
+}
+
+// String returns the column's DBML definition, such as `id bigint [pk, not null]`.
+func (c *Column) String() string {
+	return c.Generate()
+}
+
+// String returns a one-line form of the ref with schema-qualified endpoints,
+// such as `Ref: public.posts.user_id > public.users.id [delete: cascade]`.
+func (r *Ref) String() string {
+	var b strings.Builder
+
+	b.WriteString("Ref")
+	if r.Name != nil {
+		b.WriteString(" " + *r.Name)
+	}
+	b.WriteString(fmt.Sprintf(": %s %s %s", qualifiedRefEndpoint(r.Left), r.Type, qualifiedRefEndpoint(r.Right)))
+
+	settings := []string{}
+	if r.OnDelete != nil {
+		settings = append(settings, "delete: "+r.OnDelete.DBML())
+	}
+	if r.OnUpdate != nil {
+		settings = append(settings, "update: "+r.OnUpdate.DBML())
+	}
+	if len(settings) > 0 {
+		b.WriteString(" [" + strings.Join(settings, ", ") + "]")
+	}
+
+	return b.String()
+}
+
+func qualifiedRefEndpoint(endpoint *RefEndpoint) string {
+	if endpoint == nil {
+		return "?"
+	}
+	if len(endpoint.Columns) == 1 {
+		return fmt.Sprintf("%s.%s.%s", endpoint.Schema, endpoint.Table, endpoint.Columns[0])
+	}
+	return fmt.Sprintf("%s.%s.(%s)", endpoint.Schema, endpoint.Table, strings.Join(endpoint.Columns, ", "))
+}
+
+func pluralize(n int, singular string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", singular)
+	}
+	if strings.HasSuffix(singular, "x") {
+		return fmt.Sprintf("%d %ses", n, singular)
+	}
+	return fmt.Sprintf("%d %ss", n, singular)
+}
@@ -0,0 +1,59 @@
+package dbml
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTable_String(t *testing.T) {
+	table := NewTable("users").
+		AddColumn(NewColumn("id", "bigint")).
+		AddColumn(NewColumn("email", "varchar")).
+		AddIndex(NewIndex("email"))
+
+	expected := "Table public.users [2 columns, 1 index]"
+	if got := fmt.Sprint(table); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+
+	table.AddIndex(NewIndex("id"))
+	expected = "Table public.users [2 columns, 2 indexes]"
+	if got := table.String(); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestColumn_String(t *testing.T) {
+	col := NewColumn("id", "bigint").WithPrimaryKey()
+
+	expected := "id bigint [pk, not null]"
+	if got := fmt.Sprintf("%v", col); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestRef_String(t *testing.T) {
+	t.Run("with action", func(t *testing.T) {
+		ref := NewRef(ManyToOne).
+			From("public", "posts", "user_id").
+			To("public", "users", "id").
+			WithOnDelete(Cascade)
+
+		expected := "Ref: public.posts.user_id > public.users.id [delete: cascade]"
+		if got := ref.String(); got != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, got)
+		}
+	})
+
+	t.Run("named composite", func(t *testing.T) {
+		ref := NewRef(OneToMany).
+			WithName("fk_items").
+			From("sales", "orders", "id", "region").
+			To("sales", "items", "order_id", "region")
+
+		expected := "Ref fk_items: sales.orders.(id, region) < sales.items.(order_id, region)"
+		if got := ref.String(); got != expected {
+			t.Errorf("Expected '%s', got '%s'", expected, got)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package dbml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// supabaseEmptySection is how Supabase's generator writes an empty section.
+const supabaseEmptySection = "{\n      [_ in never]: never\n    }\n"
+
+// GenerateSupabaseTypes generates TypeScript types in the format of
+// `supabase gen types typescript`. Every schema gets Tables with Row,
+// Insert, Update and Relationships types, and Enums. Insert fields are
+// optional when the column is nullable, has a default or is generated;
+// all Update fields are optional.
+func (p *Project) GenerateSupabaseTypes() string {
+	var b strings.Builder
+
+	b.WriteString("export type Json =\n")
+	b.WriteString("  | string\n  | number\n  | boolean\n  | null\n")
+	b.WriteString("  | { [key: string]: Json | undefined }\n  | Json[]\n\n")
+
+	schemas := map[string]bool{}
+	tables := map[string][]*Table{}
+	enums := map[string][]*Enum{}
+	for _, key := range sortedKeys(p.Tables) {
+		table := p.Tables[key]
+		schemas[table.Schema] = true
+		tables[table.Schema] = append(tables[table.Schema], table)
+	}
+	for _, key := range sortedKeys(p.Enums) {
+		enum := p.Enums[key]
+		schemas[enum.Schema] = true
+		enums[enum.Schema] = append(enums[enum.Schema], enum)
+	}
+
+	relationships := map[string][]ormRelation{}
+	for _, rel := range p.ormRelations() {
+		relationships[rel.childKey] = append(relationships[rel.childKey], rel)
+	}
+
+	b.WriteString("export type Database = {\n")
+	for _, schema := range sortedKeys(schemas) {
+		b.WriteString(fmt.Sprintf("  %s: {\n", supabaseKey(schema)))
+
+		b.WriteString("    Tables: ")
+		if len(tables[schema]) == 0 {
+			b.WriteString(supabaseEmptySection)
+		} else {
+			b.WriteString("{\n")
+			for _, table := range tables[schema] {
+				b.WriteString(p.supabaseTable(table, relationships[table.Schema+"."+table.Name]))
+			}
+			b.WriteString("    }\n")
+		}
+
+		b.WriteString("    Views: " + supabaseEmptySection)
+		b.WriteString("    Functions: " + supabaseEmptySection)
+
+		b.WriteString("    Enums: ")
+		if len(enums[schema]) == 0 {
+			b.WriteString(supabaseEmptySection)
+		} else {
+			b.WriteString("{\n")
+			for _, enum := range enums[schema] {
+				values := make([]string, len(enum.Values))
+				for i, value := range enum.Values {
+					values[i] = strconv.Quote(value)
+				}
+				b.WriteString(fmt.Sprintf("      %s: %s\n", supabaseKey(enum.Name), strings.Join(values, " | ")))
+			}
+			b.WriteString("    }\n")
+		}
+
+		b.WriteString("    CompositeTypes: " + supabaseEmptySection)
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (p *Project) supabaseTable(t *Table, relationships []ormRelation) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("      %s: {\n", supabaseKey(t.Name)))
+
+	for _, section := range []string{"Row", "Insert", "Update"} {
+		b.WriteString(fmt.Sprintf("        %s: {\n", section))
+		for _, col := range t.Columns {
+			typ := p.supabaseType(col)
+			nullable := col.Settings != nil && col.Settings.Null && !col.Settings.PrimaryKey
+			if nullable {
+				typ += " | null"
+			}
+
+			optional := ""
+			switch section {
+			case "Insert":
+				if col.ComputedAlias != nil {
+					typ = "never"
+				}
+				if nullable || col.ComputedAlias != nil || (col.Settings != nil &&
+					(col.Settings.Default != nil || col.Settings.Increment || col.Settings.DatabaseDefault)) {
+					optional = "?"
+				}
+			case "Update":
+				if col.ComputedAlias != nil {
+					typ = "never"
+				}
+				optional = "?"
+			}
+			b.WriteString(fmt.Sprintf("          %s%s: %s\n", supabaseKey(col.Name), optional, typ))
+		}
+		b.WriteString("        }\n")
+	}
+
+	if len(relationships) == 0 {
+		b.WriteString("        Relationships: []\n")
+	} else {
+		b.WriteString("        Relationships: [\n")
+		for _, rel := range relationships {
+			b.WriteString("          {\n")
+			b.WriteString(fmt.Sprintf("            foreignKeyName: %q\n", rel.ref.foreignKeyName(rel.child)))
+			b.WriteString(fmt.Sprintf("            columns: [%s]\n", supabaseQuoteList(rel.child.Columns)))
+			b.WriteString(fmt.Sprintf("            isOneToOne: %t\n", rel.ref.Type == OneToOne))
+			b.WriteString(fmt.Sprintf("            referencedRelation: %q\n", rel.parent.Table))
+			b.WriteString(fmt.Sprintf("            referencedColumns: [%s]\n", supabaseQuoteList(rel.parent.Columns)))
+			b.WriteString("          },\n")
+		}
+		b.WriteString("        ]\n")
+	}
+
+	b.WriteString("      }\n")
+
+	return b.String()
+}
+
+// supabaseType maps a column to the TypeScript type Supabase generates for it.
+func (p *Project) supabaseType(c *Column) string {
+	base, isArray := baseSQLType(c.Type)
+
+	var typ string
+	if enum := p.columnEnum(c); enum != nil {
+		typ = fmt.Sprintf("Database[%q][\"Enums\"][%q]", enum.Schema, enum.Name)
+	} else {
+		switch base {
+		case "int", "integer", "int2", "int4", "int8", "smallint", "bigint", "serial", "serial2", "serial4",
+			"serial8", "smallserial", "bigserial", "mediumint", "tinyint", "real", "float", "float4", "float8",
+			"double", "double precision", "numeric", "decimal":
+			typ = "number"
+		case "boolean", "bool":
+			typ = "boolean"
+		case "json", "jsonb":
+			typ = "Json"
+		default:
+			typ = "string"
+		}
+	}
+
+	if isArray {
+		return typ + "[]"
+	}
+	return typ
+}
+
+// supabaseKey quotes object keys that are not valid TypeScript identifiers.
+func supabaseKey(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func supabaseQuoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return strings.Join(quoted, ", ")
+}
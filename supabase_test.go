@@ -0,0 +1,53 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateSupabaseTypes(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)")).
+			AddColumn(NewColumn("name", "text").WithNull()).
+			AddColumn(NewColumn("settings", "jsonb").WithDefault("'{}'"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "uuid").WithPrimaryKey().WithDefault("gen_random_uuid()")).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "post_status")).
+			AddColumn(NewColumn("tags", "text[]").WithNull()).
+			AddColumn(NewColumn("slug", "text").WithComputedAlias("lower(title)"))).
+		AddTable(NewTable("events").WithSchema("audit").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id"))
+
+	output := project.GenerateSupabaseTypes()
+
+	expected := []string{
+		"export type Json =\n  | string\n",
+		"export type Database = {\n  audit: {\n    Tables: {\n      events: {\n",
+		"        Relationships: []\n",
+		"    Views: {\n      [_ in never]: never\n    }\n",
+		"    Enums: {\n      [_ in never]: never\n    }\n",
+		"  public: {\n    Tables: {\n      posts: {\n",
+		"        Row: {\n          id: string\n          user_id: number\n" +
+			"          status: Database[\"public\"][\"Enums\"][\"post_status\"]\n          tags: string[] | null\n          slug: string\n        }\n",
+		"        Insert: {\n          id?: string\n          user_id: number\n" +
+			"          status: Database[\"public\"][\"Enums\"][\"post_status\"]\n          tags?: string[] | null\n          slug?: never\n        }\n",
+		"        Update: {\n          id?: string\n          user_id?: number\n",
+		"        Relationships: [\n          {\n            foreignKeyName: \"fk_posts_user_id\"\n" +
+			"            columns: [\"user_id\"]\n            isOneToOne: false\n" +
+			"            referencedRelation: \"users\"\n            referencedColumns: [\"id\"]\n          },\n        ]\n",
+		"        Insert: {\n          id?: number\n          email: string\n          name?: string | null\n          settings?: Json\n        }\n",
+		"    Enums: {\n      post_status: \"draft\" | \"published\"\n    }\n",
+		"    CompositeTypes: {\n      [_ in never]: never\n    }\n  }\n}\n",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package dbml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// varcharLengthPattern extracts the length argument of types like varchar(255).
+var varcharLengthPattern = regexp.MustCompile(`^\s*(?i:varchar|character varying|char|character)\s*\(\s*(\d+)\s*\)`)
+
+// GenerateTypeORMEntities generates TypeScript TypeORM entity classes from a
+// Project. Properties keep their column names; foreign keys become
+// @ManyToOne/@OneToMany (or @OneToOne) pairs on both entities.
+func (p *Project) GenerateTypeORMEntities() string {
+	var body strings.Builder
+
+	decorators := map[string]bool{"Entity": true}
+	relations := map[string][]ormRelation{}
+	inverse := map[string][]ormRelation{}
+	for _, rel := range p.ormRelations() {
+		relations[rel.childKey] = append(relations[rel.childKey], rel)
+		inverse[rel.parentKey] = append(inverse[rel.parentKey], rel)
+	}
+
+	for _, key := range sortedKeys(p.Tables) {
+		body.WriteString("\n")
+		body.WriteString(p.typeormEntity(p.Tables[key], relations[key], inverse[key], decorators))
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by dbml. DO NOT EDIT.\n\n")
+	b.WriteString(fmt.Sprintf("import { %s } from \"typeorm\";\n", strings.Join(sortedKeys(decorators), ", ")))
+	b.WriteString(body.String())
+
+	return b.String()
+}
+
+func (p *Project) typeormEntity(t *Table, relations, inverse []ormRelation, decorators map[string]bool) string {
+	var b strings.Builder
+
+	className := goStructName(t.Name)
+	pkColumns := t.primaryKeyColumns()
+
+	b.WriteString(fmt.Sprintf("@Entity({ name: %q, schema: %q })\n", t.Name, t.Schema))
+	b.WriteString(fmt.Sprintf("export class %s {\n", className))
+
+	members := []string{}
+	for _, col := range t.Columns {
+		isPK := false
+		for _, name := range pkColumns {
+			if name == col.Name {
+				isPK = true
+			}
+		}
+		members = append(members, p.typeormColumn(col, isPK, decorators))
+	}
+
+	for _, rel := range relations {
+		parentClass := goStructName(rel.parent.Table)
+		decorator := "ManyToOne"
+		if rel.ref.Type == OneToOne {
+			decorator = "OneToOne"
+		}
+		decorators[decorator] = true
+		decorators["JoinColumn"] = true
+
+		options := []string{}
+		if rel.ref.OnDelete != nil {
+			options = append(options, fmt.Sprintf("onDelete: %q", rel.ref.OnDelete.SQL(p.sqlDialect())))
+		}
+		if rel.ref.OnUpdate != nil {
+			options = append(options, fmt.Sprintf("onUpdate: %q", rel.ref.OnUpdate.SQL(p.sqlDialect())))
+		}
+		if !rel.nullable {
+			options = append(options, "nullable: false")
+		}
+
+		param := goParamName(parentClass)
+		var m strings.Builder
+		m.WriteString(fmt.Sprintf("  @%s(() => %s, (%s) => %s.%s", decorator, parentClass, param, param, rel.backField))
+		if len(options) > 0 {
+			m.WriteString(", { " + strings.Join(options, ", ") + " }")
+		}
+		m.WriteString(")\n")
+		m.WriteString("  @JoinColumn(" + typeormJoinColumns(rel) + ")\n")
+		propType := parentClass
+		if rel.nullable {
+			propType += " | null"
+		}
+		m.WriteString(fmt.Sprintf("  %s: %s;\n", rel.field, propType))
+		members = append(members, m.String())
+	}
+
+	for _, rel := range inverse {
+		childClass := goStructName(rel.child.Table)
+		decorator, propType := "OneToMany", childClass+"[]"
+		if rel.ref.Type == OneToOne {
+			decorator, propType = "OneToOne", childClass+" | null"
+		}
+		decorators[decorator] = true
+
+		param := goParamName(childClass)
+		members = append(members, fmt.Sprintf("  @%s(() => %s, (%s) => %s.%s)\n  %s: %s;\n",
+			decorator, childClass, param, param, rel.field, rel.backField, propType))
+	}
+
+	b.WriteString(strings.Join(members, "\n"))
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (p *Project) typeormColumn(c *Column, isPK bool, decorators map[string]bool) string {
+	base, isArray := baseSQLType(c.Type)
+	enum := p.columnEnum(c)
+
+	options := []string{}
+	tsType := typeormTSType(base)
+	if enum != nil {
+		values := make([]string, len(enum.Values))
+		for i, value := range enum.Values {
+			values[i] = strconv.Quote(value)
+		}
+		options = append(options, `type: "enum"`, fmt.Sprintf("enum: [%s]", strings.Join(values, ", ")))
+		tsType = strings.Join(values, " | ")
+	} else {
+		options = append(options, fmt.Sprintf("type: %q", base))
+		if match := varcharLengthPattern.FindStringSubmatch(c.Type); match != nil {
+			options = append(options, "length: "+match[1])
+		}
+	}
+	if isArray {
+		options = append(options, "array: true")
+		if enum != nil {
+			tsType = "(" + tsType + ")"
+		}
+		tsType += "[]"
+	}
+
+	decorator := "Column"
+	if isPK {
+		decorator = "PrimaryColumn"
+		if c.Settings != nil && c.Settings.Increment {
+			decorator = "PrimaryGeneratedColumn"
+		}
+	}
+	decorators[decorator] = true
+
+	if c.Settings != nil {
+		if c.Settings.Null && !isPK {
+			options = append(options, "nullable: true")
+			tsType += " | null"
+		}
+		if c.Settings.Unique && !isPK {
+			options = append(options, "unique: true")
+		}
+		if c.Settings.Default != nil && !c.Settings.Increment {
+			options = append(options, "default: "+typeormDefault(sqlDefault(*c.Settings.Default, c.Settings.DefaultKind), c.Settings.DatabaseDefault))
+		}
+	}
+
+	return fmt.Sprintf("  @%s({ %s })\n  %s: %s;\n", decorator, strings.Join(options, ", "), c.Name, tsType)
+}
+
+func typeormJoinColumns(rel ormRelation) string {
+	columns := make([]string, len(rel.child.Columns))
+	for i, col := range rel.child.Columns {
+		referenced := ""
+		if i < len(rel.parent.Columns) {
+			referenced = rel.parent.Columns[i]
+		}
+		columns[i] = fmt.Sprintf("{ name: %q, referencedColumnName: %q }", col, referenced)
+	}
+	if len(columns) == 1 {
+		return columns[0]
+	}
+	return "[" + strings.Join(columns, ", ") + "]"
+}
+
+func typeormTSType(base string) string {
+	switch base {
+	case "int", "integer", "int4", "serial", "serial4", "smallint", "int2", "smallserial", "serial2",
+		"mediumint", "tinyint", "real", "float4", "float", "double", "double precision", "float8":
+		return "number"
+	case "boolean", "bool":
+		return "boolean"
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone",
+		"date", "datetime":
+		return "Date"
+	case "json", "jsonb":
+		return "Record<string, unknown>"
+	case "bytea", "blob", "binary", "varbinary":
+		return "Buffer"
+	default:
+		// bigint and numeric are returned as strings to avoid precision loss
+		return "string"
+	}
+}
+
+// typeormDefault converts a DBML default value to a TypeORM default option.
+// Literals are passed as values; anything else is a raw SQL expression.
+func typeormDefault(value string, databaseDefault bool) string {
+	switch {
+	case databaseDefault:
+		return fmt.Sprintf("() => %q", value)
+	case value == "true" || value == "false" || isNumeric(value):
+		return value
+	case len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"):
+		return strconv.Quote(strings.ReplaceAll(value[1:len(value)-1], "''", "'"))
+	default:
+		return fmt.Sprintf("() => %q", value)
+	}
+}
@@ -0,0 +1,50 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateTypeORMEntities(t *testing.T) {
+	project := NewProject("blog").
+		AddEnum(NewEnum("post_status", "draft", "published")).
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("email", "varchar(255)").WithUnique()).
+			AddColumn(NewColumn("created_at", "timestamp").WithDefault("now()"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "bigint")).
+			AddColumn(NewColumn("status", "post_status").WithDefault("'draft'")).
+			AddColumn(NewColumn("body", "text").WithNull())).
+		AddTable(NewTable("profiles").
+			AddColumn(NewColumn("user_id", "bigint").WithPrimaryKey())).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id").WithOnDelete(Cascade)).
+		AddRef(NewRef(OneToOne).From("public", "profiles", "user_id").To("public", "users", "id"))
+
+	output := project.GenerateTypeORMEntities()
+
+	expected := []string{
+		`import { Column, Entity, JoinColumn, ManyToOne, OneToMany, OneToOne, PrimaryColumn, PrimaryGeneratedColumn } from "typeorm";`,
+		"@Entity({ name: \"users\", schema: \"public\" })\nexport class User {",
+		"  @PrimaryGeneratedColumn({ type: \"bigint\" })\n  id: string;",
+		"  @Column({ type: \"varchar\", length: 255, unique: true })\n  email: string;",
+		"  @Column({ type: \"timestamp\", default: () => \"now()\" })\n  created_at: Date;",
+		"  @OneToMany(() => Post, (post) => post.user)\n  posts: Post[];",
+		"  @OneToOne(() => Profile, (profile) => profile.user)\n  profiles: Profile | null;",
+		"export class Post {",
+		"  @PrimaryColumn({ type: \"int\" })\n  id: number;",
+		"  @Column({ type: \"enum\", enum: [\"draft\", \"published\"], default: \"draft\" })\n  status: \"draft\" | \"published\";",
+		"  @Column({ type: \"text\", nullable: true })\n  body: string | null;",
+		"  @ManyToOne(() => User, (user) => user.posts, { onDelete: \"CASCADE\", nullable: false })\n" +
+			"  @JoinColumn({ name: \"user_id\", referencedColumnName: \"id\" })\n  user: User;",
+		"  @OneToOne(() => User, (user) => user.profiles, { nullable: false })\n" +
+			"  @JoinColumn({ name: \"user_id\", referencedColumnName: \"id\" })\n  user: User;",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
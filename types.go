@@ -7,38 +7,57 @@ type Project struct {
 	Note         *string
 	Tables       map[string]*Table
 	Enums        map[string]*Enum
+	Schemas      map[string]*Schema
 	TableGroups  []*TableGroup
 	Refs         []*Ref
+	TableOrder   []string // "schema.table" keys emitted first by Generate
+}
+
+// Schema represents a database schema and its metadata.
+type Schema struct {
+	Owner      *string
+	Comment    *string
+	Name       string
+	SearchPath bool // add the schema to the search_path in generated DDL
 }
 
 // Table represents a database table.
 type Table struct {
-	Alias    *string
-	Note     *string
-	Settings map[string]string
-	Schema   string
-	Name     string
-	Columns  []*Column
-	Indexes  []*Index
+	Alias            *string
+	Note             *string
+	RowLevelSecurity *bool   // PostgreSQL row-level security
+	SQLComment       *string // emitted as COMMENT ON TABLE in SQL DDL
+	Settings         map[string]string
+	StorageParams    map[string]string // PostgreSQL storage parameters, e.g. fillfactor
+	Schema           string
+	Name             string
+	Columns          []*Column
+	Indexes          []*Index
+	ExcludeFromDDL   bool // generated as DBML for documentation but skipped in SQL DDL
 }
 
 // Column represents a table column.
 type Column struct {
-	Settings  *ColumnSettings
-	Note      *string
-	InlineRef *InlineRef
-	Name      string
-	Type      string
+	Settings      *ColumnSettings
+	Note          *string
+	InlineRef     *InlineRef
+	ComputedAlias *string           // expression of a computed (generated) column
+	TypeAlias     *string           // SQL type backing a domain-specific Type, used in DDL
+	Tags          map[string]string // ORM struct tags, e.g. "gorm" -> "primaryKey"
+	Name          string
+	Type          string
 }
 
 // ColumnSettings represents all column-level settings.
 type ColumnSettings struct {
-	Default    *string
-	Check      *string
-	PrimaryKey bool
-	Null       bool
-	Unique     bool
-	Increment  bool
+	Default         *string
+	Check           *string
+	DefaultKind     DefaultKind // how Default is written in DBML; empty writes it as-is
+	PrimaryKey      bool
+	Null            bool
+	Unique          bool
+	Increment       bool
+	DatabaseDefault bool // default assigned by the database (sequence, trigger)
 }
 
 // Index represents a table index.
@@ -59,13 +78,14 @@ type IndexColumn struct {
 
 // Ref represents a relationship between tables.
 type Ref struct {
-	Name     *string
-	Left     *RefEndpoint
-	Right    *RefEndpoint
-	OnDelete *RefAction
-	OnUpdate *RefAction
-	Color    *string
-	Type     RelType
+	Name       *string
+	Left       *RefEndpoint
+	Right      *RefEndpoint
+	OnDelete   *RefAction
+	OnUpdate   *RefAction
+	Color      *string
+	Deferrable *DeferrableMode // PostgreSQL deferrable constraint
+	Type       RelType
 }
 
 // RefEndpoint represents one side of a relationship.
@@ -104,18 +124,39 @@ const (
 	NoAction   RefAction = "no action"
 )
 
+// DefaultKind describes the kind of value a column default holds.
+type DefaultKind string
+
+const (
+	DefaultKindExpression DefaultKind = "expression" // function call or expression, written in backticks
+	DefaultKindString     DefaultKind = "string"
+	DefaultKindNumber     DefaultKind = "number"
+	DefaultKindBoolean    DefaultKind = "boolean"
+	DefaultKindNull       DefaultKind = "null"
+)
+
+// DeferrableMode controls when a deferrable constraint is checked.
+type DeferrableMode string
+
+const (
+	DeferrableInitiallyDeferred  DeferrableMode = "initially deferred"
+	DeferrableInitiallyImmediate DeferrableMode = "initially immediate"
+)
+
 // Enum represents an enumeration type.
 type Enum struct {
-	Note   *string
-	Schema string
-	Name   string
-	Values []string
+	Note       *string
+	ValueNotes map[string]string // value -> note
+	Schema     string
+	Name       string
+	Values     []string
 }
 
 // TableGroup represents a logical grouping of tables.
 type TableGroup struct {
-	Name   string
-	Tables []TableRef // references to tables by schema.name
+	ParentGroup *string // enclosing group; DBML has no nested groups, so it is not generated
+	Name        string
+	Tables      []TableRef // references to tables by schema.name
 }
 
 // TableRef references a table by schema and name.
@@ -1,6 +1,7 @@
 package dbml
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -1511,3 +1512,1296 @@ func TestFormatRefEndpoint(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateWithOptions(t *testing.T) {
+	t.Run("omit not null on primary key", func(t *testing.T) {
+		project := NewProject("test")
+
+		users := NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar"))
+
+		project.AddTable(users)
+
+		output := project.GenerateWithOptions(GenerateOptions{OmitRedundantConstraints: true})
+
+		if !strings.Contains(output, "id bigint [pk]") {
+			t.Errorf("Expected output to contain 'id bigint [pk]', got:\n%s", output)
+		}
+
+		if !strings.Contains(output, "email varchar [not null]") {
+			t.Errorf("Expected output to contain 'email varchar [not null]', got:\n%s", output)
+		}
+	})
+
+	t.Run("omit pk covered by primary key index", func(t *testing.T) {
+		project := NewProject("test")
+
+		users := NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddIndex(NewIndex("id").WithPrimaryKey())
+
+		project.AddTable(users)
+
+		output := project.GenerateWithOptions(GenerateOptions{OmitRedundantConstraints: true})
+
+		if strings.Contains(output, "id bigint [") {
+			t.Errorf("Expected column settings to be omitted, got:\n%s", output)
+		}
+	})
+
+	t.Run("default options keep constraints", func(t *testing.T) {
+		project := NewProject("test")
+
+		users := NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey())
+
+		project.AddTable(users)
+
+		output := project.Generate()
+
+		if !strings.Contains(output, "id bigint [pk, not null]") {
+			t.Errorf("Expected output to contain 'id bigint [pk, not null]', got:\n%s", output)
+		}
+	})
+}
+
+func TestTableValidatePrimaryKeyConflict(t *testing.T) {
+	t.Run("column pk and pk index on same column", func(t *testing.T) {
+		table := NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddIndex(NewIndex("id").WithPrimaryKey())
+
+		err := table.Validate()
+		if err == nil {
+			t.Fatal("Expected validation error for redundant primary key")
+		}
+
+		if !strings.Contains(err.Error(), "column id") {
+			t.Errorf("Expected error to name the conflicting column, got: %v", err)
+		}
+	})
+
+	t.Run("pk index on other column", func(t *testing.T) {
+		table := NewTable("users").
+			AddColumn(NewColumn("id", "bigint").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar")).
+			AddIndex(NewIndex("email").WithUnique())
+
+		if err := table.Validate(); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+}
+
+func TestTableCompositeIndexShorthands(t *testing.T) {
+	table := NewTable("memberships").
+		AddColumn(NewColumn("user_id", "int")).
+		AddColumn(NewColumn("group_id", "int")).
+		AddCompositePrimaryKey("user_id", "group_id").
+		AddCompositeUniqueIndex("uq_memberships_group_user", "group_id", "user_id")
+
+	if len(table.Indexes) != 2 {
+		t.Fatalf("Expected 2 indexes, got %d", len(table.Indexes))
+	}
+
+	pk := table.Indexes[0]
+	if !pk.PrimaryKey || len(pk.Columns) != 2 || *pk.Columns[0].Name != "user_id" {
+		t.Errorf("Expected composite primary key on (user_id, group_id), got %+v", pk)
+	}
+
+	unique := table.Indexes[1]
+	if !unique.Unique || unique.Name == nil || *unique.Name != "uq_memberships_group_user" {
+		t.Errorf("Expected named unique index, got %+v", unique)
+	}
+
+	if len(unique.Columns) != 2 || *unique.Columns[1].Name != "user_id" {
+		t.Errorf("Expected unique index on (group_id, user_id), got %+v", unique.Columns)
+	}
+}
+
+func TestTableValidateIndexColumns(t *testing.T) {
+	t.Run("index on missing column", func(t *testing.T) {
+		table := NewTable("users").
+			AddColumn(NewColumn("email", "varchar")).
+			AddIndex(NewIndex("emial"))
+
+		err := table.Validate()
+		if err == nil {
+			t.Fatal("Expected validation error for index on missing column")
+		}
+
+		if !strings.Contains(err.Error(), "emial") {
+			t.Errorf("Expected error to name the missing column, got: %v", err)
+		}
+	})
+
+	t.Run("expression index is not checked", func(t *testing.T) {
+		table := NewTable("users").
+			AddColumn(NewColumn("email", "varchar")).
+			AddIndex(NewExpressionIndex("lower(email)"))
+
+		if err := table.Validate(); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+}
+
+func TestProjectWithDefaultDatabaseType(t *testing.T) {
+	project := NewProject("test").WithDefaultDatabaseType("PostgreSQL")
+	if project.DatabaseType == nil || *project.DatabaseType != "PostgreSQL" {
+		t.Error("Expected DatabaseType 'PostgreSQL' when unset")
+	}
+
+	project = NewProject("test").WithDatabaseType("MySQL").WithDefaultDatabaseType("PostgreSQL")
+	if *project.DatabaseType != "MySQL" {
+		t.Errorf("Expected explicit DatabaseType to be kept, got '%s'", *project.DatabaseType)
+	}
+}
+
+func TestTableWithRowLevelSecurity(t *testing.T) {
+	table := NewTable("documents").
+		WithRowLevelSecurity(true).
+		AddColumn(NewColumn("tenant_id", "uuid"))
+
+	if table.RowLevelSecurity == nil || !*table.RowLevelSecurity {
+		t.Fatal("Expected RowLevelSecurity to be true")
+	}
+
+	output := table.Generate()
+	if !strings.Contains(output, "Table documents [rls: enabled] {") {
+		t.Errorf("Expected output to contain 'rls: enabled', got:\n%s", output)
+	}
+
+	output = NewTable("documents").WithRowLevelSecurity(false).
+		AddColumn(NewColumn("id", "int")).Generate()
+	if !strings.Contains(output, "rls: disabled") {
+		t.Errorf("Expected output to contain 'rls: disabled', got:\n%s", output)
+	}
+}
+
+func TestIdentifierCheckedConstructors(t *testing.T) {
+	t.Run("valid identifiers", func(t *testing.T) {
+		table, err := NewTableE("user_accounts")
+		if err != nil || table.Name != "user_accounts" {
+			t.Fatalf("Expected table, got %v, %v", table, err)
+		}
+
+		if _, err := table.WithSchemaE("auth"); err != nil || table.Schema != "auth" {
+			t.Errorf("Expected schema 'auth', got '%s', %v", table.Schema, err)
+		}
+
+		if _, err := NewColumnE("_id", "int"); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if _, err := NewIndexE("a", "b2"); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		if _, err := NewEnumE("Status", "a"); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("invalid identifiers", func(t *testing.T) {
+		invalid := []string{"", "1users", "users; DROP TABLE x", "auth.users", "user-name"}
+
+		for _, name := range invalid {
+			if _, err := NewTableE(name); err == nil {
+				t.Errorf("Expected NewTableE(%q) to fail", name)
+			}
+			if _, err := NewTable("users").WithSchemaE(name); err == nil {
+				t.Errorf("Expected WithSchemaE(%q) to fail", name)
+			}
+			if _, err := NewColumnE(name, "int"); err == nil {
+				t.Errorf("Expected NewColumnE(%q) to fail", name)
+			}
+			if _, err := NewIndexE("id", name); err == nil {
+				t.Errorf("Expected NewIndexE(%q) to fail", name)
+			}
+			if _, err := NewEnumE(name); err == nil {
+				t.Errorf("Expected NewEnumE(%q) to fail", name)
+			}
+		}
+	})
+
+	t.Run("failed schema leaves table unchanged", func(t *testing.T) {
+		table := NewTable("users")
+		if _, err := table.WithSchemaE("bad schema"); err == nil {
+			t.Fatal("Expected error")
+		}
+		if table.Schema != "public" {
+			t.Errorf("Expected schema to remain 'public', got '%s'", table.Schema)
+		}
+	})
+}
+
+func TestProjectSchemas(t *testing.T) {
+	owner := "app"
+	project := NewProject("test").
+		AddSchema(NewSchema("public")).
+		AddSchema(&Schema{Name: "auth", Owner: &owner})
+
+	schema, ok := project.GetSchema("auth")
+	if !ok || schema.Owner == nil || *schema.Owner != "app" {
+		t.Errorf("Expected registered schema 'auth' owned by 'app', got %+v", schema)
+	}
+
+	if _, ok := project.GetSchema("billing"); ok {
+		t.Error("Expected unregistered schema lookup to fail")
+	}
+
+	t.Run("validate registered schemas", func(t *testing.T) {
+		project.AddTable(NewTable("users").WithSchema("auth").AddColumn(NewColumn("id", "int")))
+		project.AddEnum(NewEnum("status", "active"))
+
+		if err := project.Validate(); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("validate unregistered table schema", func(t *testing.T) {
+		project.AddTable(NewTable("invoices").WithSchema("billing").AddColumn(NewColumn("id", "int")))
+
+		err := project.Validate()
+		if err == nil || !strings.Contains(err.Error(), "billing") {
+			t.Errorf("Expected error for unregistered schema 'billing', got: %v", err)
+		}
+	})
+
+	t.Run("validate unregistered enum schema", func(t *testing.T) {
+		p := NewProject("test").
+			AddSchema(NewSchema("public")).
+			AddEnum(NewEnum("role", "admin").WithSchema("auth"))
+
+		if err := p.Validate(); err == nil {
+			t.Error("Expected error for enum in unregistered schema")
+		}
+	})
+
+	t.Run("no registry accepts any schema", func(t *testing.T) {
+		p := NewProject("test").
+			AddTable(NewTable("invoices").WithSchema("billing").AddColumn(NewColumn("id", "int")))
+
+		if err := p.Validate(); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	})
+}
+
+func TestColumnWithTagValue(t *testing.T) {
+	col := NewColumn("id", "bigint").
+		WithTagValue("gorm", "primaryKey").
+		WithTagValue("json", "id,omitempty")
+
+	if col.Tags["gorm"] != "primaryKey" {
+		t.Errorf("Expected gorm tag 'primaryKey', got %q", col.Tags["gorm"])
+	}
+	if col.Tags["json"] != "id,omitempty" {
+		t.Errorf("Expected json tag 'id,omitempty', got %q", col.Tags["json"])
+	}
+
+	col.WithTagValue("gorm", "column:id")
+	if col.Tags["gorm"] != "column:id" {
+		t.Errorf("Expected gorm tag to be replaced, got %q", col.Tags["gorm"])
+	}
+}
+
+func TestInlineRefToRef(t *testing.T) {
+	col := NewColumn("user_id", "bigint").WithRef(ManyToOne, "public", "users", "id")
+
+	ref := col.InlineRef.ToRef("sales", "orders", "user_id")
+
+	if ref.Type != ManyToOne {
+		t.Errorf("Expected type '>', got '%s'", ref.Type)
+	}
+	if ref.Left.Schema != "sales" || ref.Left.Table != "orders" || len(ref.Left.Columns) != 1 || ref.Left.Columns[0] != "user_id" {
+		t.Errorf("Expected left endpoint sales.orders.user_id, got %+v", ref.Left)
+	}
+	if ref.Right.Schema != "public" || ref.Right.Table != "users" || len(ref.Right.Columns) != 1 || ref.Right.Columns[0] != "id" {
+		t.Errorf("Expected right endpoint public.users.id, got %+v", ref.Right)
+	}
+	if ref.Name != nil || ref.OnDelete != nil || ref.OnUpdate != nil {
+		t.Error("Expected name and actions to be unset")
+	}
+}
+
+func TestEnumWithSchemaE(t *testing.T) {
+	project := NewProject("test").AddSchema(NewSchema("public")).AddSchema(NewSchema("billing"))
+
+	t.Run("registered schema", func(t *testing.T) {
+		enum, err := NewEnum("status", "active").WithSchemaE("billing", project)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if enum.Schema != "billing" {
+			t.Errorf("Expected schema 'billing', got '%s'", enum.Schema)
+		}
+	})
+
+	t.Run("unregistered schema", func(t *testing.T) {
+		enum := NewEnum("status", "active")
+		if _, err := enum.WithSchemaE("audit", project); err == nil {
+			t.Error("Expected error for unregistered schema")
+		}
+		if enum.Schema != "public" {
+			t.Errorf("Expected schema to stay 'public', got '%s'", enum.Schema)
+		}
+	})
+
+	t.Run("invalid identifier", func(t *testing.T) {
+		if _, err := NewEnum("status", "active").WithSchemaE("bad-schema", project); err == nil {
+			t.Error("Expected error for invalid identifier")
+		}
+	})
+
+	t.Run("project without registry", func(t *testing.T) {
+		if _, err := NewEnum("status", "active").WithSchemaE("audit", NewProject("empty")); err != nil {
+			t.Errorf("Expected any schema to be accepted, got: %v", err)
+		}
+	})
+}
+
+func TestProjectSetTableOrder(t *testing.T) {
+	project := NewProject("shop").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("orders").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("audit_log").AddColumn(NewColumn("id", "int"))).
+		AddTable(NewTable("products").AddColumn(NewColumn("id", "int")))
+
+	t.Run("sorted by default", func(t *testing.T) {
+		output := project.Generate()
+		assertTableOrder(t, output, "Table audit_log", "Table orders", "Table products", "Table users")
+	})
+
+	t.Run("declared order first", func(t *testing.T) {
+		project.SetTableOrder("public.users", "public.missing", "public.orders", "public.users")
+		output := project.Generate()
+		assertTableOrder(t, output, "Table users", "Table orders", "Table audit_log", "Table products")
+
+		if strings.Count(output, "Table users") != 1 {
+			t.Errorf("Expected users to be emitted once, got:\n%s", output)
+		}
+	})
+}
+
+func assertTableOrder(t *testing.T, output string, headers ...string) {
+	t.Helper()
+	last := -1
+	for _, header := range headers {
+		pos := strings.Index(output, header+" {")
+		if pos < 0 {
+			t.Fatalf("Expected output to contain %q, got:\n%s", header, output)
+		}
+		if pos < last {
+			t.Errorf("Expected %q to follow the previous table, got:\n%s", header, output)
+		}
+		last = pos
+	}
+}
+
+func TestTableAddColumnBeforeAfter(t *testing.T) {
+	columnNames := func(table *Table) string {
+		names := make([]string, len(table.Columns))
+		for i, col := range table.Columns {
+			names[i] = col.Name
+		}
+		return strings.Join(names, ",")
+	}
+
+	newTable := func() *Table {
+		return NewTable("users").
+			AddColumn(NewColumn("id", "int")).
+			AddColumn(NewColumn("email", "varchar"))
+	}
+
+	tests := []struct {
+		apply    func(*Table) *Table
+		name     string
+		expected string
+	}{
+		{func(t *Table) *Table { return t.AddColumnBefore(NewColumn("tenant_id", "int"), "") }, "before empty", "tenant_id,id,email"},
+		{func(t *Table) *Table { return t.AddColumnBefore(NewColumn("name", "varchar"), "email") }, "before email", "id,name,email"},
+		{func(t *Table) *Table { return t.AddColumnBefore(NewColumn("name", "varchar"), "missing") }, "before missing", "id,email,name"},
+		{func(t *Table) *Table { return t.AddColumnAfter(NewColumn("name", "varchar"), "id") }, "after id", "id,name,email"},
+		{func(t *Table) *Table { return t.AddColumnAfter(NewColumn("name", "varchar"), "email") }, "after last", "id,email,name"},
+		{func(t *Table) *Table { return t.AddColumnAfter(NewColumn("name", "varchar"), "") }, "after empty", "id,email,name"},
+		{func(t *Table) *Table { return t.AddColumnAfter(NewColumn("name", "varchar"), "missing") }, "after missing", "id,email,name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.apply(newTable())
+			if got := columnNames(table); got != tt.expected {
+				t.Errorf("Expected columns %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTableWithSettings(t *testing.T) {
+	table := NewTable("users").
+		WithSetting("engine", "MyISAM").
+		WithSettings(map[string]string{"engine": "InnoDB", "charset": "utf8mb4"})
+
+	if len(table.Settings) != 2 {
+		t.Fatalf("Expected 2 settings, got %d", len(table.Settings))
+	}
+	if table.Settings["engine"] != "InnoDB" {
+		t.Errorf("Expected engine to be overwritten with 'InnoDB', got '%s'", table.Settings["engine"])
+	}
+	if table.Settings["charset"] != "utf8mb4" {
+		t.Errorf("Expected charset 'utf8mb4', got '%s'", table.Settings["charset"])
+	}
+}
+
+func TestProjectAddMigrationTable(t *testing.T) {
+	project := NewProject("app").AddMigrationTable("flyway_schema_history")
+
+	table, ok := project.Tables["public.flyway_schema_history"]
+	if !ok {
+		t.Fatal("Expected migration table in the default schema")
+	}
+	if table.Settings["migration_table"] != "true" {
+		t.Errorf("Expected migration_table setting, got %v", table.Settings)
+	}
+
+	expected := []string{"id", "version", "description", "script", "checksum",
+		"installed_by", "installed_on", "execution_time", "success"}
+	if len(table.Columns) != len(expected) {
+		t.Fatalf("Expected %d columns, got %d", len(expected), len(table.Columns))
+	}
+	for i, name := range expected {
+		if table.Columns[i].Name != name {
+			t.Errorf("Expected column %d to be '%s', got '%s'", i, name, table.Columns[i].Name)
+		}
+	}
+
+	if err := project.Validate(); err != nil {
+		t.Errorf("Expected migration table to validate, got: %v", err)
+	}
+}
+
+func TestRefWithDeferrable(t *testing.T) {
+	ref := NewRef(ManyToOne).
+		From("public", "posts", "user_id").
+		To("public", "users", "id").
+		WithDeferrable(DeferrableInitiallyImmediate)
+
+	if ref.Deferrable == nil || *ref.Deferrable != DeferrableInitiallyImmediate {
+		t.Fatalf("Expected deferrable mode 'initially immediate', got %v", ref.Deferrable)
+	}
+	if err := ref.Validate(); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	ref.WithDeferrable("sometimes")
+	if err := ref.Validate(); err == nil {
+		t.Error("Expected error for invalid deferrable mode")
+	}
+}
+
+func TestColumnWithComputedAlias(t *testing.T) {
+	col := NewColumn("full_name", "varchar").WithComputedAlias("first_name || ' ' || last_name")
+
+	if col.ComputedAlias == nil || *col.ComputedAlias != "first_name || ' ' || last_name" {
+		t.Fatalf("Expected computed expression to be set, got %v", col.ComputedAlias)
+	}
+
+	expected := `full_name varchar [not null, computed: 'first_name || \' \' || last_name']`
+	if got := col.Generate(); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestEnumSetValueNote(t *testing.T) {
+	enum := NewEnum("order_status", "pending", "in transit", "shipped").
+		SetValueNote("pending", "Awaiting payment").
+		SetValueNote("in transit", "Handed to carrier")
+
+	output := enum.Generate()
+
+	expected := []string{
+		"  pending [note: 'Awaiting payment']\n",
+		"  \"in transit\" [note: 'Handed to carrier']\n",
+		"  shipped\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestValidateWithOptions_SingleAutoIncrement(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("pairs").
+			AddColumn(NewColumn("a", "int").WithPrimaryKey().WithIncrement()).
+			AddColumn(NewColumn("b", "int").WithPrimaryKey().WithIncrement()))
+
+	if err := project.Validate(); err != nil {
+		t.Errorf("Expected no error without a dialect, got: %v", err)
+	}
+
+	if err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectPostgres}); err != nil {
+		t.Errorf("Expected no error for PostgreSQL, got: %v", err)
+	}
+
+	err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectMySQL})
+	if err == nil {
+		t.Fatal("Expected error for MySQL with two auto-increment columns")
+	}
+	if !strings.Contains(err.Error(), "auto-increment") {
+		t.Errorf("Expected auto-increment error, got: %v", err)
+	}
+
+	project.Tables["public.pairs"].Columns[1].Settings.Increment = false
+	if err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectMySQL}); err != nil {
+		t.Errorf("Expected no error with a single auto-increment column, got: %v", err)
+	}
+}
+
+func TestValidateWithOptions_MySQLIndexType(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("docs").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("body", "json")).
+			AddIndex(NewIndex("id").WithType("hash")).
+			AddIndex(NewIndex("body").WithType("gin")))
+
+	if err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectPostgres}); err != nil {
+		t.Errorf("Expected no error for PostgreSQL, got: %v", err)
+	}
+
+	err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectMySQL})
+	if err == nil || !strings.Contains(err.Error(), "Table.Indexes[1].Type") {
+		t.Errorf("Expected index type error for gin, got: %v", err)
+	}
+
+	project.Tables["public.docs"].Indexes[1].WithType("BTREE")
+	if err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectMySQL}); err != nil {
+		t.Errorf("Expected BTREE and HASH to pass, got: %v", err)
+	}
+}
+
+func TestProjectAutoCreateTableGroups(t *testing.T) {
+	newProject := func() *Project {
+		return NewProject("test").
+			AddTable(NewTable("auth_users").AddColumn(NewColumn("id", "int"))).
+			AddTable(NewTable("auth_roles").AddColumn(NewColumn("id", "int"))).
+			AddTable(NewTable("billing_invoices").AddColumn(NewColumn("id", "int"))).
+			AddTable(NewTable("settings").AddColumn(NewColumn("id", "int")))
+	}
+
+	t.Run("all prefixes", func(t *testing.T) {
+		project := newProject().AutoCreateTableGroups("_", 0)
+
+		if len(project.TableGroups) != 2 {
+			t.Fatalf("Expected 2 table groups, got %d", len(project.TableGroups))
+		}
+		auth := project.TableGroups[0]
+		if auth.Name != "Auth" || len(auth.Tables) != 2 || auth.Tables[0].Name != "auth_roles" {
+			t.Errorf("Expected Auth group with auth_roles and auth_users, got %+v", auth)
+		}
+		if project.TableGroups[1].Name != "Billing" {
+			t.Errorf("Expected Billing group, got '%s'", project.TableGroups[1].Name)
+		}
+		if err := project.Validate(); err != nil {
+			t.Errorf("Expected generated groups to validate, got: %v", err)
+		}
+	})
+
+	t.Run("minimum tables per group", func(t *testing.T) {
+		project := newProject().AutoCreateTableGroups("_", 2)
+
+		if len(project.TableGroups) != 1 || project.TableGroups[0].Name != "Auth" {
+			t.Errorf("Expected only the Auth group, got %+v", project.TableGroups)
+		}
+	})
+
+	t.Run("repeated calls", func(t *testing.T) {
+		project := newProject().AutoCreateTableGroups("_", 0).AutoCreateTableGroups("_", 0)
+
+		if len(project.TableGroups) != 2 {
+			t.Errorf("Expected existing groups not to be duplicated, got %d", len(project.TableGroups))
+		}
+	})
+
+	t.Run("multibyte prefix", func(t *testing.T) {
+		project := NewProject("test").
+			AddTable(NewTable("élèves_notes").AddColumn(NewColumn("id", "int"))).
+			AutoCreateTableGroups("_", 0)
+
+		if len(project.TableGroups) != 1 || project.TableGroups[0].Name != "Élèves" {
+			t.Errorf("Expected Élèves group, got %+v", project.TableGroups)
+		}
+	})
+}
+
+func TestProjectDefineSchema(t *testing.T) {
+	project := NewProject("test").
+		DefineSchema("billing", WithOwner("finance"), WithComment("Invoices"), WithSearchPath(true)).
+		DefineSchema("auth")
+
+	billing, ok := project.GetSchema("billing")
+	if !ok {
+		t.Fatal("Expected billing schema to be registered")
+	}
+	if billing.Owner == nil || *billing.Owner != "finance" {
+		t.Errorf("Expected owner 'finance', got %v", billing.Owner)
+	}
+	if billing.Comment == nil || *billing.Comment != "Invoices" {
+		t.Errorf("Expected comment 'Invoices', got %v", billing.Comment)
+	}
+	if !billing.SearchPath {
+		t.Error("Expected search path to be enabled")
+	}
+
+	output := project.Generate()
+
+	expected := "Schema billing {\n  owner: 'finance'\n  search_path: true\n  Note: 'Invoices'\n}\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+	}
+	if strings.Contains(output, "Schema auth") {
+		t.Errorf("Expected no block for a schema without options, got:\n%s", output)
+	}
+}
+
+func TestRefActionKeywords(t *testing.T) {
+	tests := []struct {
+		action RefAction
+		dbml   string
+		sql    string
+	}{
+		{Cascade, "cascade", "CASCADE"},
+		{Restrict, "restrict", "RESTRICT"},
+		{SetNull, "set null", "SET NULL"},
+		{SetDefault, "set default", "SET DEFAULT"},
+		{NoAction, "no action", "NO ACTION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			if got := tt.action.DBML(); got != tt.dbml {
+				t.Errorf("Expected DBML '%s', got '%s'", tt.dbml, got)
+			}
+			for _, dialect := range []SQLDialect{DialectPostgres, DialectMySQL} {
+				if got := tt.action.SQL(dialect); got != tt.sql {
+					t.Errorf("Expected %s SQL '%s', got '%s'", dialect, tt.sql, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTableWithStorageParameter(t *testing.T) {
+	table := NewTable("events").
+		WithStorageParameter("fillfactor", "70").
+		WithStorageParameter("autovacuum_enabled", "false").
+		AddColumn(NewColumn("id", "bigint"))
+
+	if table.StorageParams["fillfactor"] != "70" {
+		t.Errorf("Expected fillfactor '70', got '%s'", table.StorageParams["fillfactor"])
+	}
+
+	output := table.Generate()
+	if !strings.Contains(output, "Table events [autovacuum_enabled: false, fillfactor: 70] {") {
+		t.Errorf("Expected storage parameters in table settings, got:\n%s", output)
+	}
+}
+
+func TestWithComment_Aliases(t *testing.T) {
+	table := NewTable("users").WithComment("User accounts")
+	if table.Note == nil || *table.Note != "User accounts" {
+		t.Errorf("Expected table note 'User accounts', got %v", table.Note)
+	}
+
+	column := NewColumn("email", "varchar").WithComment("Login name")
+	if column.Note == nil || *column.Note != "Login name" {
+		t.Errorf("Expected column note 'Login name', got %v", column.Note)
+	}
+}
+
+func TestGenerator_DefaultSchema(t *testing.T) {
+	project := NewProject("test").
+		AddEnum(NewEnum("status", "active").WithSchema("app")).
+		AddTable(NewTable("users").WithSchema("app").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("audit_log").
+			AddColumn(NewColumn("user_id", "int"))).
+		AddRef(NewRef(ManyToOne).From("public", "audit_log", "user_id").To("app", "users", "id")).
+		AddTableGroup(NewTableGroup("core").AddTable("app", "users"))
+
+	output := (&Generator{DefaultSchema: "app"}).Generate(project)
+
+	expected := []string{
+		"Enum status {",
+		"Table users {",
+		"Table public.audit_log {",
+		"  public.audit_log.user_id > users.id\n",
+		"TableGroup core {\n  users\n}",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if NewGenerator().Generate(project) != project.Generate() {
+		t.Error("Expected NewGenerator to match the package-level Generate output")
+	}
+}
+
+func TestSetDefaultSchema(t *testing.T) {
+	defer SetDefaultSchema(defaultSchema)
+
+	table := NewTable("users").WithSchema("app")
+	if !strings.HasPrefix(table.Generate(), "Table app.users {") {
+		t.Errorf("Expected qualified table name, got:\n%s", table.Generate())
+	}
+
+	SetDefaultSchema("app")
+	if !strings.HasPrefix(table.Generate(), "Table users {") {
+		t.Errorf("Expected unqualified table name, got:\n%s", table.Generate())
+	}
+
+	generator := NewGenerator()
+	if generator.DefaultSchema != "public" {
+		t.Errorf("Expected new generators to keep the public default, got %s", generator.DefaultSchema)
+	}
+}
+
+func TestTable_WithPrimaryKeyTemplates(t *testing.T) {
+	table := NewTable("users").
+		AddColumn(NewColumn("email", "varchar")).
+		WithUUIDPrimaryKey()
+
+	if len(table.Columns) != 2 || table.Columns[0].Name != "id" {
+		t.Fatalf("Expected id to be prepended, got %v", table.GetColumnNames())
+	}
+	if got := table.Columns[0].Generate(); got != "id uuid [pk, not null, default: `gen_random_uuid()`]" {
+		t.Errorf("Expected uuid primary key column, got %s", got)
+	}
+
+	events := NewTable("events").
+		AddColumn(NewColumn("name", "text")).
+		WithBigIntPrimaryKey()
+
+	if events.Columns[0].Name != "id" {
+		t.Fatalf("Expected id to be prepended, got %v", events.GetColumnNames())
+	}
+	if got := events.Columns[0].Generate(); got != "id bigint [pk, not null, increment]" {
+		t.Errorf("Expected bigint primary key column, got %s", got)
+	}
+}
+
+func TestProject_ValidateTableGroupCycles(t *testing.T) {
+	group := func(name string, parent string) *TableGroup {
+		g := NewTableGroup(name).AddTable("public", "users")
+		if parent != "" {
+			g.WithParentGroup(parent)
+		}
+		return g
+	}
+
+	nested := NewProject("test").
+		AddTableGroup(group("core", "")).
+		AddTableGroup(group("auth", "core")).
+		AddTableGroup(group("sessions", "auth"))
+	if err := nested.ValidateTableGroupCycles(); err != nil {
+		t.Errorf("Expected no error for acyclic nesting, got %v", err)
+	}
+
+	self := NewProject("test").AddTableGroup(group("core", "core"))
+	if err := self.ValidateTableGroupCycles(); err == nil {
+		t.Error("Expected error for a group nested in itself")
+	}
+
+	indirect := NewProject("test").
+		AddTableGroup(group("a", "b")).
+		AddTableGroup(group("b", "c")).
+		AddTableGroup(group("c", "a"))
+	err := indirect.ValidateTableGroupCycles()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if !strings.Contains(validationErr.Message, "a -> b -> c -> a") {
+		t.Errorf("Expected cycle path in message, got %s", validationErr.Message)
+	}
+
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int"))).
+		AddTableGroup(group("a", "b")).
+		AddTableGroup(group("b", "a"))
+	if err := project.Validate(); err == nil {
+		t.Error("Expected Validate to report the table group cycle")
+	}
+}
+
+func TestGenerateOptions_CompactSingleColumnIndexes(t *testing.T) {
+	table := NewTable("users").
+		AddColumn(NewColumn("id", "int")).
+		AddColumn(NewColumn("email", "varchar")).
+		AddIndex(NewIndex("email").WithUnique()).
+		AddIndex(NewIndex("id", "email")).
+		AddIndex(NewExpressionIndex("lower(email)"))
+	project := NewProject("test").AddTable(table)
+
+	output := project.GenerateWithOptions(GenerateOptions{CompactSingleColumnIndexes: true})
+	expected := []string{"    email [unique]\n", "    (id, email)\n", "    (`lower(email)`)\n"}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if !strings.Contains(project.Generate(), "    (email) [unique]\n") {
+		t.Errorf("Expected default output to keep parentheses, got:\n%s", project.Generate())
+	}
+}
+
+func TestTable_WithIndexesFromConstraints(t *testing.T) {
+	table := NewTable("memberships").
+		AddColumn(NewColumn("user_id", "int").WithPrimaryKey()).
+		AddColumn(NewColumn("team_id", "int").WithPrimaryKey()).
+		AddColumn(NewColumn("invite_code", "varchar").WithUnique()).
+		AddColumn(NewColumn("slug", "varchar").WithUnique()).
+		AddIndex(NewIndex("slug").WithUnique()).
+		WithIndexesFromConstraints()
+
+	if len(table.Indexes) != 3 {
+		t.Fatalf("Expected 3 indexes, got %d", len(table.Indexes))
+	}
+	if got := table.Indexes[1].Generate(); got != "(user_id, team_id) [pk]" {
+		t.Errorf("Expected composite pk index, got %s", got)
+	}
+	if got := table.Indexes[2].Generate(); got != "(invite_code) [unique]" {
+		t.Errorf("Expected unique index on invite_code, got %s", got)
+	}
+	for _, col := range table.Columns {
+		if col.Settings.PrimaryKey || col.Settings.Unique {
+			t.Errorf("Expected constraint flags on %s to be cleared", col.Name)
+		}
+	}
+	if err := table.Validate(); err != nil {
+		t.Errorf("Expected normalized table to validate, got %v", err)
+	}
+	if pk := table.primaryKeyColumns(); len(pk) != 2 {
+		t.Errorf("Expected 2 primary key columns, got %v", pk)
+	}
+}
+
+func TestGenerateOptions_InlineTableNotes(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			WithNote("Registered users").
+			WithSetting("headercolor", "#3498DB").
+			AddColumn(NewColumn("id", "int")))
+
+	output := project.GenerateWithOptions(GenerateOptions{InlineTableNotes: true})
+	want := "Table users [headercolor: #3498DB, note: 'Registered users'] {\n  id int [not null]\n}\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+	}
+	if strings.Contains(output, "Note:") {
+		t.Errorf("Expected no block note, got:\n%s", output)
+	}
+
+	if !strings.Contains(project.Generate(), "\n  Note: 'Registered users'\n") {
+		t.Errorf("Expected default output to keep the block note, got:\n%s", project.Generate())
+	}
+}
+
+func TestValidateNameLength(t *testing.T) {
+	long := strings.Repeat("a", 64)
+
+	if err := NewTable(long[:63]).ValidateNameLength(DialectPostgres); err != nil {
+		t.Errorf("Expected 63-byte name to pass on PostgreSQL, got %v", err)
+	}
+	if err := NewTable(long).ValidateNameLength(DialectPostgres); err == nil {
+		t.Error("Expected 64-byte name to fail on PostgreSQL")
+	}
+	if err := NewTable(long).ValidateNameLength(DialectMySQL); err != nil {
+		t.Errorf("Expected 64-character name to pass on MySQL, got %v", err)
+	}
+
+	// 32 two-byte characters: 64 bytes but 32 characters
+	wide := strings.Repeat("é", 32)
+	if err := NewColumn(wide, "int").ValidateNameLength(DialectPostgres); err == nil {
+		t.Error("Expected 64-byte column name to fail on PostgreSQL")
+	}
+	if err := NewColumn(wide, "int").ValidateNameLength(DialectMySQL); err != nil {
+		t.Errorf("Expected 32-character column name to pass on MySQL, got %v", err)
+	}
+
+	if err := NewIndex("id").ValidateNameLength(DialectPostgres); err != nil {
+		t.Errorf("Expected unnamed index to pass, got %v", err)
+	}
+	if err := NewIndex("id").WithName(long).ValidateNameLength(DialectPostgres); err == nil {
+		t.Error("Expected long index name to fail on PostgreSQL")
+	}
+	if err := NewTable(long + long).ValidateNameLength(""); err != nil {
+		t.Errorf("Expected no limit without a dialect, got %v", err)
+	}
+
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn(long, "int")))
+	if err := project.Validate(); err != nil {
+		t.Errorf("Expected Validate without dialect to skip length checks, got %v", err)
+	}
+	err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectPostgres})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "Column.Name" {
+		t.Errorf("Expected Column.Name length error, got %v", err)
+	}
+}
+
+func TestColumn_WithDefault_DetectsKind(t *testing.T) {
+	tests := []struct {
+		value    string
+		kind     DefaultKind
+		expected string
+	}{
+		{"now()", DefaultKindExpression, "default: `now()`"},
+		{"'draft'", DefaultKindString, "default: 'draft'"},
+		{"", DefaultKindString, "default: ''"},
+		{"42", DefaultKindNumber, "default: 42"},
+		{"-1.5", DefaultKindNumber, "default: -1.5"},
+		{"true", DefaultKindBoolean, "default: true"},
+		{"FALSE", DefaultKindBoolean, "default: FALSE"},
+		{"null", DefaultKindNull, "default: null"},
+		{"NaN", DefaultKindExpression, "default: `NaN`"},
+		{"Inf", DefaultKindExpression, "default: `Inf`"},
+		{"Infinity", DefaultKindExpression, "default: `Infinity`"},
+	}
+
+	for _, tt := range tests {
+		col := NewColumn("c", "text").WithDefault(tt.value)
+
+		if col.Settings.DefaultKind != tt.kind {
+			t.Errorf("Expected %q to be detected as %s, got %s", tt.value, tt.kind, col.Settings.DefaultKind)
+		}
+		if output := col.Generate(); !strings.Contains(output, tt.expected) {
+			t.Errorf("Expected %q in output, got %s", tt.expected, output)
+		}
+	}
+}
+
+func TestColumn_WithDefault_QuotedString(t *testing.T) {
+	tests := map[string]string{
+		"'it''s'":    `default: 'it\'s'`,
+		"''''":       `default: '\''`,
+		"'a''''b'":   `default: 'a\'\'b'`,
+		"'no quote'": "default: 'no quote'",
+	}
+
+	for value, expected := range tests {
+		project := NewProject("test").
+			AddTable(NewTable("notes").
+				AddColumn(NewColumn("body", "text").WithDefault(value)))
+
+		output := project.Generate()
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got:\n%s", expected, output)
+		}
+		if _, err := Parse(output); err != nil {
+			t.Errorf("Expected generated DBML to parse, got %v\n%s", err, output)
+		}
+	}
+}
+
+func TestColumn_WithDefaultKind(t *testing.T) {
+	col := NewColumn("status", "text").WithDefaultKind("it's", DefaultKindString)
+	if output := col.Generate(); !strings.Contains(output, `default: 'it\'s'`) {
+		t.Errorf("Expected quoted string default, got %s", output)
+	}
+
+	col = NewColumn("code", "text").WithDefaultKind("42", DefaultKindString)
+	if output := col.Generate(); !strings.Contains(output, "default: '42'") {
+		t.Errorf("Expected numeric string to be quoted, got %s", output)
+	}
+
+	// Defaults set without a kind are written as-is
+	col = NewColumn("created_at", "timestamp")
+	value := "now()"
+	col.Settings.Default = &value
+	if output := col.Generate(); !strings.Contains(output, "default: now()") {
+		t.Errorf("Expected default written as-is, got %s", output)
+	}
+}
+
+func TestColumn_DefaultKind_SQL(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("status", "text").WithDefaultKind("active", DefaultKindString)).
+			AddColumn(NewColumn("nickname", "text").WithDefault("")).
+			AddColumn(NewColumn("created_at", "timestamp").WithDefaultKind("`now()`", DefaultKindExpression)))
+
+	tests := map[string]struct {
+		output   string
+		expected []string
+	}{
+		"postgres": {project.GeneratePostgresSQL(), []string{`"status" text NOT NULL DEFAULT 'active'`, `"nickname" text NOT NULL DEFAULT ''`, "DEFAULT now()"}},
+		"mysql":    {project.GenerateMySQLSQL(), []string{"DEFAULT 'active'", "DEFAULT ''", "DEFAULT (now())"}},
+	}
+
+	for name, tt := range tests {
+		for _, want := range tt.expected {
+			if !strings.Contains(tt.output, want) {
+				t.Errorf("Expected %s output to contain %q, got:\n%s", name, want, tt.output)
+			}
+		}
+	}
+
+	empty := NewProject("test").
+		AddTable(NewTable("users").
+			AddColumn(NewColumn("nickname", "text").WithDefault("")))
+	if err := empty.RoundTripCheck(); err != nil {
+		t.Errorf("Expected an empty default to round trip, got %v", err)
+	}
+
+	blank := ""
+	col := NewColumn("note", "text")
+	col.Settings.Default = &blank
+	if err := col.Validate(); err == nil {
+		t.Error("Expected error for an empty default without a kind, got nil")
+	}
+}
+
+func TestProject_ValidateWithOptions_EnforceUniqueRefNames(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("author_id", "int")).
+			AddColumn(NewColumn("editor_id", "int"))).
+		AddRef(NewRef(ManyToOne).WithName("fk_posts_user").From("public", "posts", "author_id").To("public", "users", "id")).
+		AddRef(NewRef(ManyToOne).WithName("fk_posts_user").From("public", "posts", "editor_id").To("public", "users", "id")).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "id").To("public", "users", "id"))
+
+	if err := project.Validate(); err != nil {
+		t.Errorf("Expected duplicate ref names to be allowed by default, got %v", err)
+	}
+
+	err := project.ValidateWithOptions(ValidationOptions{EnforceUniqueRefNames: true})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "Ref.Name" {
+		t.Fatalf("Expected Ref.Name validation error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "fk_posts_user") {
+		t.Errorf("Expected error to name the duplicate, got %v", err)
+	}
+
+	project.Refs[1].WithName("fk_posts_editor")
+	if err := project.ValidateWithOptions(ValidationOptions{EnforceUniqueRefNames: true}); err != nil {
+		t.Errorf("Expected unique ref names to pass, got %v", err)
+	}
+}
+
+func TestProject_ValidateWithOptions_MySQLSetDefault(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("users").AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("author_id", "int").WithDefault("0"))).
+		AddRef(NewRef(ManyToOne).From("public", "posts", "author_id").To("public", "users", "id").WithOnUpdate(SetDefault))
+
+	if err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectPostgres}); err != nil {
+		t.Errorf("Expected set default to be allowed for PostgreSQL, got %v", err)
+	}
+
+	err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectMySQL})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "Ref.OnUpdate" {
+		t.Fatalf("Expected Ref.OnUpdate validation error, got %v", err)
+	}
+
+	project.Refs[0].WithOnUpdate(Restrict)
+	if err := project.ValidateWithOptions(ValidationOptions{Dialect: DialectMySQL}); err != nil {
+		t.Errorf("Expected restrict to pass for MySQL, got %v", err)
+	}
+}
+
+func TestTable_WithForeignKeyIndex(t *testing.T) {
+	table := NewTable("posts").
+		AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+		AddColumn(NewColumn("user_id", "int")).
+		WithForeignKeyIndex("user_id").
+		WithForeignKeyIndex("missing")
+
+	if len(table.Indexes) != 1 {
+		t.Fatalf("Expected 1 index, got %d", len(table.Indexes))
+	}
+	idx := table.Indexes[0]
+	if idx.Name == nil || *idx.Name != "idx_posts_user_id" {
+		t.Errorf("Expected index name idx_posts_user_id, got %v", idx.Name)
+	}
+	if !idx.ContainsColumn("user_id") || idx.Unique || idx.PrimaryKey {
+		t.Errorf("Expected plain index on user_id, got %+v", idx)
+	}
+}
+
+func TestTable_AddIndexIfNotExists(t *testing.T) {
+	table := NewTable("posts").
+		AddIndex(NewIndex("user_id", "created_at")).
+		AddIndexIfNotExists(NewIndex("user_id", "created_at").WithUnique()).
+		AddIndexIfNotExists(NewIndex("created_at", "user_id")).
+		AddIndexIfNotExists(NewIndex("user_id", "created_at").WithName("idx_posts_user_created")).
+		AddIndexIfNotExists(NewIndex("user_id", "created_at").WithName("idx_posts_user_created"))
+
+	if len(table.Indexes) != 3 {
+		t.Fatalf("Expected 3 indexes, got %d", len(table.Indexes))
+	}
+	if table.Indexes[0].Unique {
+		t.Error("Expected existing index to be kept unchanged")
+	}
+	if !table.Indexes[1].ContainsColumn("created_at") || *table.Indexes[1].Columns[0].Name != "created_at" {
+		t.Errorf("Expected reordered columns to be added, got %+v", table.Indexes[1].Columns)
+	}
+	if name := table.Indexes[2].Name; name == nil || *name != "idx_posts_user_created" {
+		t.Errorf("Expected named index to be added once, got %v", name)
+	}
+}
+
+func TestNewEnumFromSlice(t *testing.T) {
+	values := []string{"pending", "shipped"}
+	enum := NewEnumFromSlice("order_status", "sales", values)
+
+	if enum.Name != "order_status" || enum.Schema != "sales" {
+		t.Errorf("Expected sales.order_status, got %s.%s", enum.Schema, enum.Name)
+	}
+	if len(enum.Values) != 2 || enum.Values[1] != "shipped" {
+		t.Errorf("Expected values %v, got %v", values, enum.Values)
+	}
+
+	values[0] = "changed"
+	if enum.Values[0] != "pending" {
+		t.Error("Expected enum values to be copied from the slice")
+	}
+
+	if enum := NewEnumFromSlice("status", "", nil); enum.Schema != "public" {
+		t.Errorf("Expected default schema public, got %s", enum.Schema)
+	}
+}
+
+func TestProject_GenerateDBMLv2(t *testing.T) {
+	project := NewProject("test").
+		WithNote("Line one\nLine two").
+		AddTable(NewTable("users").
+			WithNote("Single line").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey().WithNote("First\nSecond"))).
+		AddTable(NewTable("posts").
+			AddColumn(NewColumn("user_id", "int"))).
+		AddRef(NewRef(ManyToOne).WithName("fk_posts_user").WithOnDelete(Cascade).
+			From("public", "posts", "user_id").To("public", "users", "id"))
+
+	output := project.GenerateDBMLv2()
+
+	expected := []string{
+		"  Note: '''Line one\nLine two'''\n",
+		"  Note: 'Single line'\n",
+		"id int [pk, not null, note: '''First\nSecond''']",
+		"Ref fk_posts_user: posts.user_id > users.id [delete: cascade]\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected v2 output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	v1 := project.GenerateWithOptions(GenerateOptions{DBMLVersion: DBMLv1})
+	if v1 != project.Generate() {
+		t.Error("Expected DBMLv1 to match the default output")
+	}
+	if !strings.Contains(v1, "Ref fk_posts_user [delete: cascade] {\n  posts.user_id > users.id\n}\n") {
+		t.Errorf("Expected v1 block ref syntax, got:\n%s", v1)
+	}
+	if strings.Contains(v1, "'''") {
+		t.Error("Expected v1 output not to use triple quotes")
+	}
+}
+
+func TestProject_GenerateDBMLv2_EscapesTripleQuotes(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("docs").
+			WithNote("Example:\n'''quoted'''").
+			AddColumn(NewColumn("id", "int")))
+
+	output := project.GenerateDBMLv2()
+	if !strings.Contains(output, `Note: '''Example:`+"\n"+`\'\'\'quoted\'\'\''''`) {
+		t.Errorf("Expected embedded triple quotes to be escaped, got:\n%s", output)
+	}
+
+	parsed, err := Parse(output)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v\n%s", err, output)
+	}
+	if note := parsed.Tables["public.docs"].Note; note == nil || *note != "Example:\n'''quoted'''" {
+		t.Errorf("Expected escaped note to parse back, got %v", note)
+	}
+}
+
+func TestProject_GenerateDBMLv2_EscapesTrailingQuote(t *testing.T) {
+	notes := []string{
+		"ends with quote\nit's'",
+		"two quotes\n''",
+		"four quotes\na''''b",
+	}
+
+	for _, note := range notes {
+		project := NewProject("test").
+			AddTable(NewTable("docs").
+				WithNote(note).
+				AddColumn(NewColumn("id", "int")))
+
+		output := project.GenerateDBMLv2()
+		parsed, err := Parse(output)
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v\n%s", note, err, output)
+		}
+		if got := parsed.Tables["public.docs"].Note; got == nil || *got != note {
+			t.Errorf("Expected note %q to round trip, got %v", note, got)
+		}
+	}
+}
+
+func TestRef_GenerateShorthand(t *testing.T) {
+	ref := NewRef(ManyToOne).From("public", "posts", "user_id").To("auth", "users", "id")
+	if got := ref.GenerateShorthand(); got != "Ref: posts.user_id > auth.users.id\n" {
+		t.Errorf("Expected shorthand ref, got %q", got)
+	}
+
+	composite := NewRef(OneToOne).From("public", "a", "x", "y").To("public", "b", "x", "y")
+	if got := composite.GenerateShorthand(); got != "Ref: a.(x, y) - b.(x, y)\n" {
+		t.Errorf("Expected composite shorthand ref, got %q", got)
+	}
+}
+
+func TestProject_GenerateWithOptions_UseShorthandRefs(t *testing.T) {
+	project := NewProject("test").
+		AddRef(NewRef(ManyToOne).From("public", "posts", "user_id").To("public", "users", "id")).
+		AddRef(NewRef(ManyToOne).WithName("fk_comments_post").From("public", "comments", "post_id").To("public", "posts", "id")).
+		AddRef(NewRef(ManyToOne).WithOnDelete(Cascade).From("public", "likes", "post_id").To("public", "posts", "id")).
+		AddRef(NewRef(ManyToOne).WithColor("#f00").From("public", "tags", "post_id").To("public", "posts", "id"))
+
+	output := project.GenerateWithOptions(GenerateOptions{UseShorthandRefs: true})
+
+	expected := []string{
+		"Ref: posts.user_id > users.id\n",
+		"Ref fk_comments_post {\n  comments.post_id > posts.id\n}\n",
+		"Ref [delete: cascade] {\n  likes.post_id > posts.id\n}\n",
+		"Ref [color: #f00] {\n  tags.post_id > posts.id\n}\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(project.Generate(), "Ref: ") {
+		t.Error("Expected block syntax by default")
+	}
+}
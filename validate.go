@@ -2,8 +2,14 @@ package dbml
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
+// identifierPattern matches plain SQL identifiers.
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 // ValidationError represents a validation error.
 type ValidationError struct {
 	Field   string
@@ -14,15 +20,32 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// ValidationOptions enables optional validation checks.
+type ValidationOptions struct {
+	// Dialect enables dialect-specific checks; empty skips them.
+	Dialect SQLDialect
+	// EnforceUniqueRefNames rejects standalone refs that share a name.
+	EnforceUniqueRefNames bool
+}
+
 // Validate validates a Project.
 func (p *Project) Validate() error {
+	return p.ValidateWithOptions(ValidationOptions{})
+}
+
+// ValidateWithOptions validates a Project, including the optional checks
+// enabled by opts.
+func (p *Project) ValidateWithOptions(opts ValidationOptions) error {
 	if p.Name == "" {
 		return &ValidationError{Field: "Project.Name", Message: "name is required"}
 	}
 
 	// Validate all tables
 	for key, table := range p.Tables {
-		if err := table.Validate(); err != nil {
+		if err := table.ValidateWithOptions(opts); err != nil {
+			return fmt.Errorf("table %s: %w", key, err)
+		}
+		if err := p.validateSchemaRegistered("Table.Schema", table.Schema); err != nil {
 			return fmt.Errorf("table %s: %w", key, err)
 		}
 	}
@@ -32,6 +55,9 @@ func (p *Project) Validate() error {
 		if err := enum.Validate(); err != nil {
 			return fmt.Errorf("enum %s: %w", key, err)
 		}
+		if err := p.validateSchemaRegistered("Enum.Schema", enum.Schema); err != nil {
+			return fmt.Errorf("enum %s: %w", key, err)
+		}
 	}
 
 	// Validate all refs
@@ -40,6 +66,39 @@ func (p *Project) Validate() error {
 			return fmt.Errorf("ref %d: %w", i, err)
 		}
 	}
+	// InnoDB rejects foreign keys with SET DEFAULT actions
+	if opts.Dialect == DialectMySQL {
+		for i, ref := range p.Refs {
+			field := ""
+			switch {
+			case ref.OnDelete != nil && *ref.OnDelete == SetDefault:
+				field = "Ref.OnDelete"
+			case ref.OnUpdate != nil && *ref.OnUpdate == SetDefault:
+				field = "Ref.OnUpdate"
+			default:
+				continue
+			}
+			return fmt.Errorf("ref %d: %w", i, &ValidationError{
+				Field:   field,
+				Message: "set default is not supported by MySQL; use set null, restrict or no action",
+			})
+		}
+	}
+	if opts.EnforceUniqueRefNames {
+		seen := make(map[string]int, len(p.Refs))
+		for i, ref := range p.Refs {
+			if ref.Name == nil {
+				continue
+			}
+			if first, ok := seen[*ref.Name]; ok {
+				return fmt.Errorf("ref %d: %w", i, &ValidationError{
+					Field:   "Ref.Name",
+					Message: fmt.Sprintf("duplicate ref name %s (also used by ref %d)", *ref.Name, first),
+				})
+			}
+			seen[*ref.Name] = i
+		}
+	}
 
 	// Validate all table groups
 	for i, group := range p.TableGroups {
@@ -48,11 +107,53 @@ func (p *Project) Validate() error {
 		}
 	}
 
+	return p.ValidateTableGroupCycles()
+}
+
+// ValidateTableGroupCycles reports a table group that is nested inside
+// itself, directly or through other groups, via ParentGroup.
+func (p *Project) ValidateTableGroupCycles() error {
+	parents := make(map[string]string, len(p.TableGroups))
+	for _, group := range p.TableGroups {
+		if group.ParentGroup != nil {
+			parents[group.Name] = *group.ParentGroup
+		}
+	}
+
+	for _, group := range p.TableGroups {
+		path := []string{group.Name}
+		seen := map[string]bool{group.Name: true}
+		for name := group.Name; ; {
+			parent, ok := parents[name]
+			if !ok {
+				break
+			}
+			path = append(path, parent)
+			if parent == group.Name {
+				return &ValidationError{
+					Field:   "TableGroup.ParentGroup",
+					Message: fmt.Sprintf("table group %s is nested inside itself: %s", group.Name, strings.Join(path, " -> ")),
+				}
+			}
+			if seen[parent] {
+				break // a cycle that does not include this group is reported for its own members
+			}
+			seen[parent] = true
+			name = parent
+		}
+	}
+
 	return nil
 }
 
 // Validate validates a Table.
 func (t *Table) Validate() error {
+	return t.ValidateWithOptions(ValidationOptions{})
+}
+
+// ValidateWithOptions validates a Table, including the optional checks
+// enabled by opts.
+func (t *Table) ValidateWithOptions(opts ValidationOptions) error {
 	if t.Name == "" {
 		return &ValidationError{Field: "Table.Name", Message: "name is required"}
 	}
@@ -79,9 +180,133 @@ func (t *Table) Validate() error {
 		}
 	}
 
+	// Index columns must refer to columns of this table
+	columnNames := make(map[string]bool, len(t.Columns))
+	for _, col := range t.Columns {
+		columnNames[col.Name] = true
+	}
+	for i, idx := range t.Indexes {
+		for j, col := range idx.Columns {
+			if col.Name != nil && !columnNames[*col.Name] {
+				return &ValidationError{
+					Field:   fmt.Sprintf("Table.Indexes[%d].Columns[%d]", i, j),
+					Message: fmt.Sprintf("column %s does not exist in table %s", *col.Name, t.Name),
+				}
+			}
+		}
+	}
+
+	// A column-level pk and a pk index on the same column are redundant
+	for _, col := range t.Columns {
+		if col.Settings == nil || !col.Settings.PrimaryKey {
+			continue
+		}
+		for i, idx := range t.Indexes {
+			if idx.PrimaryKey && idx.ContainsColumn(col.Name) {
+				return &ValidationError{
+					Field: fmt.Sprintf("Table.Indexes[%d]", i),
+					Message: fmt.Sprintf(
+						"column %s is marked as primary key and is also covered by a primary key index; remove one of them",
+						col.Name,
+					),
+				}
+			}
+		}
+	}
+
+	// MySQL allows a single AUTO_INCREMENT column per table
+	if opts.Dialect == DialectMySQL {
+		autoIncrement := 0
+		for _, col := range t.Columns {
+			if col.Settings != nil && col.Settings.PrimaryKey && col.Settings.Increment {
+				autoIncrement++
+			}
+		}
+		if autoIncrement > 1 {
+			return &ValidationError{
+				Field:   "Table.Columns",
+				Message: fmt.Sprintf("table %s has %d auto-increment primary key columns; MySQL allows one", t.Name, autoIncrement),
+			}
+		}
+
+		// MySQL supports only BTREE and HASH index types
+		for i, idx := range t.Indexes {
+			if idx.Type != nil && !mysqlIndexTypes[strings.ToUpper(*idx.Type)] {
+				return &ValidationError{
+					Field:   fmt.Sprintf("Table.Indexes[%d].Type", i),
+					Message: fmt.Sprintf("index type %s is not supported by MySQL; use BTREE or HASH", *idx.Type),
+				}
+			}
+		}
+	}
+
+	// Identifiers must fit the dialect's length limit
+	if opts.Dialect != "" {
+		if err := t.ValidateNameLength(opts.Dialect); err != nil {
+			return err
+		}
+		for i, col := range t.Columns {
+			if err := col.ValidateNameLength(opts.Dialect); err != nil {
+				return fmt.Errorf("column %d: %w", i, err)
+			}
+		}
+		for i, idx := range t.Indexes {
+			if err := idx.ValidateNameLength(opts.Dialect); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// identifierLimits are the maximum identifier lengths per dialect.
+// PostgreSQL counts bytes; MySQL counts characters.
+var identifierLimits = map[SQLDialect]int{
+	DialectPostgres: 63,
+	DialectMySQL:    64,
+}
+
+// validateNameLength checks name against the dialect's identifier limit.
+// Dialects without a known limit accept any length.
+func validateNameLength(field, name string, dialect SQLDialect) error {
+	limit, ok := identifierLimits[dialect]
+	if !ok {
+		return nil
+	}
+
+	length, unit := len(name), "bytes"
+	if dialect == DialectMySQL {
+		length, unit = utf8.RuneCountInString(name), "characters"
+	}
+	if length > limit {
+		return &ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("name %s is %d %s long; %s allows at most %d", name, length, unit, dialect, limit),
+		}
+	}
 	return nil
 }
 
+// ValidateNameLength checks the table name against the dialect's identifier limit.
+func (t *Table) ValidateNameLength(dialect SQLDialect) error {
+	return validateNameLength("Table.Name", t.Name, dialect)
+}
+
+// ValidateNameLength checks the column name against the dialect's identifier limit.
+func (c *Column) ValidateNameLength(dialect SQLDialect) error {
+	return validateNameLength("Column.Name", c.Name, dialect)
+}
+
+// ValidateNameLength checks an explicit index name against the dialect's
+// identifier limit. Unnamed indexes pass.
+func (i *Index) ValidateNameLength(dialect SQLDialect) error {
+	if i.Name == nil {
+		return nil
+	}
+	return validateNameLength("Index.Name", *i.Name, dialect)
+}
+
 // Validate validates a Column.
 func (c *Column) Validate() error {
 	if c.Name == "" {
@@ -92,6 +317,12 @@ func (c *Column) Validate() error {
 		return &ValidationError{Field: "Column.Type", Message: "type is required"}
 	}
 
+	// An empty default is only meaningful as the empty string literal
+	if c.Settings != nil && c.Settings.Default != nil && strings.TrimSpace(*c.Settings.Default) == "" &&
+		c.Settings.DefaultKind != DefaultKindString {
+		return &ValidationError{Field: "Column.Settings.Default", Message: "default is empty; use '' for an empty string"}
+	}
+
 	// Validate inline ref if present
 	if c.InlineRef != nil {
 		if err := c.InlineRef.Validate(); err != nil {
@@ -177,6 +408,13 @@ func (r *Ref) Validate() error {
 		}
 	}
 
+	if r.Deferrable != nil && *r.Deferrable != DeferrableInitiallyDeferred && *r.Deferrable != DeferrableInitiallyImmediate {
+		return &ValidationError{
+			Field:   "Ref.Deferrable",
+			Message: fmt.Sprintf("invalid deferrable mode: %s", *r.Deferrable),
+		}
+	}
+
 	return nil
 }
 
@@ -278,6 +516,31 @@ func (g *TableGroup) Validate() error {
 	return nil
 }
 
+// validateSchemaRegistered checks a schema name against the project's schema
+// registry. Projects without registered schemas accept any schema.
+func (p *Project) validateSchemaRegistered(field, schema string) error {
+	if len(p.Schemas) == 0 {
+		return nil
+	}
+	if _, ok := p.Schemas[schema]; !ok {
+		return &ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("schema %s is not registered in the project", schema),
+		}
+	}
+	return nil
+}
+
+func validateIdentifier(field, name string) error {
+	if !identifierPattern.MatchString(name) {
+		return &ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("invalid identifier %q: must match %s", name, identifierPattern),
+		}
+	}
+	return nil
+}
+
 func validateRefAction(action RefAction) error {
 	validActions := map[RefAction]bool{
 		Cascade:    true,
@@ -0,0 +1,207 @@
+package dbml
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// viewerAlpineURL is the Alpine.js build loaded by the schema viewer.
+const viewerAlpineURL = "https://cdn.jsdelivr.net/npm/alpinejs@3.14.1/dist/cdn.min.js"
+
+// viewerGroupColors are assigned to table groups in order.
+var viewerGroupColors = []string{"#2563eb", "#16a34a", "#d97706", "#9333ea", "#dc2626", "#0891b2"}
+
+// viewerSection is a table group, or the tables outside any group, as
+// rendered by the schema viewer.
+type viewerSection struct {
+	Name   string        `json:"name"`
+	Parent string        `json:"parent,omitempty"`
+	Color  string        `json:"color"`
+	Tables []viewerTable `json:"tables"`
+}
+
+type viewerTable struct {
+	Key     string         `json:"key"`
+	Name    string         `json:"name"`
+	Note    string         `json:"note,omitempty"`
+	Columns []viewerColumn `json:"columns"`
+	Refs    []viewerRef    `json:"refs"`
+	Related []string       `json:"related"`
+}
+
+type viewerColumn struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Note     string   `json:"note,omitempty"`
+	Settings []string `json:"settings"`
+}
+
+type viewerRef struct {
+	Target string `json:"target"`
+	Label  string `json:"label"`
+}
+
+// GenerateSchemaViewerHTML generates a self-contained HTML page that browses
+// the schema with Alpine.js, loaded from a CDN. Each table is a collapsible
+// card listing its columns and their settings; hovering a table highlights
+// the tables it is related to and the refs that connect them. Table groups
+// are rendered as colored sections, followed by any ungrouped tables.
+func (p *Project) GenerateSchemaViewerHTML() string {
+	// The sections hold only strings and slices, which always marshal
+	data, _ := json.Marshal(p.viewerSections())
+
+	title := html.EscapeString(p.Name)
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n")
+	b.WriteString("<!-- Code generated by dbml. DO NOT EDIT. -->\n")
+	b.WriteString("<html lang=\"en\">\n<head>\n")
+	b.WriteString("<meta charset=\"utf-8\">\n")
+	b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	b.WriteString(fmt.Sprintf("<title>%s</title>\n", title))
+	b.WriteString(viewerStyle)
+	b.WriteString(fmt.Sprintf("<script>\nconst schema = %s;\n</script>\n", data))
+	b.WriteString(fmt.Sprintf("<script defer src=\"%s\"></script>\n", viewerAlpineURL))
+	b.WriteString("</head>\n")
+	b.WriteString("<body x-data=\"{ hovered: null, sections: schema }\">\n")
+	b.WriteString(fmt.Sprintf("<h1>%s</h1>\n", title))
+	b.WriteString(viewerBody)
+	b.WriteString("</body>\n</html>\n")
+
+	return b.String()
+}
+
+func (p *Project) viewerSections() []viewerSection {
+	related := map[string]map[string]bool{}
+	refs := map[string][]viewerRef{}
+	for _, ref := range p.allRefs() {
+		if ref.Left == nil || ref.Right == nil {
+			continue
+		}
+		left := ref.Left.Schema + "." + ref.Left.Table
+		right := ref.Right.Schema + "." + ref.Right.Table
+		label := fmt.Sprintf("%s %s %s", qualifiedRefEndpoint(ref.Left), ref.Type, qualifiedRefEndpoint(ref.Right))
+		for _, pair := range [][2]string{{left, right}, {right, left}} {
+			if related[pair[0]] == nil {
+				related[pair[0]] = map[string]bool{}
+			}
+			related[pair[0]][pair[1]] = true
+			refs[pair[0]] = append(refs[pair[0]], viewerRef{Target: pair[1], Label: label})
+		}
+	}
+
+	table := func(key string) viewerTable {
+		t := p.Tables[key]
+		vt := viewerTable{
+			Key:     key,
+			Name:    key,
+			Columns: []viewerColumn{},
+			Refs:    refs[key],
+			Related: sortedKeys(related[key]),
+		}
+		if t.Note != nil {
+			vt.Note = *t.Note
+		}
+		if vt.Refs == nil {
+			vt.Refs = []viewerRef{}
+		}
+		for _, col := range t.Columns {
+			vc := viewerColumn{Name: col.Name, Type: col.Type, Settings: col.dbmlSettings(GenerateOptions{}, false)}
+			if col.Note != nil {
+				vc.Note = *col.Note
+			}
+			vt.Columns = append(vt.Columns, vc)
+		}
+		return vt
+	}
+
+	sections := []viewerSection{}
+	grouped := map[string]bool{}
+	for i, group := range p.TableGroups {
+		section := viewerSection{
+			Name:   group.Name,
+			Color:  viewerGroupColors[i%len(viewerGroupColors)],
+			Tables: []viewerTable{},
+		}
+		if group.ParentGroup != nil {
+			section.Parent = *group.ParentGroup
+		}
+		for _, ref := range group.Tables {
+			key := ref.Schema + "." + ref.Name
+			if _, ok := p.Tables[key]; !ok {
+				continue
+			}
+			grouped[key] = true
+			section.Tables = append(section.Tables, table(key))
+		}
+		sections = append(sections, section)
+	}
+
+	ungrouped := viewerSection{Name: "Tables", Color: "#64748b", Tables: []viewerTable{}}
+	for _, key := range sortedKeys(p.Tables) {
+		if !grouped[key] {
+			ungrouped.Tables = append(ungrouped.Tables, table(key))
+		}
+	}
+	if len(ungrouped.Tables) > 0 {
+		sections = append(sections, ungrouped)
+	}
+
+	return sections
+}
+
+const viewerStyle = `<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #0f172a; background: #f8fafc; }
+h1 { margin-top: 0; }
+section { border-left: 4px solid; padding: 0.5rem 1rem 1rem; margin-bottom: 1.5rem; background: #fff; }
+section h2 { margin: 0.5rem 0 1rem; font-size: 1.1rem; }
+section h2 small { color: #64748b; font-weight: normal; }
+.tables { display: flex; flex-wrap: wrap; gap: 1rem; align-items: flex-start; }
+.card { border: 1px solid #cbd5e1; border-radius: 6px; min-width: 16rem; background: #fff; transition: box-shadow 0.15s, border-color 0.15s; }
+.card.active { border-color: #2563eb; box-shadow: 0 0 0 3px #bfdbfe; }
+.card.related { border-color: #f59e0b; box-shadow: 0 0 0 3px #fde68a; }
+.card header { padding: 0.5rem 0.75rem; font-weight: 600; cursor: pointer; border-bottom: 1px solid #e2e8f0; }
+.card p { margin: 0.5rem 0.75rem; color: #475569; font-size: 0.85rem; }
+.card table { border-collapse: collapse; width: 100%; font-size: 0.85rem; }
+.card td { padding: 0.25rem 0.75rem; border-top: 1px solid #f1f5f9; vertical-align: top; }
+.card td.type { color: #64748b; }
+.settings span { display: inline-block; margin-right: 0.25rem; padding: 0 0.3rem; border-radius: 3px; background: #e2e8f0; font-size: 0.75rem; }
+.refs { margin: 0; padding: 0.5rem 0.75rem; list-style: none; font-size: 0.8rem; color: #64748b; }
+.refs li.highlight { color: #b45309; font-weight: 600; }
+</style>
+`
+
+const viewerBody = `<template x-for="section in sections" :key="section.name">
+  <section :style="'border-color: ' + section.color">
+    <h2><span x-text="section.name"></span> <small x-show="section.parent" x-text="'in ' + section.parent"></small></h2>
+    <div class="tables">
+      <template x-for="table in section.tables" :key="table.key">
+        <div class="card" x-data="{ open: true }"
+             :class="{ active: hovered === table.key, related: hovered !== null && table.related.includes(hovered) }"
+             @mouseenter="hovered = table.key" @mouseleave="hovered = null">
+          <header @click="open = !open" x-text="table.name"></header>
+          <div x-show="open">
+            <p x-show="table.note" x-text="table.note"></p>
+            <table>
+              <template x-for="column in table.columns" :key="column.name">
+                <tr :title="column.note">
+                  <td x-text="column.name"></td>
+                  <td class="type" x-text="column.type"></td>
+                  <td class="settings"><template x-for="setting in column.settings"><span x-text="setting"></span></template></td>
+                </tr>
+              </template>
+            </table>
+            <ul class="refs" x-show="table.refs.length">
+              <template x-for="ref in table.refs">
+                <li :class="{ highlight: hovered === table.key || hovered === ref.target }" x-text="ref.label"></li>
+              </template>
+            </ul>
+          </div>
+        </div>
+      </template>
+    </div>
+  </section>
+</template>
+`
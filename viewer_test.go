@@ -0,0 +1,81 @@
+package dbml
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestProject_GenerateSchemaViewerHTML(t *testing.T) {
+	project := NewProject("shop <beta>").
+		AddTable(NewTable("users").WithNote("Accounts").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("email", "varchar").WithUnique().WithNote("Login name"))).
+		AddTable(NewTable("orders").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey()).
+			AddColumn(NewColumn("user_id", "int").WithRef(ManyToOne, "public", "users", "id"))).
+		AddTable(NewTable("audit_log").
+			AddColumn(NewColumn("id", "int").WithPrimaryKey())).
+		AddTableGroup(NewTableGroup("core").AddTable("public", "users").AddTable("public", "orders"))
+
+	output := project.GenerateSchemaViewerHTML()
+
+	expected := []string{
+		"<!DOCTYPE html>\n",
+		"<title>shop &lt;beta&gt;</title>\n",
+		"<script defer src=\"" + viewerAlpineURL + "\"></script>\n",
+		"<style>\n",
+		"<body x-data=\"{ hovered: null, sections: schema }\">\n",
+		"x-data=\"{ open: true }\"",
+		"@mouseenter=\"hovered = table.key\"",
+		"table.related.includes(hovered)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q", want)
+		}
+	}
+
+	start := strings.Index(output, "const schema = ") + len("const schema = ")
+	end := strings.Index(output[start:], ";\n</script>")
+	var sections []viewerSection
+	if err := json.Unmarshal([]byte(output[start:start+end]), &sections); err != nil {
+		t.Fatalf("Expected embedded schema to be valid JSON, got %v", err)
+	}
+
+	if len(sections) != 2 || sections[0].Name != "core" || sections[1].Name != "Tables" {
+		t.Fatalf("Expected core group and ungrouped tables, got %+v", sections)
+	}
+	if sections[0].Color == "" || sections[0].Color == sections[1].Color {
+		t.Errorf("Expected the group to have its own color, got %q", sections[0].Color)
+	}
+	if len(sections[1].Tables) != 1 || sections[1].Tables[0].Key != "public.audit_log" {
+		t.Errorf("Expected only audit_log to be ungrouped, got %+v", sections[1].Tables)
+	}
+
+	users := sections[0].Tables[0]
+	if users.Key != "public.users" || users.Note != "Accounts" {
+		t.Errorf("Expected users table with note, got %+v", users)
+	}
+	if email := users.Columns[1]; email.Note != "Login name" || strings.Join(email.Settings, ", ") != "unique, not null" {
+		t.Errorf("Expected email settings and note, got %+v", email)
+	}
+	if len(users.Related) != 1 || users.Related[0] != "public.orders" {
+		t.Errorf("Expected users to be related to orders, got %v", users.Related)
+	}
+	if len(users.Refs) != 1 || users.Refs[0].Label != "public.orders.user_id > public.users.id" {
+		t.Errorf("Expected ref label on users, got %+v", users.Refs)
+	}
+}
+
+func TestProject_GenerateSchemaViewerHTML_EscapesScript(t *testing.T) {
+	project := NewProject("test").
+		AddTable(NewTable("notes").WithNote("</script><script>alert(1)</script>").
+			AddColumn(NewColumn("id", "int")))
+
+	output := project.GenerateSchemaViewerHTML()
+
+	if strings.Contains(output, "</script><script>alert") {
+		t.Error("Expected notes to be escaped inside the embedded schema")
+	}
+}